@@ -0,0 +1,25 @@
+// Package api embeds the OpenAPI description of the REST gateway and the
+// Swagger UI, so they can be served next to it.
+package api
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed prog/prog.swagger.json
+var SwaggerJSON []byte
+
+//go:embed swaggerui
+var swaggerUI embed.FS
+
+// SwaggerUIAssets are the files of the swagger-ui-dist package the Swagger UI
+// page loads, they're downloaded with make swagger-ui.
+var SwaggerUIAssets = []string{"swagger-ui.css", "swagger-ui-bundle.js"}
+
+// SwaggerUI returns the Swagger UI page and its assets.
+func SwaggerUI() fs.FS {
+	ui, _ := fs.Sub(swaggerUI, "swaggerui")
+
+	return ui
+}
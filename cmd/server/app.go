@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -13,29 +14,90 @@ import (
 	stdlog "log"
 
 	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/urfave/cli/v2"
 	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/authn"
+	"github.com/weaveworks/progressive-delivery/pkg/cache"
+	"github.com/weaveworks/progressive-delivery/pkg/clusters"
 	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"github.com/weaveworks/progressive-delivery/pkg/metrics"
 	"github.com/weaveworks/progressive-delivery/pkg/server"
+	"github.com/weaveworks/progressive-delivery/pkg/services/cloudevents"
 	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"github.com/weaveworks/progressive-delivery/pkg/services/history"
+	"github.com/weaveworks/progressive-delivery/pkg/services/notify"
 	"github.com/weaveworks/weave-gitops/core/clustersmngr"
 	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster"
 	"github.com/weaveworks/weave-gitops/core/clustersmngr/fetcher"
 	"github.com/weaveworks/weave-gitops/core/logger"
+	"github.com/weaveworks/weave-gitops/core/nsaccess"
 	"github.com/weaveworks/weave-gitops/core/nsaccess/nsaccessfakes"
 	"github.com/weaveworks/weave-gitops/pkg/server/auth"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 	v1 "k8s.io/api/core/v1"
+	apiruntime "k8s.io/apimachinery/pkg/runtime"
 	v1a "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 )
 
 type appConfig struct {
-	Host   string
-	Port   string
-	Logger logr.Logger
+	Host      string
+	Port      string
+	HistoryDB string
+	// HistoryRetention bounds the revisions kept in HistoryDB.
+	HistoryRetention history.Retention
+	// GatewayPort serves the REST gateway on Host if set.
+	GatewayPort string
+	SwaggerUI   bool
+	// MetricsAddress is the address of the /metrics endpoint, it's disabled
+	// if empty.
+	MetricsAddress string
+	// Auth configures how the principal of requests is derived, unless NoAuth
+	// is set and every request is served as an admin.
+	Auth   authn.Options
+	NoAuth bool
+	// KubeconfigContexts are the contexts of the kubeconfig served as
+	// clusters, instead of the management cluster.
+	KubeconfigContexts []string
+	// KubeconfigDir is a directory of kubeconfigs served as clusters.
+	KubeconfigDir string
+	// ClusterSecrets serves the clusters of the GitopsCluster and Cluster API
+	// kubeconfig secrets of the management cluster.
+	ClusterSecrets bool
+	// InformerCache reads Canaries, their Deployments and MetricTemplates
+	// from informers. It's opt-in, the server needs to watch them.
+	InformerCache bool
+	// NotificationConfig is the path of the notification receivers and rules.
+	NotificationConfig string
+	// LoadtesterHosts are the hosts of the loadtesters whose gates can be
+	// called.
+	LoadtesterHosts []string
+	// MetricProviderHosts are the hosts of the metric providers templates can
+	// be run against.
+	MetricProviderHosts []string
+	// CloudEvents configures the exporter of canary lifecycle events.
+	CloudEvents cloudEventsConfig
+	Logger      logr.Logger
+}
+
+type cloudEventsConfig struct {
+	// Sink enables the exporter if set.
+	Sink string
+	Mode cloudevents.Mode
+	// Buffer is the path of the database of undelivered events and the last
+	// progress of Canaries, they're kept in memory if empty.
+	Buffer     string
+	BufferSize int
+	// Overflow is what's done with new events once the buffer is full.
+	Overflow cloudevents.Overflow
 }
 
 func NewApp(out io.Writer) *cli.App {
@@ -53,6 +115,15 @@ func NewApp(out io.Writer) *cli.App {
 		Usage: "Progressive Delivery Server",
 		Flags: CLIFlags(
 			WithHTTPServerFlags(),
+			WithGatewayFlags(),
+			WithHistoryFlags(),
+			WithNotificationFlags(),
+			WithCanaryActionFlags(),
+			WithMetricTemplateFlags(),
+			WithCloudEventsFlags(),
+			WithMetricsFlags(),
+			WithAuthFlags(),
+			WithClustersFlags(),
 		),
 		Before: parseFlags(cfg),
 		Action: func(c *cli.Context) error {
@@ -68,7 +139,10 @@ func NewApp(out io.Writer) *cli.App {
 }
 
 func serve(cfg *appConfig) error {
-	ctx := context.Background()
+	// ctx is cancelled on shutdown, stopping the watches and the goroutines
+	// started for the server.
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
 
 	restCfg, err := config.GetConfig()
 	if err != nil {
@@ -77,32 +151,121 @@ func serve(cfg *appConfig) error {
 
 	scheme := kube.CreateScheme()
 
-	cl, err := cluster.NewSingleCluster(cluster.DefaultCluster, restCfg, scheme, cluster.DefaultKubeConfigOptions...)
+	kubeClient, err := client.New(restCfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("could not create kubernetes client: %w", err)
+	}
+
+	fetcher, err := newClusterFetcher(cfg, restCfg, kubeClient, scheme)
 	if err != nil {
-		return fmt.Errorf("unable to create single cluster: %w", err)
+		return err
 	}
 
-	fetcher := fetcher.NewSingleClusterFetcher(cl)
+	var (
+		principalGetter auth.PrincipalGetter
+		nsChecker       nsaccess.Checker
+	)
+
+	if cfg.NoAuth {
+		cfg.Logger.Info("Authentication is disabled, every request is served as an admin")
 
-	nsChecker := nsaccessfakes.FakeChecker{}
-	nsChecker.FilterAccessibleNamespacesStub = func(ctx context.Context, _ v1a.AuthorizationV1Interface, n []v1.Namespace) ([]v1.Namespace, error) {
-		// Pretend the user has access to everything
-		return n, nil
+		principalGetter = adminPrincipalGetter{}
+
+		fakeChecker := &nsaccessfakes.FakeChecker{}
+		fakeChecker.FilterAccessibleNamespacesStub = func(ctx context.Context, _ v1a.AuthorizationV1Interface, n []v1.Namespace) ([]v1.Namespace, error) {
+			// Pretend the user has access to everything
+			return n, nil
+		}
+		nsChecker = fakeChecker
+	} else {
+		principalGetter, err = authn.NewPrincipalGetter(ctx, cfg.Auth, kubeClient, cfg.Logger)
+		if err != nil {
+			return fmt.Errorf("could not configure authentication: %w", err)
+		}
+
+		nsChecker = nsaccess.NewChecker(nsaccess.DefautltWegoAppRules)
 	}
 
-	clustersManager := clustersmngr.NewClustersManager([]clustersmngr.ClusterFetcher{fetcher}, &nsChecker, cfg.Logger)
+	clustersManager := clustersmngr.NewClustersManager([]clustersmngr.ClusterFetcher{fetcher}, nsChecker, cfg.Logger)
 	clustersManager.Start(ctx)
 
 	_ = clustersManager.UpdateClusters(ctx)
 	_ = clustersManager.UpdateNamespaces(ctx)
 
+	crdService := crd.NewFetcher(ctx, cfg.Logger, clustersManager)
+
+	// Canaries are watched once for the history, notifications and
+	// CloudEvents.
+	canaryEvents := flagger.NewBroadcaster(flagger.NewFetcher(crdService, cfg.Logger), clustersManager, cfg.Logger)
+
 	opts := server.ServerOpts{
-		ClustersManager: clustersManager,
-		CRDService:      crd.NewNoCacheFetcher(clustersManager),
-		Logger:          cfg.Logger,
+		Context:             ctx,
+		ClustersManager:     clustersManager,
+		CRDService:          crdService,
+		CanaryEvents:        canaryEvents,
+		LoadtesterHosts:     cfg.LoadtesterHosts,
+		MetricProviderHosts: cfg.MetricProviderHosts,
+		Logger:              cfg.Logger,
+	}
+
+	if cfg.HistoryDB != "" {
+		store, err := history.NewBoltStore(cfg.HistoryDB, cfg.HistoryRetention)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+
+		opts.HistoryStore = store
+	}
+
+	if cfg.NotificationConfig != "" {
+		notificationConfig, err := notify.LoadConfig(cfg.NotificationConfig)
+		if err != nil {
+			return err
+		}
+
+		opts.NotificationConfig = notificationConfig
+	}
+
+	// collectors are the metrics of the services started, served with the
+	// ones of the server.
+	collectors := []prometheus.Collector{}
+
+	if cfg.CloudEvents.Sink != "" {
+		buffer := cloudevents.NewMemoryBuffer(cfg.CloudEvents.BufferSize)
+
+		if cfg.CloudEvents.Buffer != "" {
+			buffer, err = cloudevents.NewBoltBuffer(cfg.CloudEvents.Buffer, cfg.CloudEvents.BufferSize)
+			if err != nil {
+				return err
+			}
+		}
+		defer buffer.Close()
+
+		exporter := cloudevents.NewExporter(
+			cloudevents.Options{Sink: cfg.CloudEvents.Sink, Mode: cfg.CloudEvents.Mode, Overflow: cfg.CloudEvents.Overflow},
+			buffer,
+			flagger.NewFetcher(crdService, cfg.Logger),
+			clustersManager,
+			cfg.Logger,
+		)
+
+		collectors = append(collectors, exporter.Collector())
+
+		go exporter.Start(ctx, canaryEvents.Subscribe())
+	}
+
+	if cfg.InformerCache {
+		objectCache := cache.New(clustersManager, scheme, cfg.Logger)
+
+		go objectCache.Start(ctx)
+
+		opts.Cache = objectCache
 	}
 
 	pdServer, _ := server.NewProgressiveDeliveryServer(opts)
+
+	go canaryEvents.Start(ctx)
 	address := fmt.Sprintf("%s:%s", cfg.Host, cfg.Port)
 
 	lis, err := net.Listen("tcp", address)
@@ -110,18 +273,61 @@ func serve(cfg *appConfig) error {
 		return err
 	}
 
-	principal := &auth.UserPrincipal{
-		ID:     "pd-admin",
-		Groups: []string{"admin"},
+	serverOpts := []grpc.ServerOption{
+		withClientsPoolInterceptor(clustersManager, restCfg, principalGetter),
+		withClientsPoolStreamInterceptor(clustersManager, principalGetter),
 	}
-	s := grpc.NewServer(
-		withClientsPoolInterceptor(clustersManager, restCfg, principal),
-	)
+
+	httpServers := []*http.Server{}
+
+	var httpMetrics *metrics.HTTPMetrics
+
+	if cfg.MetricsAddress != "" {
+		reg := prometheus.NewRegistry()
+
+		grpcMetrics, err := metrics.NewGRPCMetrics(reg)
+		if err != nil {
+			return err
+		}
+
+		if httpMetrics, err = metrics.NewHTTPMetrics(reg); err != nil {
+			return err
+		}
+
+		collectors = append(collectors, metrics.NewCanaryCollector(clustersManager, flagger.NewFetcher(crdService, cfg.Logger), crdService, cfg.Logger))
+
+		for _, collector := range collectors {
+			if err := reg.Register(collector); err != nil {
+				return err
+			}
+		}
+
+		serverOpts = append(serverOpts,
+			grpc.ChainUnaryInterceptor(grpcMetrics.UnaryServerInterceptor()),
+			grpc.ChainStreamInterceptor(grpcMetrics.StreamServerInterceptor()),
+		)
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler(reg))
+
+		httpServers = append(httpServers, startHTTPServer(cfg, "metrics", cfg.MetricsAddress, mux))
+	}
+
+	s := grpc.NewServer(serverOpts...)
 
 	pb.RegisterProgressiveDeliveryServiceServer(s, pdServer)
 
 	reflection.Register(s)
 
+	if cfg.GatewayPort != "" {
+		handler, err := newGatewayHandler(ctx, pdServer, clustersManager, principalGetter, cfg.SwaggerUI, httpMetrics)
+		if err != nil {
+			return err
+		}
+
+		httpServers = append(httpServers, startHTTPServer(cfg, "gateway", fmt.Sprintf("%s:%s", cfg.Host, cfg.GatewayPort), handler))
+	}
+
 	go func() {
 		cfg.Logger.Info("Starting server", "address", address)
 
@@ -135,7 +341,7 @@ func serve(cfg *appConfig) error {
 	signal.Notify(quit, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	_, cancel := context.WithTimeout(ctx, 5*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 
 	defer func() {
 		cancel()
@@ -143,28 +349,138 @@ func serve(cfg *appConfig) error {
 
 	s.GracefulStop()
 
+	for _, httpServer := range httpServers {
+		_ = httpServer.Shutdown(shutdownCtx)
+	}
+
+	stop()
+
 	return nil
 }
 
-func withClientsPoolInterceptor(clustersManager clustersmngr.ClustersManager, config *rest.Config, user *auth.UserPrincipal) grpc.ServerOption {
+// newClusterFetcher returns the fetcher of the clusters the server shows. The
+// management cluster is served as the default cluster, unless kubeconfig
+// contexts are served instead.
+func newClusterFetcher(cfg *appConfig, restCfg *rest.Config, kubeClient client.Client, scheme *apiruntime.Scheme) (clustersmngr.ClusterFetcher, error) {
+	fetchers := []clustersmngr.ClusterFetcher{}
+
+	if len(cfg.KubeconfigContexts) > 0 {
+		kubeconfig := clientcmd.NewDefaultClientConfigLoadingRules().GetDefaultFilename()
+		fetchers = append(fetchers, clusters.NewKubeconfigFetcher(kubeconfig, cfg.KubeconfigContexts, scheme))
+	} else {
+		cl, err := cluster.NewSingleCluster(cluster.DefaultCluster, restCfg, scheme, cluster.DefaultKubeConfigOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create single cluster: %w", err)
+		}
+
+		fetchers = append(fetchers, fetcher.NewSingleClusterFetcher(cl))
+	}
+
+	if cfg.KubeconfigDir != "" {
+		fetchers = append(fetchers, clusters.NewDirectoryFetcher(cfg.KubeconfigDir, scheme, cfg.Logger))
+	}
+
+	if cfg.ClusterSecrets {
+		fetchers = append(fetchers, clusters.NewSecretsFetcher(kubeClient, scheme, cfg.Logger))
+	}
+
+	if len(fetchers) == 1 {
+		return fetchers[0], nil
+	}
+
+	return clusters.NewMultiFetcher(cfg.Logger, fetchers...), nil
+}
+
+func startHTTPServer(cfg *appConfig, name, address string, handler http.Handler) *http.Server {
+	httpServer := &http.Server{Addr: address, Handler: handler, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		cfg.Logger.Info("Starting "+name+" server", "address", address)
+
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			cfg.Logger.Error(err, name+" server exited")
+			os.Exit(1)
+		}
+	}()
+
+	return httpServer
+}
+
+func withClientsPoolInterceptor(clustersManager clustersmngr.ClustersManager, config *rest.Config, principalGetter auth.PrincipalGetter) grpc.ServerOption {
 	return grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		if err := clustersManager.UpdateClusters(ctx); err != nil {
-			return nil, err
+		user, err := principalGetter.Principal(authn.RequestFromIncomingContext(ctx))
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
 		}
-		if err := clustersManager.UpdateNamespaces(ctx); err != nil {
+
+		ctx, err = withClientsPool(ctx, clustersManager, user)
+		if err != nil {
 			return nil, err
 		}
 
-		clustersManager.UpdateUserNamespaces(ctx, user)
+		return handler(ctx, req)
+	})
+}
 
-		clusterClient, err := clustersManager.GetImpersonatedClient(ctx, user)
+func withClientsPoolStreamInterceptor(clustersManager clustersmngr.ClustersManager, principalGetter auth.PrincipalGetter) grpc.ServerOption {
+	return grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		user, err := principalGetter.Principal(authn.RequestFromIncomingContext(ss.Context()))
 		if err != nil {
-			return nil, err
+			return status.Error(codes.Unauthenticated, err.Error())
 		}
 
-		ctx = auth.WithPrincipal(ctx, user)
-		ctx = context.WithValue(ctx, clustersmngr.ClustersClientCtxKey, clusterClient)
+		ctx, err := withClientsPool(ss.Context(), clustersManager, user)
+		if err != nil {
+			return err
+		}
 
-		return handler(ctx, req)
+		return handler(srv, &serverStreamWithContext{ServerStream: ss, ctx: ctx})
 	})
 }
+
+// withClientsPool refreshes the clusters and namespaces, then sets the user and
+// its impersonated client on the context, the way weave-gitops does for the
+// requests it forwards to the server.
+func withClientsPool(ctx context.Context, clustersManager clustersmngr.ClustersManager, user *auth.UserPrincipal) (context.Context, error) {
+	if err := clustersManager.UpdateClusters(ctx); err != nil {
+		return nil, err
+	}
+	if err := clustersManager.UpdateNamespaces(ctx); err != nil {
+		return nil, err
+	}
+
+	clustersManager.UpdateUserNamespaces(ctx, user)
+
+	clusterClient, err := clustersManager.GetImpersonatedClient(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = auth.WithPrincipal(ctx, user)
+	ctx = context.WithValue(ctx, clustersmngr.ClustersClientCtxKey, clusterClient)
+
+	return ctx, nil
+}
+
+// adminPrincipalGetter serves every request as the same admin, when
+// authentication is disabled.
+type adminPrincipalGetter struct{}
+
+func (adminPrincipalGetter) Principal(_ *http.Request) (*auth.UserPrincipal, error) {
+	return &auth.UserPrincipal{
+		ID:     "pd-admin",
+		Groups: []string{"admin"},
+	}, nil
+}
+
+// serverStreamWithContext overrides the context of a stream, so handlers can
+// access values set by interceptors.
+type serverStreamWithContext struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s *serverStreamWithContext) Context() context.Context {
+	return s.ctx
+}
@@ -1,14 +1,77 @@
 package main
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/urfave/cli/v2"
+	"github.com/weaveworks/progressive-delivery/pkg/authn"
+	"github.com/weaveworks/progressive-delivery/pkg/services/cloudevents"
+	"github.com/weaveworks/progressive-delivery/pkg/services/history"
 )
 
 const (
 	hostFlag        = "host"
+	historyDBFlag   = "history-db"
+	gatewayPortFlag = "gateway-port"
+	swaggerUIFlag   = "swagger-ui"
+	metricsAddrFlag = "metrics-address"
 	portFlag        = "port"
 	defaultHTTPHost = "0.0.0.0"
 	defaultHTTPPort = "9002"
+	defaultGateway  = "9001"
+)
+
+const (
+	authMethodsFlag       = "auth-methods"
+	authTokenFileFlag     = "auth-token-file"
+	oidcIssuerURLFlag     = "oidc-issuer-url"
+	oidcClientIDFlag      = "oidc-client-id"
+	oidcJWKSURLFlag       = "oidc-jwks-url"
+	oidcUsernameClaimFlag = "oidc-username-claim"
+	oidcGroupsClaimFlag   = "oidc-groups-claim"
+	defaultUsernameClaim  = "email"
+	defaultGroupsClaim    = "groups"
+
+	// noAuthMethod serves every request as an admin, it's meant for local
+	// development only.
+	noAuthMethod = "none"
+)
+
+const (
+	historyMaxAgeFlag          = "history-max-age"
+	historyMaxRevisionsFlag    = "history-max-revisions"
+	defaultHistoryMaxAge       = 90 * 24 * time.Hour
+	defaultHistoryMaxRevisions = 1000
+)
+
+const (
+	kubeconfigContextsFlag = "kubeconfig-contexts"
+	kubeconfigDirFlag      = "kubeconfig-dir"
+	clusterSecretsFlag     = "cluster-secrets"
+	informerCacheFlag      = "informer-cache"
+)
+
+const (
+	notificationConfigFlag = "notification-config"
+)
+
+const (
+	loadtesterHostsFlag = "loadtester-hosts"
+)
+
+const (
+	metricProviderHostsFlag = "metric-provider-hosts"
+)
+
+const (
+	cloudEventsSinkFlag       = "cloudevents-sink"
+	cloudEventsModeFlag       = "cloudevents-mode"
+	cloudEventsBufferFlag     = "cloudevents-buffer"
+	cloudEventsBufferSizeFlag = "cloudevents-buffer-size"
+	cloudEventsOverflowFlag   = "cloudevents-overflow"
+	defaultCloudEventsMode    = "binary"
+	defaultCloudEventsBuffer  = 10000
 )
 
 type WithFlagsFunc func() []cli.Flag
@@ -27,9 +90,70 @@ func parseFlags(cfg *appConfig) cli.BeforeFunc {
 	return func(ctx *cli.Context) error {
 		cfg.Host = ctx.String(hostFlag)
 		cfg.Port = ctx.String(portFlag)
+		cfg.HistoryDB = ctx.String(historyDBFlag)
+		cfg.HistoryRetention = history.Retention{
+			MaxAge:       ctx.Duration(historyMaxAgeFlag),
+			MaxRevisions: ctx.Int(historyMaxRevisionsFlag),
+		}
+		cfg.GatewayPort = ctx.String(gatewayPortFlag)
+		cfg.SwaggerUI = ctx.Bool(swaggerUIFlag)
+		cfg.MetricsAddress = ctx.String(metricsAddrFlag)
+		cfg.KubeconfigContexts = ctx.StringSlice(kubeconfigContextsFlag)
+		cfg.KubeconfigDir = ctx.String(kubeconfigDirFlag)
+		cfg.ClusterSecrets = ctx.Bool(clusterSecretsFlag)
+		cfg.InformerCache = ctx.Bool(informerCacheFlag)
+		cfg.NotificationConfig = ctx.String(notificationConfigFlag)
+		cfg.LoadtesterHosts = ctx.StringSlice(loadtesterHostsFlag)
+		cfg.MetricProviderHosts = ctx.StringSlice(metricProviderHostsFlag)
+		cfg.CloudEvents = cloudEventsConfig{
+			Sink:       ctx.String(cloudEventsSinkFlag),
+			Mode:       cloudevents.Mode(ctx.String(cloudEventsModeFlag)),
+			Buffer:     ctx.String(cloudEventsBufferFlag),
+			BufferSize: ctx.Int(cloudEventsBufferSizeFlag),
+			Overflow:   cloudevents.Overflow(ctx.String(cloudEventsOverflowFlag)),
+		}
+
+		if mode := cfg.CloudEvents.Mode; mode != cloudevents.BinaryMode && mode != cloudevents.StructuredMode {
+			return fmt.Errorf("unknown --%s %q, expected %s or %s", cloudEventsModeFlag, mode, cloudevents.BinaryMode, cloudevents.StructuredMode)
+		}
+
+		if overflow := cfg.CloudEvents.Overflow; overflow != cloudevents.BlockOverflow && overflow != cloudevents.DropOverflow {
+			return fmt.Errorf("unknown --%s %q, expected %s or %s", cloudEventsOverflowFlag, overflow, cloudevents.BlockOverflow, cloudevents.DropOverflow)
+		}
+
+		return parseAuthFlags(ctx, cfg)
+	}
+}
+
+func parseAuthFlags(ctx *cli.Context, cfg *appConfig) error {
+	methods := ctx.StringSlice(authMethodsFlag)
+	if len(methods) == 0 {
+		return fmt.Errorf("no authentication method configured, set --%s", authMethodsFlag)
+	}
 
-		return nil
+	for _, method := range methods {
+		if method != noAuthMethod {
+			cfg.Auth.Methods = append(cfg.Auth.Methods, authn.Method(method))
+			continue
+		}
+
+		if len(methods) > 1 {
+			return fmt.Errorf("the %s authentication method can't be combined with others", noAuthMethod)
+		}
+
+		cfg.NoAuth = true
 	}
+
+	cfg.Auth.TokenFile = ctx.String(authTokenFileFlag)
+	cfg.Auth.OIDC = authn.OIDCOptions{
+		IssuerURL:     ctx.String(oidcIssuerURLFlag),
+		ClientID:      ctx.String(oidcClientIDFlag),
+		JWKSURL:       ctx.String(oidcJWKSURLFlag),
+		UsernameClaim: ctx.String(oidcUsernameClaimFlag),
+		GroupsClaim:   ctx.String(oidcGroupsClaimFlag),
+	}
+
+	return nil
 }
 
 func WithHTTPServerFlags() WithFlagsFunc {
@@ -43,7 +167,182 @@ func WithHTTPServerFlags() WithFlagsFunc {
 			&cli.StringFlag{
 				Name:  portFlag,
 				Value: defaultHTTPPort,
-				Usage: "HTTP listening port",
+				Usage: "gRPC listening port",
+			},
+		}
+	}
+}
+
+func WithGatewayFlags() WithFlagsFunc {
+	return func() []cli.Flag {
+		return []cli.Flag{
+			&cli.StringFlag{
+				Name:  gatewayPortFlag,
+				Value: defaultGateway,
+				Usage: "REST gateway listening port, the gateway is disabled if empty",
+			},
+			&cli.BoolFlag{
+				Name:  swaggerUIFlag,
+				Usage: "Serve the Swagger UI at /swagger-ui/ on the gateway port",
+			},
+		}
+	}
+}
+
+func WithHistoryFlags() WithFlagsFunc {
+	return func() []cli.Flag {
+		return []cli.Flag{
+			&cli.StringFlag{
+				Name:  historyDBFlag,
+				Usage: "Path of the database canary revisions are recorded to, history is disabled if empty",
+			},
+			&cli.DurationFlag{
+				Name:  historyMaxAgeFlag,
+				Value: defaultHistoryMaxAge,
+				Usage: "How long canary revisions are kept, they're kept forever if 0",
+			},
+			&cli.IntFlag{
+				Name:  historyMaxRevisionsFlag,
+				Value: defaultHistoryMaxRevisions,
+				Usage: "Number of revisions kept per canary, the oldest are removed beyond it, they're all kept if 0",
+			},
+		}
+	}
+}
+
+func WithNotificationFlags() WithFlagsFunc {
+	return func() []cli.Flag {
+		return []cli.Flag{
+			&cli.StringFlag{
+				Name:  notificationConfigFlag,
+				Usage: "Path of the YAML file of notification receivers and rules, notifications are disabled if empty",
+			},
+		}
+	}
+}
+
+func WithCanaryActionFlags() WithFlagsFunc {
+	return func() []cli.Flag {
+		return []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  loadtesterHostsFlag,
+				Usage: "Hosts of the loadtesters whose gates can be called to roll back, pause and resume Canaries, as host or host:port",
+			},
+		}
+	}
+}
+
+func WithMetricTemplateFlags() WithFlagsFunc {
+	return func() []cli.Flag {
+		return []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  metricProviderHostsFlag,
+				Usage: "Hosts of the metric providers metric templates can be run against, as host or host:port",
+			},
+		}
+	}
+}
+
+func WithCloudEventsFlags() WithFlagsFunc {
+	return func() []cli.Flag {
+		return []cli.Flag{
+			&cli.StringFlag{
+				Name:  cloudEventsSinkFlag,
+				Usage: "URL canary lifecycle CloudEvents are posted to, the exporter is disabled if empty",
+			},
+			&cli.StringFlag{
+				Name:  cloudEventsModeFlag,
+				Value: defaultCloudEventsMode,
+				Usage: "HTTP content mode of CloudEvents, binary or structured",
+			},
+			&cli.StringFlag{
+				Name:  cloudEventsBufferFlag,
+				Usage: "Path of the database undelivered CloudEvents and the last progress of Canaries are kept in, they're kept in memory and lost on restart if empty",
+			},
+			&cli.IntFlag{
+				Name:  cloudEventsBufferSizeFlag,
+				Value: defaultCloudEventsBuffer,
+				Usage: "Maximum number of undelivered CloudEvents, new events are handled as set by --" + cloudEventsOverflowFlag + " beyond it",
+			},
+			&cli.StringFlag{
+				Name:  cloudEventsOverflowFlag,
+				Value: string(cloudevents.BlockOverflow),
+				Usage: "What to do with new CloudEvents once the buffer is full: block until delivered events make room, or drop them and count them in progressive_delivery_cloudevents_dropped_total",
+			},
+		}
+	}
+}
+
+func WithMetricsFlags() WithFlagsFunc {
+	return func() []cli.Flag {
+		return []cli.Flag{
+			&cli.StringFlag{
+				Name:  metricsAddrFlag,
+				Usage: "Address the Prometheus metrics are served on at /metrics, e.g. localhost:9003, metrics are disabled if empty",
+			},
+		}
+	}
+}
+
+func WithAuthFlags() WithFlagsFunc {
+	return func() []cli.Flag {
+		return []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  authMethodsFlag,
+				Value: cli.NewStringSlice(string(authn.BearerPassthroughMethod)),
+				Usage: fmt.Sprintf(
+					"Authentication methods tried in order: %s, %s, %s, or %s to serve every request as an admin",
+					authn.BearerPassthroughMethod, authn.OIDCMethod, authn.TokenFileMethod, noAuthMethod,
+				),
+			},
+			&cli.StringFlag{
+				Name:  authTokenFileFlag,
+				Usage: "Path of the static token file, in the format of the Kubernetes API server --token-auth-file",
+			},
+			&cli.StringFlag{
+				Name:  oidcIssuerURLFlag,
+				Usage: "URL of the OIDC issuer",
+			},
+			&cli.StringFlag{
+				Name:  oidcClientIDFlag,
+				Usage: "Audience of the OIDC tokens, it isn't checked if empty",
+			},
+			&cli.StringFlag{
+				Name:  oidcJWKSURLFlag,
+				Usage: "URL of the OIDC signing keys, they are discovered from the issuer if empty",
+			},
+			&cli.StringFlag{
+				Name:  oidcUsernameClaimFlag,
+				Value: defaultUsernameClaim,
+				Usage: "OIDC claim used as the user name",
+			},
+			&cli.StringFlag{
+				Name:  oidcGroupsClaimFlag,
+				Value: defaultGroupsClaim,
+				Usage: "OIDC claim used as the user groups",
+			},
+		}
+	}
+}
+
+func WithClustersFlags() WithFlagsFunc {
+	return func() []cli.Flag {
+		return []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  kubeconfigContextsFlag,
+				Usage: "Contexts of the kubeconfig served as clusters named after them, or * for all of them, instead of the management cluster",
+			},
+			&cli.StringFlag{
+				Name:  kubeconfigDirFlag,
+				Usage: "Directory of kubeconfigs served as clusters named after the files, using their current context",
+			},
+			&cli.BoolFlag{
+				Name:  clusterSecretsFlag,
+				Usage: "Serve the clusters of the GitopsCluster and Cluster API kubeconfig secrets of the management cluster",
+			},
+			&cli.BoolFlag{
+				Name:  informerCacheFlag,
+				Usage: "Read Canaries and their Deployments and MetricTemplates from informers, the server needs to watch them. Other targets are read from the API servers",
 			},
 		}
 	}
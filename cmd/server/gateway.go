@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/weaveworks/progressive-delivery/api"
+	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/metrics"
+	"github.com/weaveworks/progressive-delivery/pkg/server"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	swaggerJSONPath = "/swagger.json"
+	swaggerUIPath   = "/swagger-ui/"
+)
+
+// newGatewayHandler serves the REST gateway of the server, its swagger
+// description, and the Swagger UI if enabled. Requests are recorded in the
+// HTTP metrics if set.
+func newGatewayHandler(
+	ctx context.Context,
+	pdServer pb.ProgressiveDeliveryServiceServer,
+	clustersManager clustersmngr.ClustersManager,
+	principalGetter auth.PrincipalGetter,
+	swaggerUI bool,
+	httpMetrics *metrics.HTTPMetrics,
+) (http.Handler, error) {
+	gateway := runtime.NewServeMux(
+		// Annotators run once a request is matched, its path pattern is the
+		// route of the metrics.
+		runtime.WithMetadata(func(ctx context.Context, _ *http.Request) metadata.MD {
+			if pattern, ok := runtime.HTTPPathPattern(ctx); ok {
+				metrics.SetRoute(ctx, pattern)
+			}
+
+			return nil
+		}),
+	)
+
+	if err := server.RegisterHandlers(ctx, gateway, pdServer); err != nil {
+		return nil, fmt.Errorf("could not register gateway handlers: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", withClientsPoolHandler(gateway, clustersManager, principalGetter))
+	mux.HandleFunc(swaggerJSONPath, func(w http.ResponseWriter, r *http.Request) {
+		metrics.SetRoute(r.Context(), swaggerJSONPath)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(api.SwaggerJSON)
+	})
+
+	if swaggerUI {
+		ui := api.SwaggerUI()
+
+		for _, asset := range api.SwaggerUIAssets {
+			if _, err := fs.Stat(ui, asset); err != nil {
+				return nil, fmt.Errorf("swagger UI asset %s is missing, download the assets with make swagger-ui: %w", asset, err)
+			}
+		}
+
+		files := http.StripPrefix(swaggerUIPath, http.FileServer(http.FS(ui)))
+
+		mux.Handle(swaggerUIPath, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			metrics.SetRoute(r.Context(), swaggerUIPath)
+
+			files.ServeHTTP(w, r)
+		}))
+	}
+
+	if httpMetrics != nil {
+		return httpMetrics.Middleware(mux), nil
+	}
+
+	return mux, nil
+}
+
+// withClientsPoolHandler is the HTTP counterpart of
+// withClientsPoolInterceptor, the gateway calls the server directly so gRPC
+// interceptors don't run.
+func withClientsPoolHandler(next http.Handler, clustersManager clustersmngr.ClustersManager, principalGetter auth.PrincipalGetter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := principalGetter.Principal(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		ctx, err := withClientsPool(r.Context(), clustersManager, user)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
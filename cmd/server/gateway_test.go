@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/api"
+	"github.com/weaveworks/progressive-delivery/pkg/metrics"
+	"github.com/weaveworks/progressive-delivery/pkg/server"
+	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+func TestNewGatewayHandler_Swagger(t *testing.T) {
+	pdServer, err := server.NewProgressiveDeliveryServer(server.ServerOpts{
+		CRDService: crd.NewNoCacheFetcher(nil),
+		Logger:     logr.Discard(),
+	})
+	assert.NoError(t, err)
+
+	reg := prometheus.NewRegistry()
+
+	httpMetrics, err := metrics.NewHTTPMetrics(reg)
+	assert.NoError(t, err)
+
+	handler, err := newGatewayHandler(context.Background(), pdServer, nil, nil, false, httpMetrics)
+	assert.NoError(t, err)
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + swaggerJSONPath)
+	assert.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, api.SwaggerJSON, body)
+
+	resp, err = http.Get(ts.URL + swaggerUIPath)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "the Swagger UI should only be served if enabled")
+
+	count, err := testutil.GatherAndCount(reg, "progressive_delivery_http_request_duration_seconds")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count, "requests should be recorded by route and status code")
+}
+
+func TestNewGatewayHandler_SwaggerUI(t *testing.T) {
+	pdServer, err := server.NewProgressiveDeliveryServer(server.ServerOpts{
+		CRDService: crd.NewNoCacheFetcher(nil),
+		Logger:     logr.Discard(),
+	})
+	assert.NoError(t, err)
+
+	handler, err := newGatewayHandler(context.Background(), pdServer, nil, nil, true, nil)
+
+	for _, asset := range api.SwaggerUIAssets {
+		if _, statErr := fs.Stat(api.SwaggerUI(), asset); statErr != nil {
+			assert.ErrorContains(t, err, "make swagger-ui", "the server shouldn't start without the assets")
+			return
+		}
+	}
+
+	assert.NoError(t, err)
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	for _, path := range append([]string{""}, api.SwaggerUIAssets...) {
+		resp, err := http.Get(ts.URL + swaggerUIPath + path)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode, path)
+	}
+}
+
+type rejectingPrincipalGetter struct{}
+
+func (rejectingPrincipalGetter) Principal(_ *http.Request) (*auth.UserPrincipal, error) {
+	return nil, errors.New("could not find valid principal")
+}
+
+func TestNewGatewayHandler_Unauthenticated(t *testing.T) {
+	pdServer, err := server.NewProgressiveDeliveryServer(server.ServerOpts{
+		CRDService: crd.NewNoCacheFetcher(nil),
+		Logger:     logr.Discard(),
+	})
+	assert.NoError(t, err)
+
+	handler, err := newGatewayHandler(context.Background(), pdServer, nil, rejectingPrincipalGetter{}, false, nil)
+	assert.NoError(t, err)
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/v1/pd/version")
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
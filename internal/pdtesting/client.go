@@ -5,7 +5,9 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
 	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster"
 	"github.com/weaveworks/weave-gitops/core/clustersmngr/fetcher"
 	"github.com/weaveworks/weave-gitops/core/nsaccess"
 	"github.com/weaveworks/weave-gitops/pkg/testutils"
@@ -39,3 +41,42 @@ func CreateClient(k8sEnv *testutils.K8sTestEnv) (clustersmngr.Client, clustersmn
 
 	return client, clustersManager, err
 }
+
+// CreateMultiClusterClient returns a server client for a set of clusters,
+// keyed by name.
+func CreateMultiClusterClient(envs map[string]*testutils.K8sTestEnv) (clustersmngr.Client, error) {
+	ctx := context.Background()
+
+	clusters := []cluster.Cluster{}
+
+	for name, env := range envs {
+		cl, err := cluster.NewSingleCluster(name, env.Rest, kube.CreateScheme(), cluster.DefaultKubeConfigOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create cluster %s from config: %w", name, err)
+		}
+
+		clusters = append(clusters, cl)
+	}
+
+	clustersManager := clustersmngr.NewClustersManager(
+		[]clustersmngr.ClusterFetcher{staticFetcher(clusters)},
+		nsaccess.NewChecker(nsaccess.DefautltWegoAppRules),
+		logr.Discard(),
+	)
+
+	if err := clustersManager.UpdateClusters(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := clustersManager.UpdateNamespaces(ctx); err != nil {
+		return nil, err
+	}
+
+	return clustersManager.GetServerClient(ctx)
+}
+
+type staticFetcher []cluster.Cluster
+
+func (f staticFetcher) Fetch(ctx context.Context) ([]cluster.Cluster, error) {
+	return f, nil
+}
@@ -30,7 +30,10 @@ func MakeGRPCServer(
 	k8sEnv *testutils.K8sTestEnv,
 ) pb.ProgressiveDeliveryServiceClient {
 	log := logr.Discard()
-	ctx := context.Background()
+
+	// The goroutines of the server stop with the test.
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
 
 	cl, err := RestConfigToCluster(k8sEnv.Rest)
 	if err != nil {
@@ -51,6 +54,7 @@ func MakeGRPCServer(
 	_ = clustersManager.UpdateNamespaces(ctx)
 
 	opts := server.ServerOpts{
+		Context:         ctx,
 		ClustersManager: clustersManager,
 		CRDService:      crd.NewNoCacheFetcher(clustersManager),
 		Logger:          logr.Discard(),
@@ -61,6 +65,7 @@ func MakeGRPCServer(
 	principal := auth.NewUserPrincipal(auth.Token("1234"))
 	s := grpc.NewServer(
 		withClientsPoolInterceptor(clustersManager, cfg, principal),
+		withClientsPoolStreamInterceptor(clustersManager, principal),
 	)
 
 	pb.RegisterProgressiveDeliveryServiceServer(s, pdServer)
@@ -125,3 +130,41 @@ func withClientsPoolInterceptor(clustersManager clustersmngr.ClustersManager, co
 		return handler(ctx, req)
 	})
 }
+
+func withClientsPoolStreamInterceptor(clustersManager clustersmngr.ClustersManager, user *auth.UserPrincipal) grpc.ServerOption {
+	return grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+
+		if err := clustersManager.UpdateClusters(ctx); err != nil {
+			return err
+		}
+		if err := clustersManager.UpdateNamespaces(ctx); err != nil {
+			return err
+		}
+
+		clustersManager.UpdateUserNamespaces(ctx, user)
+
+		ctx = auth.WithPrincipal(ctx, user)
+
+		clusterClient, err := clustersManager.GetImpersonatedClient(ctx, user)
+		if err != nil {
+			return err
+		}
+
+		ctx = context.WithValue(ctx, clustersmngr.ClustersClientCtxKey, clusterClient)
+
+		return handler(srv, &serverStreamWithContext{ServerStream: ss, ctx: ctx})
+	})
+}
+
+// serverStreamWithContext overrides the context of a stream, so handlers can
+// access values set by interceptors.
+type serverStreamWithContext struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s *serverStreamWithContext) Context() context.Context {
+	return s.ctx
+}
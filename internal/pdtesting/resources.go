@@ -13,6 +13,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/rand"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -31,7 +32,9 @@ func NewNamespace(ctx context.Context, t *testing.T, k client.Client) corev1.Nam
 type CanaryInfo struct {
 	Name      string
 	Namespace string
+	Labels    map[string]string
 	Metrics   []v1beta1.CanaryMetric
+	Webhooks  []v1beta1.CanaryWebhook
 }
 
 func NewCanary(
@@ -44,6 +47,7 @@ func NewCanary(
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      info.Name,
 			Namespace: info.Namespace,
+			Labels:    info.Labels,
 		},
 		Spec: v1beta1.CanarySpec{
 			Provider: "linkerd",
@@ -71,6 +75,7 @@ func NewCanary(
 				Iterations: 1,
 				Interval:   "1m",
 				Metrics:    info.Metrics,
+				Webhooks:   info.Webhooks,
 			},
 		},
 		Status: v1beta1.CanaryStatus{
@@ -138,6 +143,71 @@ func NewDeployment(ctx context.Context, t *testing.T, k client.Client, name stri
 	return dpl
 }
 
+func NewDaemonSet(ctx context.Context, t *testing.T, k client.Client, name string, ns string) *appsv1.DaemonSet {
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+			Labels: map[string]string{
+				server.LabelKustomizeName:      name,
+				server.LabelKustomizeNamespace: ns,
+			},
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app": name,
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app": name,
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "nginx",
+						Image: "nginx",
+					}},
+				},
+			},
+		},
+	}
+
+	err := k.Create(ctx, ds)
+	assert.NoError(t, err, "should be able to create DaemonSet: %s", ds.GetName())
+
+	return ds
+}
+
+func NewService(ctx context.Context, t *testing.T, k client.Client, name string, ns string) *corev1.Service {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+			Labels: map[string]string{
+				server.LabelKustomizeName:      name,
+				server.LabelKustomizeNamespace: ns,
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{
+				"app": name,
+			},
+			Ports: []corev1.ServicePort{{
+				Port:       80,
+				TargetPort: intstr.FromInt(8080),
+			}},
+		},
+	}
+
+	err := k.Create(ctx, svc)
+	assert.NoError(t, err, "should be able to create Service: %s", svc.GetName())
+
+	return svc
+}
+
 type CRDInfo struct {
 	Group    string
 	Plural   string
@@ -231,3 +301,99 @@ func NewMetricTemplate(ctx context.Context, t *testing.T, k client.Client, info
 
 	return tpl
 }
+
+type RolloutInfo struct {
+	Name      string
+	Namespace string
+	Image     string
+	// AnalysisTemplate is referenced by a background analysis if set.
+	AnalysisTemplate string
+}
+
+func NewRollout(ctx context.Context, t *testing.T, k client.Client, info RolloutInfo) *unstructured.Unstructured {
+	canary := map[string]interface{}{
+		"steps": []interface{}{
+			map[string]interface{}{"setWeight": int64(20)},
+			map[string]interface{}{"pause": map[string]interface{}{}},
+			map[string]interface{}{"setWeight": int64(60)},
+		},
+	}
+
+	if info.AnalysisTemplate != "" {
+		canary["analysis"] = map[string]interface{}{
+			"templates": []interface{}{
+				map[string]interface{}{"templateName": info.AnalysisTemplate},
+			},
+		}
+	}
+
+	rollout := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"selector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{"app": info.Name},
+				},
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"labels": map[string]interface{}{"app": info.Name},
+					},
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{"name": info.Name, "image": info.Image},
+						},
+					},
+				},
+				"strategy": map[string]interface{}{"canary": canary},
+			},
+		},
+	}
+
+	rollout.SetAPIVersion("argoproj.io/v1alpha1")
+	rollout.SetKind("Rollout")
+	rollout.SetName(info.Name)
+	rollout.SetNamespace(info.Namespace)
+
+	err := k.Create(ctx, rollout)
+	assert.NoError(t, err, "should be able to create rollout: %s", rollout.GetName())
+
+	return rollout
+}
+
+type AnalysisTemplateInfo struct {
+	Name            string
+	Namespace       string
+	MetricName      string
+	ProviderAddress string
+	Query           string
+}
+
+func NewAnalysisTemplate(ctx context.Context, t *testing.T, k client.Client, info AnalysisTemplateInfo) *unstructured.Unstructured {
+	tpl := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"metrics": []interface{}{
+					map[string]interface{}{
+						"name":     info.MetricName,
+						"interval": "1m",
+						"provider": map[string]interface{}{
+							"prometheus": map[string]interface{}{
+								"address": info.ProviderAddress,
+								"query":   info.Query,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tpl.SetAPIVersion("argoproj.io/v1alpha1")
+	tpl.SetKind("AnalysisTemplate")
+	tpl.SetName(info.Name)
+	tpl.SetNamespace(info.Namespace)
+
+	err := k.Create(ctx, tpl)
+	assert.NoError(t, err, "should be able to create analysis template: %s", tpl.GetName())
+
+	return tpl
+}
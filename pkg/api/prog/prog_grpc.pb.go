@@ -31,12 +31,80 @@ type ProgressiveDeliveryServiceClient interface {
 	// installed or not on that cluster.
 	IsFlaggerAvailable(ctx context.Context, in *IsFlaggerAvailableRequest, opts ...grpc.CallOption) (*IsFlaggerAvailableResponse, error)
 	//
+	// IsArgoRolloutsAvailable returns with a hashmap where the keys are the
+	// names of the clusters, and the value is a boolean indicating whether Argo
+	// Rollouts is installed or not on that cluster.
+	IsArgoRolloutsAvailable(ctx context.Context, in *IsArgoRolloutsAvailableRequest, opts ...grpc.CallOption) (*IsArgoRolloutsAvailableResponse, error)
+	//
+	// GetClusterCapabilities returns with the progressive delivery tools
+	// installed on each cluster: Flagger and its mesh providers, Gateway API,
+	// Flux controllers and Argo Rollouts.
+	GetClusterCapabilities(ctx context.Context, in *GetClusterCapabilitiesRequest, opts ...grpc.CallOption) (*GetClusterCapabilitiesResponse, error)
+	//
+	// GetCanarySummary returns with Canary counters of every cluster, by
+	// phase, deployment strategy and provider.
+	GetCanarySummary(ctx context.Context, in *GetCanarySummaryRequest, opts ...grpc.CallOption) (*GetCanarySummaryResponse, error)
+	//
 	// ListCanaries returns with a list of Canary objects.
 	ListMetricTemplates(ctx context.Context, in *ListMetricTemplatesRequest, opts ...grpc.CallOption) (*ListMetricTemplatesResponse, error)
 	//
+	// RunMetricTemplate renders the query of a MetricTemplate for a Canary and
+	// runs it against the provider of the template, without waiting for a
+	// rollout.
+	RunMetricTemplate(ctx context.Context, in *RunMetricTemplateRequest, opts ...grpc.CallOption) (*RunMetricTemplateResponse, error)
+	//
 	// ListCanaryObjects returns with a list of related objects for a Canary
 	// objects.
 	ListCanaryObjects(ctx context.Context, in *ListCanaryObjectsRequest, opts ...grpc.CallOption) (*ListCanaryObjectsResponse, error)
+	//
+	// PromoteCanary skips the analysis of the rollout in progress of a Canary,
+	// so Flagger promotes it on its next iteration. The analysis is enabled
+	// again once the rollout ends.
+	PromoteCanary(ctx context.Context, in *PromoteCanaryRequest, opts ...grpc.CallOption) (*PromoteCanaryResponse, error)
+	//
+	// RollbackCanary opens the rollback gate of a Canary, so Flagger rolls it
+	// back on its next iteration.
+	RollbackCanary(ctx context.Context, in *RollbackCanaryRequest, opts ...grpc.CallOption) (*RollbackCanaryResponse, error)
+	//
+	// PauseCanary closes the confirmation gate of a Canary, so Flagger halts
+	// the analysis until it's resumed. Canaries without a gate are suspended,
+	// if Flagger supports spec.suspend.
+	PauseCanary(ctx context.Context, in *PauseCanaryRequest, opts ...grpc.CallOption) (*PauseCanaryResponse, error)
+	//
+	// ResumeCanary opens the confirmation gate of a Canary, so Flagger
+	// continues the analysis. Canaries without a gate are unsuspended.
+	ResumeCanary(ctx context.Context, in *ResumeCanaryRequest, opts ...grpc.CallOption) (*ResumeCanaryResponse, error)
+	//
+	// ReconcileCanaryAutomation requests Flux to reconcile the Kustomization
+	// or the HelmRelease applying the target of a Canary, optionally its
+	// source first.
+	ReconcileCanaryAutomation(ctx context.Context, in *ReconcileCanaryAutomationRequest, opts ...grpc.CallOption) (*ReconcileCanaryAutomationResponse, error)
+	//
+	// ValidateCanary checks a Canary manifest, or an existing Canary, and the
+	// resources it references on the cluster.
+	ValidateCanary(ctx context.Context, in *ValidateCanaryRequest, opts ...grpc.CallOption) (*ValidateCanaryResponse, error)
+	//
+	// ListCanaryEvents returns with the timeline of a Canary rollout, built
+	// from the Kubernetes events of the Canary, its target and its primary.
+	ListCanaryEvents(ctx context.Context, in *ListCanaryEventsRequest, opts ...grpc.CallOption) (*ListCanaryEventsResponse, error)
+	//
+	// ListCanaryRevisions returns with the recorded phase transitions of
+	// Canaries. Revisions are kept by the server, so they outlive Kubernetes
+	// events.
+	ListCanaryRevisions(ctx context.Context, in *ListCanaryRevisionsRequest, opts ...grpc.CallOption) (*ListCanaryRevisionsResponse, error)
+	//
+	// ListNotificationRules returns the rules sending notifications on Canary
+	// phase transitions. Receiver URLs are not returned.
+	ListNotificationRules(ctx context.Context, in *ListNotificationRulesRequest, opts ...grpc.CallOption) (*ListNotificationRulesResponse, error)
+	//
+	// TestNotification sends a notification about a Canary through the
+	// receivers of a rule, whether the rule matches it or not.
+	TestNotification(ctx context.Context, in *TestNotificationRequest, opts ...grpc.CallOption) (*TestNotificationResponse, error)
+	//
+	// WatchCanaries streams Canary changes from all clusters. Existing Canaries
+	// are sent as ADDED events first, then an event is sent whenever a Canary
+	// is created, deleted or its rollout progresses.
+	WatchCanaries(ctx context.Context, in *WatchCanariesRequest, opts ...grpc.CallOption) (ProgressiveDeliveryService_WatchCanariesClient, error)
 }
 
 type progressiveDeliveryServiceClient struct {
@@ -83,6 +151,33 @@ func (c *progressiveDeliveryServiceClient) IsFlaggerAvailable(ctx context.Contex
 	return out, nil
 }
 
+func (c *progressiveDeliveryServiceClient) IsArgoRolloutsAvailable(ctx context.Context, in *IsArgoRolloutsAvailableRequest, opts ...grpc.CallOption) (*IsArgoRolloutsAvailableResponse, error) {
+	out := new(IsArgoRolloutsAvailableResponse)
+	err := c.cc.Invoke(ctx, "/ProgressiveDeliveryService/IsArgoRolloutsAvailable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *progressiveDeliveryServiceClient) GetClusterCapabilities(ctx context.Context, in *GetClusterCapabilitiesRequest, opts ...grpc.CallOption) (*GetClusterCapabilitiesResponse, error) {
+	out := new(GetClusterCapabilitiesResponse)
+	err := c.cc.Invoke(ctx, "/ProgressiveDeliveryService/GetClusterCapabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *progressiveDeliveryServiceClient) GetCanarySummary(ctx context.Context, in *GetCanarySummaryRequest, opts ...grpc.CallOption) (*GetCanarySummaryResponse, error) {
+	out := new(GetCanarySummaryResponse)
+	err := c.cc.Invoke(ctx, "/ProgressiveDeliveryService/GetCanarySummary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *progressiveDeliveryServiceClient) ListMetricTemplates(ctx context.Context, in *ListMetricTemplatesRequest, opts ...grpc.CallOption) (*ListMetricTemplatesResponse, error) {
 	out := new(ListMetricTemplatesResponse)
 	err := c.cc.Invoke(ctx, "/ProgressiveDeliveryService/ListMetricTemplates", in, out, opts...)
@@ -92,6 +187,15 @@ func (c *progressiveDeliveryServiceClient) ListMetricTemplates(ctx context.Conte
 	return out, nil
 }
 
+func (c *progressiveDeliveryServiceClient) RunMetricTemplate(ctx context.Context, in *RunMetricTemplateRequest, opts ...grpc.CallOption) (*RunMetricTemplateResponse, error) {
+	out := new(RunMetricTemplateResponse)
+	err := c.cc.Invoke(ctx, "/ProgressiveDeliveryService/RunMetricTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *progressiveDeliveryServiceClient) ListCanaryObjects(ctx context.Context, in *ListCanaryObjectsRequest, opts ...grpc.CallOption) (*ListCanaryObjectsResponse, error) {
 	out := new(ListCanaryObjectsResponse)
 	err := c.cc.Invoke(ctx, "/ProgressiveDeliveryService/ListCanaryObjects", in, out, opts...)
@@ -101,6 +205,128 @@ func (c *progressiveDeliveryServiceClient) ListCanaryObjects(ctx context.Context
 	return out, nil
 }
 
+func (c *progressiveDeliveryServiceClient) PromoteCanary(ctx context.Context, in *PromoteCanaryRequest, opts ...grpc.CallOption) (*PromoteCanaryResponse, error) {
+	out := new(PromoteCanaryResponse)
+	err := c.cc.Invoke(ctx, "/ProgressiveDeliveryService/PromoteCanary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *progressiveDeliveryServiceClient) RollbackCanary(ctx context.Context, in *RollbackCanaryRequest, opts ...grpc.CallOption) (*RollbackCanaryResponse, error) {
+	out := new(RollbackCanaryResponse)
+	err := c.cc.Invoke(ctx, "/ProgressiveDeliveryService/RollbackCanary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *progressiveDeliveryServiceClient) PauseCanary(ctx context.Context, in *PauseCanaryRequest, opts ...grpc.CallOption) (*PauseCanaryResponse, error) {
+	out := new(PauseCanaryResponse)
+	err := c.cc.Invoke(ctx, "/ProgressiveDeliveryService/PauseCanary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *progressiveDeliveryServiceClient) ResumeCanary(ctx context.Context, in *ResumeCanaryRequest, opts ...grpc.CallOption) (*ResumeCanaryResponse, error) {
+	out := new(ResumeCanaryResponse)
+	err := c.cc.Invoke(ctx, "/ProgressiveDeliveryService/ResumeCanary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *progressiveDeliveryServiceClient) ReconcileCanaryAutomation(ctx context.Context, in *ReconcileCanaryAutomationRequest, opts ...grpc.CallOption) (*ReconcileCanaryAutomationResponse, error) {
+	out := new(ReconcileCanaryAutomationResponse)
+	err := c.cc.Invoke(ctx, "/ProgressiveDeliveryService/ReconcileCanaryAutomation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *progressiveDeliveryServiceClient) ValidateCanary(ctx context.Context, in *ValidateCanaryRequest, opts ...grpc.CallOption) (*ValidateCanaryResponse, error) {
+	out := new(ValidateCanaryResponse)
+	err := c.cc.Invoke(ctx, "/ProgressiveDeliveryService/ValidateCanary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *progressiveDeliveryServiceClient) ListCanaryEvents(ctx context.Context, in *ListCanaryEventsRequest, opts ...grpc.CallOption) (*ListCanaryEventsResponse, error) {
+	out := new(ListCanaryEventsResponse)
+	err := c.cc.Invoke(ctx, "/ProgressiveDeliveryService/ListCanaryEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *progressiveDeliveryServiceClient) ListCanaryRevisions(ctx context.Context, in *ListCanaryRevisionsRequest, opts ...grpc.CallOption) (*ListCanaryRevisionsResponse, error) {
+	out := new(ListCanaryRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/ProgressiveDeliveryService/ListCanaryRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *progressiveDeliveryServiceClient) ListNotificationRules(ctx context.Context, in *ListNotificationRulesRequest, opts ...grpc.CallOption) (*ListNotificationRulesResponse, error) {
+	out := new(ListNotificationRulesResponse)
+	err := c.cc.Invoke(ctx, "/ProgressiveDeliveryService/ListNotificationRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *progressiveDeliveryServiceClient) TestNotification(ctx context.Context, in *TestNotificationRequest, opts ...grpc.CallOption) (*TestNotificationResponse, error) {
+	out := new(TestNotificationResponse)
+	err := c.cc.Invoke(ctx, "/ProgressiveDeliveryService/TestNotification", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *progressiveDeliveryServiceClient) WatchCanaries(ctx context.Context, in *WatchCanariesRequest, opts ...grpc.CallOption) (ProgressiveDeliveryService_WatchCanariesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ProgressiveDeliveryService_ServiceDesc.Streams[0], "/ProgressiveDeliveryService/WatchCanaries", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &progressiveDeliveryServiceWatchCanariesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ProgressiveDeliveryService_WatchCanariesClient interface {
+	Recv() (*WatchCanariesResponse, error)
+	grpc.ClientStream
+}
+
+type progressiveDeliveryServiceWatchCanariesClient struct {
+	grpc.ClientStream
+}
+
+func (x *progressiveDeliveryServiceWatchCanariesClient) Recv() (*WatchCanariesResponse, error) {
+	m := new(WatchCanariesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ProgressiveDeliveryServiceServer is the server API for ProgressiveDeliveryService service.
 // All implementations must embed UnimplementedProgressiveDeliveryServiceServer
 // for forward compatibility
@@ -118,12 +344,80 @@ type ProgressiveDeliveryServiceServer interface {
 	// installed or not on that cluster.
 	IsFlaggerAvailable(context.Context, *IsFlaggerAvailableRequest) (*IsFlaggerAvailableResponse, error)
 	//
+	// IsArgoRolloutsAvailable returns with a hashmap where the keys are the
+	// names of the clusters, and the value is a boolean indicating whether Argo
+	// Rollouts is installed or not on that cluster.
+	IsArgoRolloutsAvailable(context.Context, *IsArgoRolloutsAvailableRequest) (*IsArgoRolloutsAvailableResponse, error)
+	//
+	// GetClusterCapabilities returns with the progressive delivery tools
+	// installed on each cluster: Flagger and its mesh providers, Gateway API,
+	// Flux controllers and Argo Rollouts.
+	GetClusterCapabilities(context.Context, *GetClusterCapabilitiesRequest) (*GetClusterCapabilitiesResponse, error)
+	//
+	// GetCanarySummary returns with Canary counters of every cluster, by
+	// phase, deployment strategy and provider.
+	GetCanarySummary(context.Context, *GetCanarySummaryRequest) (*GetCanarySummaryResponse, error)
+	//
 	// ListCanaries returns with a list of Canary objects.
 	ListMetricTemplates(context.Context, *ListMetricTemplatesRequest) (*ListMetricTemplatesResponse, error)
 	//
+	// RunMetricTemplate renders the query of a MetricTemplate for a Canary and
+	// runs it against the provider of the template, without waiting for a
+	// rollout.
+	RunMetricTemplate(context.Context, *RunMetricTemplateRequest) (*RunMetricTemplateResponse, error)
+	//
 	// ListCanaryObjects returns with a list of related objects for a Canary
 	// objects.
 	ListCanaryObjects(context.Context, *ListCanaryObjectsRequest) (*ListCanaryObjectsResponse, error)
+	//
+	// PromoteCanary skips the analysis of the rollout in progress of a Canary,
+	// so Flagger promotes it on its next iteration. The analysis is enabled
+	// again once the rollout ends.
+	PromoteCanary(context.Context, *PromoteCanaryRequest) (*PromoteCanaryResponse, error)
+	//
+	// RollbackCanary opens the rollback gate of a Canary, so Flagger rolls it
+	// back on its next iteration.
+	RollbackCanary(context.Context, *RollbackCanaryRequest) (*RollbackCanaryResponse, error)
+	//
+	// PauseCanary closes the confirmation gate of a Canary, so Flagger halts
+	// the analysis until it's resumed. Canaries without a gate are suspended,
+	// if Flagger supports spec.suspend.
+	PauseCanary(context.Context, *PauseCanaryRequest) (*PauseCanaryResponse, error)
+	//
+	// ResumeCanary opens the confirmation gate of a Canary, so Flagger
+	// continues the analysis. Canaries without a gate are unsuspended.
+	ResumeCanary(context.Context, *ResumeCanaryRequest) (*ResumeCanaryResponse, error)
+	//
+	// ReconcileCanaryAutomation requests Flux to reconcile the Kustomization
+	// or the HelmRelease applying the target of a Canary, optionally its
+	// source first.
+	ReconcileCanaryAutomation(context.Context, *ReconcileCanaryAutomationRequest) (*ReconcileCanaryAutomationResponse, error)
+	//
+	// ValidateCanary checks a Canary manifest, or an existing Canary, and the
+	// resources it references on the cluster.
+	ValidateCanary(context.Context, *ValidateCanaryRequest) (*ValidateCanaryResponse, error)
+	//
+	// ListCanaryEvents returns with the timeline of a Canary rollout, built
+	// from the Kubernetes events of the Canary, its target and its primary.
+	ListCanaryEvents(context.Context, *ListCanaryEventsRequest) (*ListCanaryEventsResponse, error)
+	//
+	// ListCanaryRevisions returns with the recorded phase transitions of
+	// Canaries. Revisions are kept by the server, so they outlive Kubernetes
+	// events.
+	ListCanaryRevisions(context.Context, *ListCanaryRevisionsRequest) (*ListCanaryRevisionsResponse, error)
+	//
+	// ListNotificationRules returns the rules sending notifications on Canary
+	// phase transitions. Receiver URLs are not returned.
+	ListNotificationRules(context.Context, *ListNotificationRulesRequest) (*ListNotificationRulesResponse, error)
+	//
+	// TestNotification sends a notification about a Canary through the
+	// receivers of a rule, whether the rule matches it or not.
+	TestNotification(context.Context, *TestNotificationRequest) (*TestNotificationResponse, error)
+	//
+	// WatchCanaries streams Canary changes from all clusters. Existing Canaries
+	// are sent as ADDED events first, then an event is sent whenever a Canary
+	// is created, deleted or its rollout progresses.
+	WatchCanaries(*WatchCanariesRequest, ProgressiveDeliveryService_WatchCanariesServer) error
 	mustEmbedUnimplementedProgressiveDeliveryServiceServer()
 }
 
@@ -143,12 +437,57 @@ func (UnimplementedProgressiveDeliveryServiceServer) GetCanary(context.Context,
 func (UnimplementedProgressiveDeliveryServiceServer) IsFlaggerAvailable(context.Context, *IsFlaggerAvailableRequest) (*IsFlaggerAvailableResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IsFlaggerAvailable not implemented")
 }
+func (UnimplementedProgressiveDeliveryServiceServer) IsArgoRolloutsAvailable(context.Context, *IsArgoRolloutsAvailableRequest) (*IsArgoRolloutsAvailableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsArgoRolloutsAvailable not implemented")
+}
+func (UnimplementedProgressiveDeliveryServiceServer) GetClusterCapabilities(context.Context, *GetClusterCapabilitiesRequest) (*GetClusterCapabilitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClusterCapabilities not implemented")
+}
+func (UnimplementedProgressiveDeliveryServiceServer) GetCanarySummary(context.Context, *GetCanarySummaryRequest) (*GetCanarySummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCanarySummary not implemented")
+}
 func (UnimplementedProgressiveDeliveryServiceServer) ListMetricTemplates(context.Context, *ListMetricTemplatesRequest) (*ListMetricTemplatesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListMetricTemplates not implemented")
 }
+func (UnimplementedProgressiveDeliveryServiceServer) RunMetricTemplate(context.Context, *RunMetricTemplateRequest) (*RunMetricTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunMetricTemplate not implemented")
+}
 func (UnimplementedProgressiveDeliveryServiceServer) ListCanaryObjects(context.Context, *ListCanaryObjectsRequest) (*ListCanaryObjectsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListCanaryObjects not implemented")
 }
+func (UnimplementedProgressiveDeliveryServiceServer) PromoteCanary(context.Context, *PromoteCanaryRequest) (*PromoteCanaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromoteCanary not implemented")
+}
+func (UnimplementedProgressiveDeliveryServiceServer) RollbackCanary(context.Context, *RollbackCanaryRequest) (*RollbackCanaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackCanary not implemented")
+}
+func (UnimplementedProgressiveDeliveryServiceServer) PauseCanary(context.Context, *PauseCanaryRequest) (*PauseCanaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseCanary not implemented")
+}
+func (UnimplementedProgressiveDeliveryServiceServer) ResumeCanary(context.Context, *ResumeCanaryRequest) (*ResumeCanaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeCanary not implemented")
+}
+func (UnimplementedProgressiveDeliveryServiceServer) ReconcileCanaryAutomation(context.Context, *ReconcileCanaryAutomationRequest) (*ReconcileCanaryAutomationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileCanaryAutomation not implemented")
+}
+func (UnimplementedProgressiveDeliveryServiceServer) ValidateCanary(context.Context, *ValidateCanaryRequest) (*ValidateCanaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateCanary not implemented")
+}
+func (UnimplementedProgressiveDeliveryServiceServer) ListCanaryEvents(context.Context, *ListCanaryEventsRequest) (*ListCanaryEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCanaryEvents not implemented")
+}
+func (UnimplementedProgressiveDeliveryServiceServer) ListCanaryRevisions(context.Context, *ListCanaryRevisionsRequest) (*ListCanaryRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCanaryRevisions not implemented")
+}
+func (UnimplementedProgressiveDeliveryServiceServer) ListNotificationRules(context.Context, *ListNotificationRulesRequest) (*ListNotificationRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNotificationRules not implemented")
+}
+func (UnimplementedProgressiveDeliveryServiceServer) TestNotification(context.Context, *TestNotificationRequest) (*TestNotificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestNotification not implemented")
+}
+func (UnimplementedProgressiveDeliveryServiceServer) WatchCanaries(*WatchCanariesRequest, ProgressiveDeliveryService_WatchCanariesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCanaries not implemented")
+}
 func (UnimplementedProgressiveDeliveryServiceServer) mustEmbedUnimplementedProgressiveDeliveryServiceServer() {
 }
 
@@ -235,6 +574,60 @@ func _ProgressiveDeliveryService_IsFlaggerAvailable_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProgressiveDeliveryService_IsArgoRolloutsAvailable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsArgoRolloutsAvailableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProgressiveDeliveryServiceServer).IsArgoRolloutsAvailable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ProgressiveDeliveryService/IsArgoRolloutsAvailable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProgressiveDeliveryServiceServer).IsArgoRolloutsAvailable(ctx, req.(*IsArgoRolloutsAvailableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProgressiveDeliveryService_GetClusterCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClusterCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProgressiveDeliveryServiceServer).GetClusterCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ProgressiveDeliveryService/GetClusterCapabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProgressiveDeliveryServiceServer).GetClusterCapabilities(ctx, req.(*GetClusterCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProgressiveDeliveryService_GetCanarySummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCanarySummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProgressiveDeliveryServiceServer).GetCanarySummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ProgressiveDeliveryService/GetCanarySummary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProgressiveDeliveryServiceServer).GetCanarySummary(ctx, req.(*GetCanarySummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProgressiveDeliveryService_ListMetricTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListMetricTemplatesRequest)
 	if err := dec(in); err != nil {
@@ -253,6 +646,24 @@ func _ProgressiveDeliveryService_ListMetricTemplates_Handler(srv interface{}, ct
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProgressiveDeliveryService_RunMetricTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunMetricTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProgressiveDeliveryServiceServer).RunMetricTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ProgressiveDeliveryService/RunMetricTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProgressiveDeliveryServiceServer).RunMetricTemplate(ctx, req.(*RunMetricTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProgressiveDeliveryService_ListCanaryObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListCanaryObjectsRequest)
 	if err := dec(in); err != nil {
@@ -271,6 +682,207 @@ func _ProgressiveDeliveryService_ListCanaryObjects_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProgressiveDeliveryService_PromoteCanary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteCanaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProgressiveDeliveryServiceServer).PromoteCanary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ProgressiveDeliveryService/PromoteCanary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProgressiveDeliveryServiceServer).PromoteCanary(ctx, req.(*PromoteCanaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProgressiveDeliveryService_RollbackCanary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackCanaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProgressiveDeliveryServiceServer).RollbackCanary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ProgressiveDeliveryService/RollbackCanary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProgressiveDeliveryServiceServer).RollbackCanary(ctx, req.(*RollbackCanaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProgressiveDeliveryService_PauseCanary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseCanaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProgressiveDeliveryServiceServer).PauseCanary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ProgressiveDeliveryService/PauseCanary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProgressiveDeliveryServiceServer).PauseCanary(ctx, req.(*PauseCanaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProgressiveDeliveryService_ResumeCanary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeCanaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProgressiveDeliveryServiceServer).ResumeCanary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ProgressiveDeliveryService/ResumeCanary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProgressiveDeliveryServiceServer).ResumeCanary(ctx, req.(*ResumeCanaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProgressiveDeliveryService_ReconcileCanaryAutomation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileCanaryAutomationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProgressiveDeliveryServiceServer).ReconcileCanaryAutomation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ProgressiveDeliveryService/ReconcileCanaryAutomation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProgressiveDeliveryServiceServer).ReconcileCanaryAutomation(ctx, req.(*ReconcileCanaryAutomationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProgressiveDeliveryService_ValidateCanary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateCanaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProgressiveDeliveryServiceServer).ValidateCanary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ProgressiveDeliveryService/ValidateCanary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProgressiveDeliveryServiceServer).ValidateCanary(ctx, req.(*ValidateCanaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProgressiveDeliveryService_ListCanaryEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCanaryEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProgressiveDeliveryServiceServer).ListCanaryEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ProgressiveDeliveryService/ListCanaryEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProgressiveDeliveryServiceServer).ListCanaryEvents(ctx, req.(*ListCanaryEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProgressiveDeliveryService_ListCanaryRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCanaryRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProgressiveDeliveryServiceServer).ListCanaryRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ProgressiveDeliveryService/ListCanaryRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProgressiveDeliveryServiceServer).ListCanaryRevisions(ctx, req.(*ListCanaryRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProgressiveDeliveryService_ListNotificationRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNotificationRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProgressiveDeliveryServiceServer).ListNotificationRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ProgressiveDeliveryService/ListNotificationRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProgressiveDeliveryServiceServer).ListNotificationRules(ctx, req.(*ListNotificationRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProgressiveDeliveryService_TestNotification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestNotificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProgressiveDeliveryServiceServer).TestNotification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ProgressiveDeliveryService/TestNotification",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProgressiveDeliveryServiceServer).TestNotification(ctx, req.(*TestNotificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProgressiveDeliveryService_WatchCanaries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCanariesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProgressiveDeliveryServiceServer).WatchCanaries(m, &progressiveDeliveryServiceWatchCanariesServer{stream})
+}
+
+type ProgressiveDeliveryService_WatchCanariesServer interface {
+	Send(*WatchCanariesResponse) error
+	grpc.ServerStream
+}
+
+type progressiveDeliveryServiceWatchCanariesServer struct {
+	grpc.ServerStream
+}
+
+func (x *progressiveDeliveryServiceWatchCanariesServer) Send(m *WatchCanariesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // ProgressiveDeliveryService_ServiceDesc is the grpc.ServiceDesc for ProgressiveDeliveryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -294,15 +906,77 @@ var ProgressiveDeliveryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "IsFlaggerAvailable",
 			Handler:    _ProgressiveDeliveryService_IsFlaggerAvailable_Handler,
 		},
+		{
+			MethodName: "IsArgoRolloutsAvailable",
+			Handler:    _ProgressiveDeliveryService_IsArgoRolloutsAvailable_Handler,
+		},
+		{
+			MethodName: "GetClusterCapabilities",
+			Handler:    _ProgressiveDeliveryService_GetClusterCapabilities_Handler,
+		},
+		{
+			MethodName: "GetCanarySummary",
+			Handler:    _ProgressiveDeliveryService_GetCanarySummary_Handler,
+		},
 		{
 			MethodName: "ListMetricTemplates",
 			Handler:    _ProgressiveDeliveryService_ListMetricTemplates_Handler,
 		},
+		{
+			MethodName: "RunMetricTemplate",
+			Handler:    _ProgressiveDeliveryService_RunMetricTemplate_Handler,
+		},
 		{
 			MethodName: "ListCanaryObjects",
 			Handler:    _ProgressiveDeliveryService_ListCanaryObjects_Handler,
 		},
+		{
+			MethodName: "PromoteCanary",
+			Handler:    _ProgressiveDeliveryService_PromoteCanary_Handler,
+		},
+		{
+			MethodName: "RollbackCanary",
+			Handler:    _ProgressiveDeliveryService_RollbackCanary_Handler,
+		},
+		{
+			MethodName: "PauseCanary",
+			Handler:    _ProgressiveDeliveryService_PauseCanary_Handler,
+		},
+		{
+			MethodName: "ResumeCanary",
+			Handler:    _ProgressiveDeliveryService_ResumeCanary_Handler,
+		},
+		{
+			MethodName: "ReconcileCanaryAutomation",
+			Handler:    _ProgressiveDeliveryService_ReconcileCanaryAutomation_Handler,
+		},
+		{
+			MethodName: "ValidateCanary",
+			Handler:    _ProgressiveDeliveryService_ValidateCanary_Handler,
+		},
+		{
+			MethodName: "ListCanaryEvents",
+			Handler:    _ProgressiveDeliveryService_ListCanaryEvents_Handler,
+		},
+		{
+			MethodName: "ListCanaryRevisions",
+			Handler:    _ProgressiveDeliveryService_ListCanaryRevisions_Handler,
+		},
+		{
+			MethodName: "ListNotificationRules",
+			Handler:    _ProgressiveDeliveryService_ListNotificationRules_Handler,
+		},
+		{
+			MethodName: "TestNotification",
+			Handler:    _ProgressiveDeliveryService_TestNotification_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchCanaries",
+			Handler:       _ProgressiveDeliveryService_WatchCanaries_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/prog/prog.proto",
 }
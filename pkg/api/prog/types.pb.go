@@ -143,16 +143,20 @@ type Canary struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Namespace          string                  `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Name               string                  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	ClusterName        string                  `protobuf:"bytes,3,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
-	Provider           string                  `protobuf:"bytes,4,opt,name=provider,proto3" json:"provider,omitempty"`
-	TargetReference    *CanaryTargetReference  `protobuf:"bytes,5,opt,name=target_reference,json=targetReference,proto3" json:"target_reference,omitempty"`
+	Namespace       string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ClusterName     string                 `protobuf:"bytes,3,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	Provider        string                 `protobuf:"bytes,4,opt,name=provider,proto3" json:"provider,omitempty"`
+	TargetReference *CanaryTargetReference `protobuf:"bytes,5,opt,name=target_reference,json=targetReference,proto3" json:"target_reference,omitempty"`
+	// Deprecated: use target_workload, set for every kind of target.
 	TargetDeployment   *CanaryTargetDeployment `protobuf:"bytes,6,opt,name=target_deployment,json=targetDeployment,proto3" json:"target_deployment,omitempty"`
 	Status             *CanaryStatus           `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
 	DeploymentStrategy string                  `protobuf:"bytes,8,opt,name=deploymentStrategy,proto3" json:"deploymentStrategy,omitempty"`
 	Analysis           *CanaryAnalysis         `protobuf:"bytes,9,opt,name=analysis,proto3" json:"analysis,omitempty"`
 	Yaml               string                  `protobuf:"bytes,10,opt,name=yaml,proto3" json:"yaml,omitempty"`
+	// Controller managing the object, flagger or argo-rollouts.
+	Controller     string                `protobuf:"bytes,11,opt,name=controller,proto3" json:"controller,omitempty"`
+	TargetWorkload *CanaryTargetWorkload `protobuf:"bytes,12,opt,name=target_workload,json=targetWorkload,proto3" json:"target_workload,omitempty"`
 }
 
 func (x *Canary) Reset() {
@@ -257,6 +261,20 @@ func (x *Canary) GetYaml() string {
 	return ""
 }
 
+func (x *Canary) GetController() string {
+	if x != nil {
+		return x.Controller
+	}
+	return ""
+}
+
+func (x *Canary) GetTargetWorkload() *CanaryTargetWorkload {
+	if x != nil {
+		return x.TargetWorkload
+	}
+	return nil
+}
+
 type CanaryTargetReference struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -565,17 +583,27 @@ func (x *CanaryTargetDeployment) GetPromotedImageVersions() map[string]string {
 	return nil
 }
 
-type FluxLabels struct {
+// CanaryTargetWorkload is the object a Canary targets, a Deployment, a
+// DaemonSet or a Service. Services have no images.
+type CanaryTargetWorkload struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	KustomizeNamespace string `protobuf:"bytes,1,opt,name=kustomize_namespace,json=kustomizeNamespace,proto3" json:"kustomize_namespace,omitempty"`
-	KustomizeName      string `protobuf:"bytes,2,opt,name=kustomize_name,json=kustomizeName,proto3" json:"kustomize_name,omitempty"`
+	Kind                  string            `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name                  string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Uid                   string            `protobuf:"bytes,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	ResourceVersion       string            `protobuf:"bytes,4,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+	FluxLabels            *FluxLabels       `protobuf:"bytes,5,opt,name=flux_labels,json=fluxLabels,proto3" json:"flux_labels,omitempty"`
+	AppliedImageVersions  map[string]string `protobuf:"bytes,6,rep,name=applied_image_versions,json=appliedImageVersions,proto3" json:"applied_image_versions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	PromotedImageVersions map[string]string `protobuf:"bytes,7,rep,name=promoted_image_versions,json=promotedImageVersions,proto3" json:"promoted_image_versions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Containers of the target differing from the primary, empty until the
+	// primary is created.
+	ContainerDiffs []*CanaryContainerDiff `protobuf:"bytes,8,rep,name=container_diffs,json=containerDiffs,proto3" json:"container_diffs,omitempty"`
 }
 
-func (x *FluxLabels) Reset() {
-	*x = FluxLabels{}
+func (x *CanaryTargetWorkload) Reset() {
+	*x = CanaryTargetWorkload{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_prog_types_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -583,13 +611,13 @@ func (x *FluxLabels) Reset() {
 	}
 }
 
-func (x *FluxLabels) String() string {
+func (x *CanaryTargetWorkload) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FluxLabels) ProtoMessage() {}
+func (*CanaryTargetWorkload) ProtoMessage() {}
 
-func (x *FluxLabels) ProtoReflect() protoreflect.Message {
+func (x *CanaryTargetWorkload) ProtoReflect() protoreflect.Message {
 	mi := &file_api_prog_types_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -601,123 +629,105 @@ func (x *FluxLabels) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FluxLabels.ProtoReflect.Descriptor instead.
-func (*FluxLabels) Descriptor() ([]byte, []int) {
+// Deprecated: Use CanaryTargetWorkload.ProtoReflect.Descriptor instead.
+func (*CanaryTargetWorkload) Descriptor() ([]byte, []int) {
 	return file_api_prog_types_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *FluxLabels) GetKustomizeNamespace() string {
+func (x *CanaryTargetWorkload) GetKind() string {
 	if x != nil {
-		return x.KustomizeNamespace
+		return x.Kind
 	}
 	return ""
 }
 
-func (x *FluxLabels) GetKustomizeName() string {
+func (x *CanaryTargetWorkload) GetName() string {
 	if x != nil {
-		return x.KustomizeName
+		return x.Name
 	}
 	return ""
 }
 
-type Automation struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Kind      string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
-	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
-}
-
-func (x *Automation) Reset() {
-	*x = Automation{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_prog_types_proto_msgTypes[8]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *CanaryTargetWorkload) GetUid() string {
+	if x != nil {
+		return x.Uid
 	}
+	return ""
 }
 
-func (x *Automation) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*Automation) ProtoMessage() {}
-
-func (x *Automation) ProtoReflect() protoreflect.Message {
-	mi := &file_api_prog_types_proto_msgTypes[8]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *CanaryTargetWorkload) GetResourceVersion() string {
+	if x != nil {
+		return x.ResourceVersion
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use Automation.ProtoReflect.Descriptor instead.
-func (*Automation) Descriptor() ([]byte, []int) {
-	return file_api_prog_types_proto_rawDescGZIP(), []int{8}
+func (x *CanaryTargetWorkload) GetFluxLabels() *FluxLabels {
+	if x != nil {
+		return x.FluxLabels
+	}
+	return nil
 }
 
-func (x *Automation) GetKind() string {
+func (x *CanaryTargetWorkload) GetAppliedImageVersions() map[string]string {
 	if x != nil {
-		return x.Kind
+		return x.AppliedImageVersions
 	}
-	return ""
+	return nil
 }
 
-func (x *Automation) GetName() string {
+func (x *CanaryTargetWorkload) GetPromotedImageVersions() map[string]string {
 	if x != nil {
-		return x.Name
+		return x.PromotedImageVersions
 	}
-	return ""
+	return nil
 }
 
-func (x *Automation) GetNamespace() string {
+func (x *CanaryTargetWorkload) GetContainerDiffs() []*CanaryContainerDiff {
 	if x != nil {
-		return x.Namespace
+		return x.ContainerDiffs
 	}
-	return ""
+	return nil
 }
 
-type CanaryAnalysis struct {
+// CanaryContainerDiff is the difference of a container of the target from the
+// one of the primary with the same name.
+type CanaryContainerDiff struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Interval            string          `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`
-	Iterations          int32           `protobuf:"varint,2,opt,name=iterations,proto3" json:"iterations,omitempty"`
-	MirrorWeight        int32           `protobuf:"varint,3,opt,name=mirror_weight,json=mirrorWeight,proto3" json:"mirror_weight,omitempty"`
-	MaxWeight           int32           `protobuf:"varint,4,opt,name=max_weight,json=maxWeight,proto3" json:"max_weight,omitempty"`
-	StepWeight          int32           `protobuf:"varint,5,opt,name=step_weight,json=stepWeight,proto3" json:"step_weight,omitempty"`
-	StepWeightPromotion int32           `protobuf:"varint,6,opt,name=step_weight_promotion,json=stepWeightPromotion,proto3" json:"step_weight_promotion,omitempty"`
-	Threshold           int32           `protobuf:"varint,7,opt,name=threshold,proto3" json:"threshold,omitempty"`
-	StepWeights         []int32         `protobuf:"varint,8,rep,packed,name=step_weights,json=stepWeights,proto3" json:"step_weights,omitempty"`
-	Mirror              bool            `protobuf:"varint,9,opt,name=mirror,proto3" json:"mirror,omitempty"`
-	Yaml                string          `protobuf:"bytes,10,opt,name=yaml,proto3" json:"yaml,omitempty"`
-	Metrics             []*CanaryMetric `protobuf:"bytes,11,rep,name=metrics,proto3" json:"metrics,omitempty"`
-}
-
-func (x *CanaryAnalysis) Reset() {
-	*x = CanaryAnalysis{}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// added, removed or changed.
+	Change            string                `protobuf:"bytes,2,opt,name=change,proto3" json:"change,omitempty"`
+	TargetImage       *CanaryImageReference `protobuf:"bytes,3,opt,name=target_image,json=targetImage,proto3" json:"target_image,omitempty"`
+	PromotedImage     *CanaryImageReference `protobuf:"bytes,4,opt,name=promoted_image,json=promotedImage,proto3" json:"promoted_image,omitempty"`
+	RepositoryChanged bool                  `protobuf:"varint,5,opt,name=repository_changed,json=repositoryChanged,proto3" json:"repository_changed,omitempty"`
+	TagChanged        bool                  `protobuf:"varint,6,opt,name=tag_changed,json=tagChanged,proto3" json:"tag_changed,omitempty"`
+	DigestChanged     bool                  `protobuf:"varint,7,opt,name=digest_changed,json=digestChanged,proto3" json:"digest_changed,omitempty"`
+	Env               []*CanaryEnvVarDiff   `protobuf:"bytes,8,rep,name=env,proto3" json:"env,omitempty"`
+	ResourcesChanged  bool                  `protobuf:"varint,9,opt,name=resources_changed,json=resourcesChanged,proto3" json:"resources_changed,omitempty"`
+	// Probes changed, liveness, readiness or startup.
+	ChangedProbes []string `protobuf:"bytes,10,rep,name=changed_probes,json=changedProbes,proto3" json:"changed_probes,omitempty"`
+}
+
+func (x *CanaryContainerDiff) Reset() {
+	*x = CanaryContainerDiff{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_prog_types_proto_msgTypes[9]
+		mi := &file_api_prog_types_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CanaryAnalysis) String() string {
+func (x *CanaryContainerDiff) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CanaryAnalysis) ProtoMessage() {}
+func (*CanaryContainerDiff) ProtoMessage() {}
 
-func (x *CanaryAnalysis) ProtoReflect() protoreflect.Message {
-	mi := &file_api_prog_types_proto_msgTypes[9]
+func (x *CanaryContainerDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -728,117 +738,108 @@ func (x *CanaryAnalysis) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CanaryAnalysis.ProtoReflect.Descriptor instead.
-func (*CanaryAnalysis) Descriptor() ([]byte, []int) {
-	return file_api_prog_types_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use CanaryContainerDiff.ProtoReflect.Descriptor instead.
+func (*CanaryContainerDiff) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *CanaryAnalysis) GetInterval() string {
+func (x *CanaryContainerDiff) GetName() string {
 	if x != nil {
-		return x.Interval
+		return x.Name
 	}
 	return ""
 }
 
-func (x *CanaryAnalysis) GetIterations() int32 {
+func (x *CanaryContainerDiff) GetChange() string {
 	if x != nil {
-		return x.Iterations
+		return x.Change
 	}
-	return 0
+	return ""
 }
 
-func (x *CanaryAnalysis) GetMirrorWeight() int32 {
+func (x *CanaryContainerDiff) GetTargetImage() *CanaryImageReference {
 	if x != nil {
-		return x.MirrorWeight
+		return x.TargetImage
 	}
-	return 0
+	return nil
 }
 
-func (x *CanaryAnalysis) GetMaxWeight() int32 {
+func (x *CanaryContainerDiff) GetPromotedImage() *CanaryImageReference {
 	if x != nil {
-		return x.MaxWeight
+		return x.PromotedImage
 	}
-	return 0
+	return nil
 }
 
-func (x *CanaryAnalysis) GetStepWeight() int32 {
+func (x *CanaryContainerDiff) GetRepositoryChanged() bool {
 	if x != nil {
-		return x.StepWeight
+		return x.RepositoryChanged
 	}
-	return 0
+	return false
 }
 
-func (x *CanaryAnalysis) GetStepWeightPromotion() int32 {
+func (x *CanaryContainerDiff) GetTagChanged() bool {
 	if x != nil {
-		return x.StepWeightPromotion
+		return x.TagChanged
 	}
-	return 0
+	return false
 }
 
-func (x *CanaryAnalysis) GetThreshold() int32 {
+func (x *CanaryContainerDiff) GetDigestChanged() bool {
 	if x != nil {
-		return x.Threshold
+		return x.DigestChanged
 	}
-	return 0
+	return false
 }
 
-func (x *CanaryAnalysis) GetStepWeights() []int32 {
+func (x *CanaryContainerDiff) GetEnv() []*CanaryEnvVarDiff {
 	if x != nil {
-		return x.StepWeights
+		return x.Env
 	}
 	return nil
 }
 
-func (x *CanaryAnalysis) GetMirror() bool {
+func (x *CanaryContainerDiff) GetResourcesChanged() bool {
 	if x != nil {
-		return x.Mirror
+		return x.ResourcesChanged
 	}
 	return false
 }
 
-func (x *CanaryAnalysis) GetYaml() string {
-	if x != nil {
-		return x.Yaml
-	}
-	return ""
-}
-
-func (x *CanaryAnalysis) GetMetrics() []*CanaryMetric {
+func (x *CanaryContainerDiff) GetChangedProbes() []string {
 	if x != nil {
-		return x.Metrics
+		return x.ChangedProbes
 	}
 	return nil
 }
 
-type CanaryMetric struct {
+type CanaryImageReference struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name           string                      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Namespace      string                      `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	ThresholdRange *CanaryMetricThresholdRange `protobuf:"bytes,3,opt,name=threshold_range,json=thresholdRange,proto3" json:"threshold_range,omitempty"`
-	Interval       string                      `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"`
-	MetricTemplate *CanaryMetricTemplate       `protobuf:"bytes,5,opt,name=metric_template,json=metricTemplate,proto3" json:"metric_template,omitempty"`
+	Repository string `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	Tag        string `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	Digest     string `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
 }
 
-func (x *CanaryMetric) Reset() {
-	*x = CanaryMetric{}
+func (x *CanaryImageReference) Reset() {
+	*x = CanaryImageReference{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_prog_types_proto_msgTypes[10]
+		mi := &file_api_prog_types_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CanaryMetric) String() string {
+func (x *CanaryImageReference) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CanaryMetric) ProtoMessage() {}
+func (*CanaryImageReference) ProtoMessage() {}
 
-func (x *CanaryMetric) ProtoReflect() protoreflect.Message {
-	mi := &file_api_prog_types_proto_msgTypes[10]
+func (x *CanaryImageReference) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -849,72 +850,64 @@ func (x *CanaryMetric) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CanaryMetric.ProtoReflect.Descriptor instead.
-func (*CanaryMetric) Descriptor() ([]byte, []int) {
-	return file_api_prog_types_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use CanaryImageReference.ProtoReflect.Descriptor instead.
+func (*CanaryImageReference) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *CanaryMetric) GetName() string {
+func (x *CanaryImageReference) GetRepository() string {
 	if x != nil {
-		return x.Name
+		return x.Repository
 	}
 	return ""
 }
 
-func (x *CanaryMetric) GetNamespace() string {
+func (x *CanaryImageReference) GetTag() string {
 	if x != nil {
-		return x.Namespace
+		return x.Tag
 	}
 	return ""
 }
 
-func (x *CanaryMetric) GetThresholdRange() *CanaryMetricThresholdRange {
-	if x != nil {
-		return x.ThresholdRange
-	}
-	return nil
-}
-
-func (x *CanaryMetric) GetInterval() string {
+func (x *CanaryImageReference) GetDigest() string {
 	if x != nil {
-		return x.Interval
+		return x.Digest
 	}
 	return ""
 }
 
-func (x *CanaryMetric) GetMetricTemplate() *CanaryMetricTemplate {
-	if x != nil {
-		return x.MetricTemplate
-	}
-	return nil
-}
-
-type CanaryMetricThresholdRange struct {
+// CanaryEnvVarDiff is the difference of an environment variable. Values from
+// references are shown as the reference, for example
+// secretKeyRef:<name>/<key>.
+type CanaryEnvVarDiff struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Min float64 `protobuf:"fixed64,1,opt,name=min,proto3" json:"min,omitempty"`
-	Max float64 `protobuf:"fixed64,2,opt,name=max,proto3" json:"max,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// added, removed or changed.
+	Change        string `protobuf:"bytes,2,opt,name=change,proto3" json:"change,omitempty"`
+	TargetValue   string `protobuf:"bytes,3,opt,name=target_value,json=targetValue,proto3" json:"target_value,omitempty"`
+	PromotedValue string `protobuf:"bytes,4,opt,name=promoted_value,json=promotedValue,proto3" json:"promoted_value,omitempty"`
 }
 
-func (x *CanaryMetricThresholdRange) Reset() {
-	*x = CanaryMetricThresholdRange{}
+func (x *CanaryEnvVarDiff) Reset() {
+	*x = CanaryEnvVarDiff{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_prog_types_proto_msgTypes[11]
+		mi := &file_api_prog_types_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CanaryMetricThresholdRange) String() string {
+func (x *CanaryEnvVarDiff) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CanaryMetricThresholdRange) ProtoMessage() {}
+func (*CanaryEnvVarDiff) ProtoMessage() {}
 
-func (x *CanaryMetricThresholdRange) ProtoReflect() protoreflect.Message {
-	mi := &file_api_prog_types_proto_msgTypes[11]
+func (x *CanaryEnvVarDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -925,55 +918,65 @@ func (x *CanaryMetricThresholdRange) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CanaryMetricThresholdRange.ProtoReflect.Descriptor instead.
-func (*CanaryMetricThresholdRange) Descriptor() ([]byte, []int) {
-	return file_api_prog_types_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use CanaryEnvVarDiff.ProtoReflect.Descriptor instead.
+func (*CanaryEnvVarDiff) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *CanaryMetricThresholdRange) GetMin() float64 {
+func (x *CanaryEnvVarDiff) GetName() string {
 	if x != nil {
-		return x.Min
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *CanaryMetricThresholdRange) GetMax() float64 {
+func (x *CanaryEnvVarDiff) GetChange() string {
 	if x != nil {
-		return x.Max
+		return x.Change
 	}
-	return 0
+	return ""
 }
 
-type CanaryMetricTemplate struct {
+func (x *CanaryEnvVarDiff) GetTargetValue() string {
+	if x != nil {
+		return x.TargetValue
+	}
+	return ""
+}
+
+func (x *CanaryEnvVarDiff) GetPromotedValue() string {
+	if x != nil {
+		return x.PromotedValue
+	}
+	return ""
+}
+
+type FluxLabels struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ClusterName string          `protobuf:"bytes,1,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
-	Name        string          `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Namespace   string          `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Provider    *MetricProvider `protobuf:"bytes,4,opt,name=provider,proto3" json:"provider,omitempty"`
-	Query       string          `protobuf:"bytes,5,opt,name=query,proto3" json:"query,omitempty"`
-	Yaml        string          `protobuf:"bytes,6,opt,name=yaml,proto3" json:"yaml,omitempty"`
+	KustomizeNamespace string `protobuf:"bytes,1,opt,name=kustomize_namespace,json=kustomizeNamespace,proto3" json:"kustomize_namespace,omitempty"`
+	KustomizeName      string `protobuf:"bytes,2,opt,name=kustomize_name,json=kustomizeName,proto3" json:"kustomize_name,omitempty"`
 }
 
-func (x *CanaryMetricTemplate) Reset() {
-	*x = CanaryMetricTemplate{}
+func (x *FluxLabels) Reset() {
+	*x = FluxLabels{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_prog_types_proto_msgTypes[12]
+		mi := &file_api_prog_types_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CanaryMetricTemplate) String() string {
+func (x *FluxLabels) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CanaryMetricTemplate) ProtoMessage() {}
+func (*FluxLabels) ProtoMessage() {}
 
-func (x *CanaryMetricTemplate) ProtoReflect() protoreflect.Message {
-	mi := &file_api_prog_types_proto_msgTypes[12]
+func (x *FluxLabels) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -984,81 +987,1559 @@ func (x *CanaryMetricTemplate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CanaryMetricTemplate.ProtoReflect.Descriptor instead.
-func (*CanaryMetricTemplate) Descriptor() ([]byte, []int) {
-	return file_api_prog_types_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use FluxLabels.ProtoReflect.Descriptor instead.
+func (*FluxLabels) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *CanaryMetricTemplate) GetClusterName() string {
+func (x *FluxLabels) GetKustomizeNamespace() string {
+	if x != nil {
+		return x.KustomizeNamespace
+	}
+	return ""
+}
+
+func (x *FluxLabels) GetKustomizeName() string {
+	if x != nil {
+		return x.KustomizeName
+	}
+	return ""
+}
+
+type Automation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind      string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// The fields below are unset if the object can't be read.
+	Suspended             bool       `protobuf:"varint,4,opt,name=suspended,proto3" json:"suspended,omitempty"`
+	Ready                 *Condition `protobuf:"bytes,5,opt,name=ready,proto3" json:"ready,omitempty"`
+	LastAppliedRevision   string     `protobuf:"bytes,6,opt,name=last_applied_revision,json=lastAppliedRevision,proto3" json:"last_applied_revision,omitempty"`
+	LastAttemptedRevision string     `protobuf:"bytes,7,opt,name=last_attempted_revision,json=lastAttemptedRevision,proto3" json:"last_attempted_revision,omitempty"`
+	// Git commit applied, empty if the source isn't a GitRepository.
+	Commit string            `protobuf:"bytes,8,opt,name=commit,proto3" json:"commit,omitempty"`
+	Source *AutomationSource `protobuf:"bytes,9,opt,name=source,proto3" json:"source,omitempty"`
+}
+
+func (x *Automation) Reset() {
+	*x = Automation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_prog_types_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Automation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Automation) ProtoMessage() {}
+
+func (x *Automation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Automation.ProtoReflect.Descriptor instead.
+func (*Automation) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Automation) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Automation) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Automation) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Automation) GetSuspended() bool {
+	if x != nil {
+		return x.Suspended
+	}
+	return false
+}
+
+func (x *Automation) GetReady() *Condition {
+	if x != nil {
+		return x.Ready
+	}
+	return nil
+}
+
+func (x *Automation) GetLastAppliedRevision() string {
+	if x != nil {
+		return x.LastAppliedRevision
+	}
+	return ""
+}
+
+func (x *Automation) GetLastAttemptedRevision() string {
+	if x != nil {
+		return x.LastAttemptedRevision
+	}
+	return ""
+}
+
+func (x *Automation) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *Automation) GetSource() *AutomationSource {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+// AutomationSource is the Flux source a Kustomization or a HelmRelease
+// applies.
+type AutomationSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind      string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Url       string `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	// Revision of the latest artifact of the source.
+	Revision string     `protobuf:"bytes,5,opt,name=revision,proto3" json:"revision,omitempty"`
+	Ready    *Condition `protobuf:"bytes,6,opt,name=ready,proto3" json:"ready,omitempty"`
+}
+
+func (x *AutomationSource) Reset() {
+	*x = AutomationSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_prog_types_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AutomationSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutomationSource) ProtoMessage() {}
+
+func (x *AutomationSource) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutomationSource.ProtoReflect.Descriptor instead.
+func (*AutomationSource) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AutomationSource) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *AutomationSource) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AutomationSource) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *AutomationSource) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *AutomationSource) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+func (x *AutomationSource) GetReady() *Condition {
+	if x != nil {
+		return x.Ready
+	}
+	return nil
+}
+
+type CanaryAnalysis struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Interval            string          `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	Iterations          int32           `protobuf:"varint,2,opt,name=iterations,proto3" json:"iterations,omitempty"`
+	MirrorWeight        int32           `protobuf:"varint,3,opt,name=mirror_weight,json=mirrorWeight,proto3" json:"mirror_weight,omitempty"`
+	MaxWeight           int32           `protobuf:"varint,4,opt,name=max_weight,json=maxWeight,proto3" json:"max_weight,omitempty"`
+	StepWeight          int32           `protobuf:"varint,5,opt,name=step_weight,json=stepWeight,proto3" json:"step_weight,omitempty"`
+	StepWeightPromotion int32           `protobuf:"varint,6,opt,name=step_weight_promotion,json=stepWeightPromotion,proto3" json:"step_weight_promotion,omitempty"`
+	Threshold           int32           `protobuf:"varint,7,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	StepWeights         []int32         `protobuf:"varint,8,rep,packed,name=step_weights,json=stepWeights,proto3" json:"step_weights,omitempty"`
+	Mirror              bool            `protobuf:"varint,9,opt,name=mirror,proto3" json:"mirror,omitempty"`
+	Yaml                string          `protobuf:"bytes,10,opt,name=yaml,proto3" json:"yaml,omitempty"`
+	Metrics             []*CanaryMetric `protobuf:"bytes,11,rep,name=metrics,proto3" json:"metrics,omitempty"`
+}
+
+func (x *CanaryAnalysis) Reset() {
+	*x = CanaryAnalysis{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_prog_types_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CanaryAnalysis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanaryAnalysis) ProtoMessage() {}
+
+func (x *CanaryAnalysis) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanaryAnalysis.ProtoReflect.Descriptor instead.
+func (*CanaryAnalysis) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CanaryAnalysis) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *CanaryAnalysis) GetIterations() int32 {
+	if x != nil {
+		return x.Iterations
+	}
+	return 0
+}
+
+func (x *CanaryAnalysis) GetMirrorWeight() int32 {
+	if x != nil {
+		return x.MirrorWeight
+	}
+	return 0
+}
+
+func (x *CanaryAnalysis) GetMaxWeight() int32 {
+	if x != nil {
+		return x.MaxWeight
+	}
+	return 0
+}
+
+func (x *CanaryAnalysis) GetStepWeight() int32 {
+	if x != nil {
+		return x.StepWeight
+	}
+	return 0
+}
+
+func (x *CanaryAnalysis) GetStepWeightPromotion() int32 {
+	if x != nil {
+		return x.StepWeightPromotion
+	}
+	return 0
+}
+
+func (x *CanaryAnalysis) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *CanaryAnalysis) GetStepWeights() []int32 {
+	if x != nil {
+		return x.StepWeights
+	}
+	return nil
+}
+
+func (x *CanaryAnalysis) GetMirror() bool {
+	if x != nil {
+		return x.Mirror
+	}
+	return false
+}
+
+func (x *CanaryAnalysis) GetYaml() string {
+	if x != nil {
+		return x.Yaml
+	}
+	return ""
+}
+
+func (x *CanaryAnalysis) GetMetrics() []*CanaryMetric {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+type CanaryMetric struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name           string                      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace      string                      `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	ThresholdRange *CanaryMetricThresholdRange `protobuf:"bytes,3,opt,name=threshold_range,json=thresholdRange,proto3" json:"threshold_range,omitempty"`
+	Interval       string                      `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"`
+	MetricTemplate *CanaryMetricTemplate       `protobuf:"bytes,5,opt,name=metric_template,json=metricTemplate,proto3" json:"metric_template,omitempty"`
+}
+
+func (x *CanaryMetric) Reset() {
+	*x = CanaryMetric{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_prog_types_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CanaryMetric) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanaryMetric) ProtoMessage() {}
+
+func (x *CanaryMetric) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanaryMetric.ProtoReflect.Descriptor instead.
+func (*CanaryMetric) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CanaryMetric) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CanaryMetric) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *CanaryMetric) GetThresholdRange() *CanaryMetricThresholdRange {
+	if x != nil {
+		return x.ThresholdRange
+	}
+	return nil
+}
+
+func (x *CanaryMetric) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *CanaryMetric) GetMetricTemplate() *CanaryMetricTemplate {
+	if x != nil {
+		return x.MetricTemplate
+	}
+	return nil
+}
+
+type CanaryMetricThresholdRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Min float64 `protobuf:"fixed64,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max float64 `protobuf:"fixed64,2,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *CanaryMetricThresholdRange) Reset() {
+	*x = CanaryMetricThresholdRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_prog_types_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CanaryMetricThresholdRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanaryMetricThresholdRange) ProtoMessage() {}
+
+func (x *CanaryMetricThresholdRange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanaryMetricThresholdRange.ProtoReflect.Descriptor instead.
+func (*CanaryMetricThresholdRange) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CanaryMetricThresholdRange) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *CanaryMetricThresholdRange) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+// CanaryCounts counts Canaries, keyed by phase, deployment strategy and
+// provider.
+type CanaryCounts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total      int32            `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Phases     map[string]int32 `protobuf:"bytes,2,rep,name=phases,proto3" json:"phases,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Strategies map[string]int32 `protobuf:"bytes,3,rep,name=strategies,proto3" json:"strategies,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Providers  map[string]int32 `protobuf:"bytes,4,rep,name=providers,proto3" json:"providers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *CanaryCounts) Reset() {
+	*x = CanaryCounts{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_prog_types_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CanaryCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanaryCounts) ProtoMessage() {}
+
+func (x *CanaryCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanaryCounts.ProtoReflect.Descriptor instead.
+func (*CanaryCounts) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CanaryCounts) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *CanaryCounts) GetPhases() map[string]int32 {
+	if x != nil {
+		return x.Phases
+	}
+	return nil
+}
+
+func (x *CanaryCounts) GetStrategies() map[string]int32 {
+	if x != nil {
+		return x.Strategies
+	}
+	return nil
+}
+
+func (x *CanaryCounts) GetProviders() map[string]int32 {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+// CanaryMetricCheck is the result of a metric checked against the threshold
+// range of a Canary referencing its template.
+type CanaryMetricCheck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CanaryName      string                      `protobuf:"bytes,1,opt,name=canary_name,json=canaryName,proto3" json:"canary_name,omitempty"`
+	CanaryNamespace string                      `protobuf:"bytes,2,opt,name=canary_namespace,json=canaryNamespace,proto3" json:"canary_namespace,omitempty"`
+	MetricName      string                      `protobuf:"bytes,3,opt,name=metric_name,json=metricName,proto3" json:"metric_name,omitempty"`
+	ThresholdRange  *CanaryMetricThresholdRange `protobuf:"bytes,4,opt,name=threshold_range,json=thresholdRange,proto3" json:"threshold_range,omitempty"`
+	WithinRange     bool                        `protobuf:"varint,5,opt,name=within_range,json=withinRange,proto3" json:"within_range,omitempty"`
+}
+
+func (x *CanaryMetricCheck) Reset() {
+	*x = CanaryMetricCheck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_prog_types_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CanaryMetricCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanaryMetricCheck) ProtoMessage() {}
+
+func (x *CanaryMetricCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanaryMetricCheck.ProtoReflect.Descriptor instead.
+func (*CanaryMetricCheck) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CanaryMetricCheck) GetCanaryName() string {
+	if x != nil {
+		return x.CanaryName
+	}
+	return ""
+}
+
+func (x *CanaryMetricCheck) GetCanaryNamespace() string {
+	if x != nil {
+		return x.CanaryNamespace
+	}
+	return ""
+}
+
+func (x *CanaryMetricCheck) GetMetricName() string {
+	if x != nil {
+		return x.MetricName
+	}
+	return ""
+}
+
+func (x *CanaryMetricCheck) GetThresholdRange() *CanaryMetricThresholdRange {
+	if x != nil {
+		return x.ThresholdRange
+	}
+	return nil
+}
+
+func (x *CanaryMetricCheck) GetWithinRange() bool {
+	if x != nil {
+		return x.WithinRange
+	}
+	return false
+}
+
+type CanaryMetricTemplate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClusterName string          `protobuf:"bytes,1,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	Name        string          `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace   string          `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Provider    *MetricProvider `protobuf:"bytes,4,opt,name=provider,proto3" json:"provider,omitempty"`
+	Query       string          `protobuf:"bytes,5,opt,name=query,proto3" json:"query,omitempty"`
+	Yaml        string          `protobuf:"bytes,6,opt,name=yaml,proto3" json:"yaml,omitempty"`
+	// Controller the template belongs to, flagger or argo-rollouts.
+	Controller string `protobuf:"bytes,7,opt,name=controller,proto3" json:"controller,omitempty"`
+}
+
+func (x *CanaryMetricTemplate) Reset() {
+	*x = CanaryMetricTemplate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_prog_types_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CanaryMetricTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanaryMetricTemplate) ProtoMessage() {}
+
+func (x *CanaryMetricTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanaryMetricTemplate.ProtoReflect.Descriptor instead.
+func (*CanaryMetricTemplate) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CanaryMetricTemplate) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *CanaryMetricTemplate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CanaryMetricTemplate) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *CanaryMetricTemplate) GetProvider() *MetricProvider {
+	if x != nil {
+		return x.Provider
+	}
+	return nil
+}
+
+func (x *CanaryMetricTemplate) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *CanaryMetricTemplate) GetYaml() string {
+	if x != nil {
+		return x.Yaml
+	}
+	return ""
+}
+
+func (x *CanaryMetricTemplate) GetController() string {
+	if x != nil {
+		return x.Controller
+	}
+	return ""
+}
+
+type MetricProvider struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type               string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Address            string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	SecretName         string `protobuf:"bytes,4,opt,name=secret_name,json=secretName,proto3" json:"secret_name,omitempty"`
+	InsecureSkipVerify bool   `protobuf:"varint,3,opt,name=insecure_skip_verify,json=insecureSkipVerify,proto3" json:"insecure_skip_verify,omitempty"`
+}
+
+func (x *MetricProvider) Reset() {
+	*x = MetricProvider{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_prog_types_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MetricProvider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricProvider) ProtoMessage() {}
+
+func (x *MetricProvider) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricProvider.ProtoReflect.Descriptor instead.
+func (*MetricProvider) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MetricProvider) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *MetricProvider) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *MetricProvider) GetSecretName() string {
+	if x != nil {
+		return x.SecretName
+	}
+	return ""
+}
+
+func (x *MetricProvider) GetInsecureSkipVerify() bool {
+	if x != nil {
+		return x.InsecureSkipVerify
+	}
+	return false
+}
+
+// CanaryEvent is an entry in the timeline of a Canary rollout
+type CanaryEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// One of new-revision, analysis-started, weight-step, iteration-step,
+	// metric-check, webhook, promotion, rollback or other.
+	Kind         string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Type         string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Reason       string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Message      string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Timestamp    string `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Count        int32  `protobuf:"varint,6,opt,name=count,proto3" json:"count,omitempty"`
+	ObjectKind   string `protobuf:"bytes,7,opt,name=object_kind,json=objectKind,proto3" json:"object_kind,omitempty"`
+	ObjectName   string `protobuf:"bytes,8,opt,name=object_name,json=objectName,proto3" json:"object_name,omitempty"`
+	CanaryWeight int32  `protobuf:"varint,9,opt,name=canary_weight,json=canaryWeight,proto3" json:"canary_weight,omitempty"`
+}
+
+func (x *CanaryEvent) Reset() {
+	*x = CanaryEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_prog_types_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CanaryEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanaryEvent) ProtoMessage() {}
+
+func (x *CanaryEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanaryEvent.ProtoReflect.Descriptor instead.
+func (*CanaryEvent) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CanaryEvent) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *CanaryEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CanaryEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CanaryEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CanaryEvent) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *CanaryEvent) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CanaryEvent) GetObjectKind() string {
+	if x != nil {
+		return x.ObjectKind
+	}
+	return ""
+}
+
+func (x *CanaryEvent) GetObjectName() string {
+	if x != nil {
+		return x.ObjectName
+	}
+	return ""
+}
+
+func (x *CanaryEvent) GetCanaryWeight() int32 {
+	if x != nil {
+		return x.CanaryWeight
+	}
+	return 0
+}
+
+// CanaryRevision is a snapshot of a Canary taken when its phase changed
+type CanaryRevision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClusterName           string            `protobuf:"bytes,1,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	Namespace             string            `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name                  string            `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Phase                 string            `protobuf:"bytes,4,opt,name=phase,proto3" json:"phase,omitempty"`
+	PreviousPhase         string            `protobuf:"bytes,5,opt,name=previous_phase,json=previousPhase,proto3" json:"previous_phase,omitempty"`
+	Timestamp             string            `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	CanaryWeight          int32             `protobuf:"varint,7,opt,name=canary_weight,json=canaryWeight,proto3" json:"canary_weight,omitempty"`
+	FailedChecks          int32             `protobuf:"varint,8,opt,name=failed_checks,json=failedChecks,proto3" json:"failed_checks,omitempty"`
+	Iterations            int32             `protobuf:"varint,9,opt,name=iterations,proto3" json:"iterations,omitempty"`
+	DeploymentStrategy    string            `protobuf:"bytes,10,opt,name=deployment_strategy,json=deploymentStrategy,proto3" json:"deployment_strategy,omitempty"`
+	AppliedImageVersions  map[string]string `protobuf:"bytes,11,rep,name=applied_image_versions,json=appliedImageVersions,proto3" json:"applied_image_versions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	PromotedImageVersions map[string]string `protobuf:"bytes,12,rep,name=promoted_image_versions,json=promotedImageVersions,proto3" json:"promoted_image_versions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *CanaryRevision) Reset() {
+	*x = CanaryRevision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_prog_types_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CanaryRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanaryRevision) ProtoMessage() {}
+
+func (x *CanaryRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanaryRevision.ProtoReflect.Descriptor instead.
+func (*CanaryRevision) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CanaryRevision) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *CanaryRevision) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *CanaryRevision) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CanaryRevision) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *CanaryRevision) GetPreviousPhase() string {
+	if x != nil {
+		return x.PreviousPhase
+	}
+	return ""
+}
+
+func (x *CanaryRevision) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *CanaryRevision) GetCanaryWeight() int32 {
+	if x != nil {
+		return x.CanaryWeight
+	}
+	return 0
+}
+
+func (x *CanaryRevision) GetFailedChecks() int32 {
+	if x != nil {
+		return x.FailedChecks
+	}
+	return 0
+}
+
+func (x *CanaryRevision) GetIterations() int32 {
+	if x != nil {
+		return x.Iterations
+	}
+	return 0
+}
+
+func (x *CanaryRevision) GetDeploymentStrategy() string {
+	if x != nil {
+		return x.DeploymentStrategy
+	}
+	return ""
+}
+
+func (x *CanaryRevision) GetAppliedImageVersions() map[string]string {
+	if x != nil {
+		return x.AppliedImageVersions
+	}
+	return nil
+}
+
+func (x *CanaryRevision) GetPromotedImageVersions() map[string]string {
+	if x != nil {
+		return x.PromotedImageVersions
+	}
+	return nil
+}
+
+// GroupVersionKind represents an objects Kubernetes API type data
+type GroupVersionKind struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Group   string `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Kind    string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Version string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *GroupVersionKind) Reset() {
+	*x = GroupVersionKind{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_prog_types_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupVersionKind) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupVersionKind) ProtoMessage() {}
+
+func (x *GroupVersionKind) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupVersionKind.ProtoReflect.Descriptor instead.
+func (*GroupVersionKind) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GroupVersionKind) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *GroupVersionKind) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *GroupVersionKind) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// UnstructuredObject is a Kubernetes object of an unknown type
+type UnstructuredObject struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupVersionKind *GroupVersionKind `protobuf:"bytes,1,opt,name=groupVersionKind,proto3" json:"groupVersionKind,omitempty"`
+	Name             string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace        string            `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Uid              string            `protobuf:"bytes,4,opt,name=uid,proto3" json:"uid,omitempty"`
+	Status           string            `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Conditions       []*Condition      `protobuf:"bytes,6,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	Suspended        bool              `protobuf:"varint,7,opt,name=suspended,proto3" json:"suspended,omitempty"`
+	ClusterName      string            `protobuf:"bytes,8,opt,name=clusterName,proto3" json:"clusterName,omitempty"`
+	Images           []string          `protobuf:"bytes,9,rep,name=images,proto3" json:"images,omitempty"`
+	// Children are the objects owned by this one, in the tree returned by
+	// ListCanaryObjects.
+	Children []*UnstructuredObject `protobuf:"bytes,10,rep,name=children,proto3" json:"children,omitempty"`
+	// Containers are the statuses of the containers of Pods.
+	Containers []*ContainerStatus `protobuf:"bytes,11,rep,name=containers,proto3" json:"containers,omitempty"`
+	// Restarts is the total restart count of the containers of Pods.
+	Restarts int32 `protobuf:"varint,12,opt,name=restarts,proto3" json:"restarts,omitempty"`
+}
+
+func (x *UnstructuredObject) Reset() {
+	*x = UnstructuredObject{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_prog_types_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnstructuredObject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnstructuredObject) ProtoMessage() {}
+
+func (x *UnstructuredObject) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnstructuredObject.ProtoReflect.Descriptor instead.
+func (*UnstructuredObject) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UnstructuredObject) GetGroupVersionKind() *GroupVersionKind {
+	if x != nil {
+		return x.GroupVersionKind
+	}
+	return nil
+}
+
+func (x *UnstructuredObject) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UnstructuredObject) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *UnstructuredObject) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *UnstructuredObject) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UnstructuredObject) GetConditions() []*Condition {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+func (x *UnstructuredObject) GetSuspended() bool {
+	if x != nil {
+		return x.Suspended
+	}
+	return false
+}
+
+func (x *UnstructuredObject) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *UnstructuredObject) GetImages() []string {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *UnstructuredObject) GetChildren() []*UnstructuredObject {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *UnstructuredObject) GetContainers() []*ContainerStatus {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+func (x *UnstructuredObject) GetRestarts() int32 {
+	if x != nil {
+		return x.Restarts
+	}
+	return 0
+}
+
+// ContainerStatus is the state of a container of a Pod.
+type ContainerStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name         string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Image        string `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	Ready        bool   `protobuf:"varint,3,opt,name=ready,proto3" json:"ready,omitempty"`
+	RestartCount int32  `protobuf:"varint,4,opt,name=restart_count,json=restartCount,proto3" json:"restart_count,omitempty"`
+	// State is either waiting, running or terminated.
+	State     string `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	Reason    string `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Message   string `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	StartedAt string `protobuf:"bytes,8,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// LastTerminationReason is why the previous instance of the container
+	// terminated, OOMKilled for example.
+	LastTerminationReason string `protobuf:"bytes,9,opt,name=last_termination_reason,json=lastTerminationReason,proto3" json:"last_termination_reason,omitempty"`
+}
+
+func (x *ContainerStatus) Reset() {
+	*x = ContainerStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_prog_types_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContainerStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerStatus) ProtoMessage() {}
+
+func (x *ContainerStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerStatus.ProtoReflect.Descriptor instead.
+func (*ContainerStatus) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ContainerStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ContainerStatus) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *ContainerStatus) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *ContainerStatus) GetRestartCount() int32 {
+	if x != nil {
+		return x.RestartCount
+	}
+	return 0
+}
+
+func (x *ContainerStatus) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ContainerStatus) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ContainerStatus) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ContainerStatus) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *ContainerStatus) GetLastTerminationReason() string {
+	if x != nil {
+		return x.LastTerminationReason
+	}
+	return ""
+}
+
+type Condition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type      string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Status    string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Reason    string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Message   string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Timestamp string `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *Condition) Reset() {
+	*x = Condition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_prog_types_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Condition) ProtoMessage() {}
+
+func (x *Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Condition.ProtoReflect.Descriptor instead.
+func (*Condition) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Condition) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Condition) GetStatus() string {
 	if x != nil {
-		return x.ClusterName
+		return x.Status
 	}
 	return ""
 }
 
-func (x *CanaryMetricTemplate) GetName() string {
+func (x *Condition) GetReason() string {
 	if x != nil {
-		return x.Name
+		return x.Reason
 	}
 	return ""
 }
 
-func (x *CanaryMetricTemplate) GetNamespace() string {
+func (x *Condition) GetMessage() string {
 	if x != nil {
-		return x.Namespace
+		return x.Message
 	}
 	return ""
 }
 
-func (x *CanaryMetricTemplate) GetProvider() *MetricProvider {
+func (x *Condition) GetTimestamp() string {
 	if x != nil {
-		return x.Provider
+		return x.Timestamp
 	}
-	return nil
+	return ""
 }
 
-func (x *CanaryMetricTemplate) GetQuery() string {
+// CanaryFinding is a problem found when validating a Canary.
+type CanaryFinding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Severity is either error or warning.
+	Severity string `protobuf:"bytes,1,opt,name=severity,proto3" json:"severity,omitempty"`
+	// Field is the path of the field causing the problem.
+	Field   string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *CanaryFinding) Reset() {
+	*x = CanaryFinding{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_prog_types_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CanaryFinding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanaryFinding) ProtoMessage() {}
+
+func (x *CanaryFinding) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanaryFinding.ProtoReflect.Descriptor instead.
+func (*CanaryFinding) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CanaryFinding) GetSeverity() string {
 	if x != nil {
-		return x.Query
+		return x.Severity
 	}
 	return ""
 }
 
-func (x *CanaryMetricTemplate) GetYaml() string {
+func (x *CanaryFinding) GetField() string {
 	if x != nil {
-		return x.Yaml
+		return x.Field
 	}
 	return ""
 }
 
-type MetricProvider struct {
+func (x *CanaryFinding) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// NotificationRule sends notifications on Canary phase transitions to its
+// receivers.
+type NotificationRule struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type               string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Address            string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
-	SecretName         string `protobuf:"bytes,4,opt,name=secret_name,json=secretName,proto3" json:"secret_name,omitempty"`
-	InsecureSkipVerify bool   `protobuf:"varint,3,opt,name=insecure_skip_verify,json=insecureSkipVerify,proto3" json:"insecure_skip_verify,omitempty"`
+	Name      string                  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Receivers []*NotificationReceiver `protobuf:"bytes,2,rep,name=receivers,proto3" json:"receivers,omitempty"`
+	// Transitions notified, all of them if empty.
+	Transitions []*PhaseTransition `protobuf:"bytes,3,rep,name=transitions,proto3" json:"transitions,omitempty"`
+	// Glob patterns of the clusters and namespaces, any if empty.
+	Clusters   []string `protobuf:"bytes,4,rep,name=clusters,proto3" json:"clusters,omitempty"`
+	Namespaces []string `protobuf:"bytes,5,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	// Label selector of the Canaries, any if empty.
+	Selector string `protobuf:"bytes,6,opt,name=selector,proto3" json:"selector,omitempty"`
 }
 
-func (x *MetricProvider) Reset() {
-	*x = MetricProvider{}
+func (x *NotificationRule) Reset() {
+	*x = NotificationRule{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_prog_types_proto_msgTypes[13]
+		mi := &file_api_prog_types_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *MetricProvider) String() string {
+func (x *NotificationRule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MetricProvider) ProtoMessage() {}
+func (*NotificationRule) ProtoMessage() {}
 
-func (x *MetricProvider) ProtoReflect() protoreflect.Message {
-	mi := &file_api_prog_types_proto_msgTypes[13]
+func (x *NotificationRule) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1069,67 +2550,80 @@ func (x *MetricProvider) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MetricProvider.ProtoReflect.Descriptor instead.
-func (*MetricProvider) Descriptor() ([]byte, []int) {
-	return file_api_prog_types_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use NotificationRule.ProtoReflect.Descriptor instead.
+func (*NotificationRule) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *MetricProvider) GetType() string {
+func (x *NotificationRule) GetName() string {
 	if x != nil {
-		return x.Type
+		return x.Name
 	}
 	return ""
 }
 
-func (x *MetricProvider) GetAddress() string {
+func (x *NotificationRule) GetReceivers() []*NotificationReceiver {
 	if x != nil {
-		return x.Address
+		return x.Receivers
 	}
-	return ""
+	return nil
 }
 
-func (x *MetricProvider) GetSecretName() string {
+func (x *NotificationRule) GetTransitions() []*PhaseTransition {
 	if x != nil {
-		return x.SecretName
+		return x.Transitions
 	}
-	return ""
+	return nil
 }
 
-func (x *MetricProvider) GetInsecureSkipVerify() bool {
+func (x *NotificationRule) GetClusters() []string {
 	if x != nil {
-		return x.InsecureSkipVerify
+		return x.Clusters
 	}
-	return false
+	return nil
 }
 
-// GroupVersionKind represents an objects Kubernetes API type data
-type GroupVersionKind struct {
+func (x *NotificationRule) GetNamespaces() []string {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+func (x *NotificationRule) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+type NotificationReceiver struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Group   string `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
-	Kind    string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
-	Version string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// slack, msteams or generic.
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
 }
 
-func (x *GroupVersionKind) Reset() {
-	*x = GroupVersionKind{}
+func (x *NotificationReceiver) Reset() {
+	*x = NotificationReceiver{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_prog_types_proto_msgTypes[14]
+		mi := &file_api_prog_types_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GroupVersionKind) String() string {
+func (x *NotificationReceiver) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GroupVersionKind) ProtoMessage() {}
+func (*NotificationReceiver) ProtoMessage() {}
 
-func (x *GroupVersionKind) ProtoReflect() protoreflect.Message {
-	mi := &file_api_prog_types_proto_msgTypes[14]
+func (x *NotificationReceiver) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1140,66 +2634,108 @@ func (x *GroupVersionKind) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GroupVersionKind.ProtoReflect.Descriptor instead.
-func (*GroupVersionKind) Descriptor() ([]byte, []int) {
-	return file_api_prog_types_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use NotificationReceiver.ProtoReflect.Descriptor instead.
+func (*NotificationReceiver) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *GroupVersionKind) GetGroup() string {
+func (x *NotificationReceiver) GetName() string {
 	if x != nil {
-		return x.Group
+		return x.Name
 	}
 	return ""
 }
 
-func (x *GroupVersionKind) GetKind() string {
+func (x *NotificationReceiver) GetType() string {
 	if x != nil {
-		return x.Kind
+		return x.Type
 	}
 	return ""
 }
 
-func (x *GroupVersionKind) GetVersion() string {
+// PhaseTransition is a phase change, empty phases match any.
+type PhaseTransition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *PhaseTransition) Reset() {
+	*x = PhaseTransition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_prog_types_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PhaseTransition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhaseTransition) ProtoMessage() {}
+
+func (x *PhaseTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhaseTransition.ProtoReflect.Descriptor instead.
+func (*PhaseTransition) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PhaseTransition) GetFrom() string {
 	if x != nil {
-		return x.Version
+		return x.From
 	}
 	return ""
 }
 
-// UnstructuredObject is a Kubernetes object of an unknown type
-type UnstructuredObject struct {
+func (x *PhaseTransition) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type NotificationDelivery struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	GroupVersionKind *GroupVersionKind `protobuf:"bytes,1,opt,name=groupVersionKind,proto3" json:"groupVersionKind,omitempty"`
-	Name             string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Namespace        string            `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Uid              string            `protobuf:"bytes,4,opt,name=uid,proto3" json:"uid,omitempty"`
-	Status           string            `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
-	Conditions       []*Condition      `protobuf:"bytes,6,rep,name=conditions,proto3" json:"conditions,omitempty"`
-	Suspended        bool              `protobuf:"varint,7,opt,name=suspended,proto3" json:"suspended,omitempty"`
-	ClusterName      string            `protobuf:"bytes,8,opt,name=clusterName,proto3" json:"clusterName,omitempty"`
-	Images           []string          `protobuf:"bytes,9,rep,name=images,proto3" json:"images,omitempty"`
+	Receiver string `protobuf:"bytes,1,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	// Empty if the receiver accepted the notification.
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 }
 
-func (x *UnstructuredObject) Reset() {
-	*x = UnstructuredObject{}
+func (x *NotificationDelivery) Reset() {
+	*x = NotificationDelivery{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_prog_types_proto_msgTypes[15]
+		mi := &file_api_prog_types_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UnstructuredObject) String() string {
+func (x *NotificationDelivery) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UnstructuredObject) ProtoMessage() {}
+func (*NotificationDelivery) ProtoMessage() {}
 
-func (x *UnstructuredObject) ProtoReflect() protoreflect.Message {
-	mi := &file_api_prog_types_proto_msgTypes[15]
+func (x *NotificationDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1210,103 +2746,208 @@ func (x *UnstructuredObject) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UnstructuredObject.ProtoReflect.Descriptor instead.
-func (*UnstructuredObject) Descriptor() ([]byte, []int) {
-	return file_api_prog_types_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use NotificationDelivery.ProtoReflect.Descriptor instead.
+func (*NotificationDelivery) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *UnstructuredObject) GetGroupVersionKind() *GroupVersionKind {
+func (x *NotificationDelivery) GetReceiver() string {
 	if x != nil {
-		return x.GroupVersionKind
+		return x.Receiver
 	}
-	return nil
+	return ""
 }
 
-func (x *UnstructuredObject) GetName() string {
+func (x *NotificationDelivery) GetError() string {
 	if x != nil {
-		return x.Name
+		return x.Error
 	}
 	return ""
 }
 
-func (x *UnstructuredObject) GetNamespace() string {
+// ClusterCapabilities are the progressive delivery tools installed on a
+// cluster.
+type ClusterCapabilities struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClusterName string        `protobuf:"bytes,1,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	Flagger     *InstalledAPI `protobuf:"bytes,2,opt,name=flagger,proto3" json:"flagger,omitempty"`
+	// FlaggerVersion is the tag of the image of Flagger, empty if its
+	// Deployment isn't found.
+	FlaggerVersion string `protobuf:"bytes,3,opt,name=flagger_version,json=flaggerVersion,proto3" json:"flagger_version,omitempty"`
+	// MeshProviders are the Flagger providers whose kinds are installed.
+	MeshProviders   []string          `protobuf:"bytes,4,rep,name=mesh_providers,json=meshProviders,proto3" json:"mesh_providers,omitempty"`
+	GatewayApi      *InstalledAPI     `protobuf:"bytes,5,opt,name=gateway_api,json=gatewayApi,proto3" json:"gateway_api,omitempty"`
+	FluxControllers []*FluxController `protobuf:"bytes,6,rep,name=flux_controllers,json=fluxControllers,proto3" json:"flux_controllers,omitempty"`
+	ArgoRollouts    *InstalledAPI     `protobuf:"bytes,7,opt,name=argo_rollouts,json=argoRollouts,proto3" json:"argo_rollouts,omitempty"`
+}
+
+func (x *ClusterCapabilities) Reset() {
+	*x = ClusterCapabilities{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_prog_types_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClusterCapabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterCapabilities) ProtoMessage() {}
+
+func (x *ClusterCapabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterCapabilities.ProtoReflect.Descriptor instead.
+func (*ClusterCapabilities) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ClusterCapabilities) GetClusterName() string {
 	if x != nil {
-		return x.Namespace
+		return x.ClusterName
 	}
 	return ""
 }
 
-func (x *UnstructuredObject) GetUid() string {
+func (x *ClusterCapabilities) GetFlagger() *InstalledAPI {
 	if x != nil {
-		return x.Uid
+		return x.Flagger
 	}
-	return ""
+	return nil
 }
 
-func (x *UnstructuredObject) GetStatus() string {
+func (x *ClusterCapabilities) GetFlaggerVersion() string {
 	if x != nil {
-		return x.Status
+		return x.FlaggerVersion
 	}
 	return ""
 }
 
-func (x *UnstructuredObject) GetConditions() []*Condition {
+func (x *ClusterCapabilities) GetMeshProviders() []string {
 	if x != nil {
-		return x.Conditions
+		return x.MeshProviders
 	}
 	return nil
 }
 
-func (x *UnstructuredObject) GetSuspended() bool {
+func (x *ClusterCapabilities) GetGatewayApi() *InstalledAPI {
 	if x != nil {
-		return x.Suspended
+		return x.GatewayApi
 	}
-	return false
+	return nil
+}
+
+func (x *ClusterCapabilities) GetFluxControllers() []*FluxController {
+	if x != nil {
+		return x.FluxControllers
+	}
+	return nil
+}
+
+func (x *ClusterCapabilities) GetArgoRollouts() *InstalledAPI {
+	if x != nil {
+		return x.ArgoRollouts
+	}
+	return nil
+}
+
+// InstalledAPI is an API defined by a CRD.
+type InstalledAPI struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Installed bool `protobuf:"varint,1,opt,name=installed,proto3" json:"installed,omitempty"`
+	// Versions are the served versions, the storage version first.
+	Versions []string `protobuf:"bytes,2,rep,name=versions,proto3" json:"versions,omitempty"`
+}
+
+func (x *InstalledAPI) Reset() {
+	*x = InstalledAPI{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_prog_types_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InstalledAPI) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstalledAPI) ProtoMessage() {}
+
+func (x *InstalledAPI) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *UnstructuredObject) GetClusterName() string {
+// Deprecated: Use InstalledAPI.ProtoReflect.Descriptor instead.
+func (*InstalledAPI) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *InstalledAPI) GetInstalled() bool {
 	if x != nil {
-		return x.ClusterName
+		return x.Installed
 	}
-	return ""
+	return false
 }
 
-func (x *UnstructuredObject) GetImages() []string {
+func (x *InstalledAPI) GetVersions() []string {
 	if x != nil {
-		return x.Images
+		return x.Versions
 	}
 	return nil
 }
 
-type Condition struct {
+type FluxController struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type      string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Status    string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	Reason    string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
-	Message   string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
-	Timestamp string `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Name  string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Kinds []string `protobuf:"bytes,2,rep,name=kinds,proto3" json:"kinds,omitempty"`
 }
 
-func (x *Condition) Reset() {
-	*x = Condition{}
+func (x *FluxController) Reset() {
+	*x = FluxController{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_prog_types_proto_msgTypes[16]
+		mi := &file_api_prog_types_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Condition) String() string {
+func (x *FluxController) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Condition) ProtoMessage() {}
+func (*FluxController) ProtoMessage() {}
 
-func (x *Condition) ProtoReflect() protoreflect.Message {
-	mi := &file_api_prog_types_proto_msgTypes[16]
+func (x *FluxController) ProtoReflect() protoreflect.Message {
+	mi := &file_api_prog_types_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1317,44 +2958,23 @@ func (x *Condition) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Condition.ProtoReflect.Descriptor instead.
-func (*Condition) Descriptor() ([]byte, []int) {
-	return file_api_prog_types_proto_rawDescGZIP(), []int{16}
-}
-
-func (x *Condition) GetType() string {
-	if x != nil {
-		return x.Type
-	}
-	return ""
-}
-
-func (x *Condition) GetStatus() string {
-	if x != nil {
-		return x.Status
-	}
-	return ""
-}
-
-func (x *Condition) GetReason() string {
-	if x != nil {
-		return x.Reason
-	}
-	return ""
+// Deprecated: Use FluxController.ProtoReflect.Descriptor instead.
+func (*FluxController) Descriptor() ([]byte, []int) {
+	return file_api_prog_types_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *Condition) GetMessage() string {
+func (x *FluxController) GetName() string {
 	if x != nil {
-		return x.Message
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Condition) GetTimestamp() string {
+func (x *FluxController) GetKinds() []string {
 	if x != nil {
-		return x.Timestamp
+		return x.Kinds
 	}
-	return ""
+	return nil
 }
 
 var File_api_prog_types_proto protoreflect.FileDescriptor
@@ -1372,7 +2992,7 @@ var file_api_prog_types_proto_rawDesc = []byte{
 	0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x18,
 	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x9a, 0x03, 0x0a, 0x06, 0x43, 0x61, 0x6e,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xfa, 0x03, 0x0a, 0x06, 0x43, 0x61, 0x6e,
 	0x61, 0x72, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
 	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
@@ -1398,7 +3018,13 @@ var file_api_prog_types_proto_rawDesc = []byte{
 	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x41,
 	0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x52, 0x08, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69,
 	0x73, 0x12, 0x12, 0x0a, 0x04, 0x79, 0x61, 0x6d, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x79, 0x61, 0x6d, 0x6c, 0x22, 0x3f, 0x0a, 0x15, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x54,
+	0x04, 0x79, 0x61, 0x6d, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x6c, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x3e, 0x0a, 0x0f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f,
+	0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x57, 0x6f, 0x72,
+	0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x0e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x57, 0x6f, 0x72,
+	0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x3f, 0x0a, 0x15, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x54,
 	0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12,
 	0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69,
 	0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
@@ -1461,116 +3087,409 @@ var file_api_prog_types_proto_rawDesc = []byte{
 	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
 	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
 	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x64, 0x0a, 0x0a, 0x46, 0x6c, 0x75, 0x78, 0x4c, 0x61,
-	0x62, 0x65, 0x6c, 0x73, 0x12, 0x2f, 0x0a, 0x13, 0x6b, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x69, 0x7a,
-	0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x12, 0x6b, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x4e, 0x61, 0x6d, 0x65,
-	0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6b, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x69,
-	0x7a, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6b,
-	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x52, 0x0a, 0x0a,
-	0x41, 0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69,
-	0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
-	0x22, 0xfb, 0x02, 0x0a, 0x0e, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x41, 0x6e, 0x61, 0x6c, 0x79,
-	0x73, 0x69, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12,
-	0x1e, 0x0a, 0x0a, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0a, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
-	0x23, 0x0a, 0x0d, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x57, 0x65,
-	0x69, 0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x77, 0x65, 0x69, 0x67,
-	0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x57, 0x65, 0x69,
-	0x67, 0x68, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x65, 0x70, 0x5f, 0x77, 0x65, 0x69, 0x67,
-	0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74, 0x65, 0x70, 0x57, 0x65,
-	0x69, 0x67, 0x68, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x73, 0x74, 0x65, 0x70, 0x5f, 0x77, 0x65, 0x69,
-	0x67, 0x68, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x13, 0x73, 0x74, 0x65, 0x70, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x50,
-	0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65,
-	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x74, 0x68, 0x72,
-	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x65, 0x70, 0x5f, 0x77,
-	0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0b, 0x73, 0x74,
-	0x65, 0x70, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x69, 0x72,
-	0x72, 0x6f, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6d, 0x69, 0x72, 0x72, 0x6f,
-	0x72, 0x12, 0x12, 0x0a, 0x04, 0x79, 0x61, 0x6d, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x79, 0x61, 0x6d, 0x6c, 0x12, 0x27, 0x0a, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
-	0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x4d,
-	0x65, 0x74, 0x72, 0x69, 0x63, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x22, 0xe2,
-	0x01, 0x0a, 0x0c, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
-	0x65, 0x12, 0x44, 0x0a, 0x0f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x72,
-	0x61, 0x6e, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x43, 0x61, 0x6e,
-	0x61, 0x72, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
-	0x6c, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0e, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
-	0x6c, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72,
-	0x76, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72,
-	0x76, 0x61, 0x6c, 0x12, 0x3e, 0x0a, 0x0f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x5f, 0x74, 0x65,
-	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x43,
-	0x61, 0x6e, 0x61, 0x72, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x54, 0x65, 0x6d, 0x70, 0x6c,
-	0x61, 0x74, 0x65, 0x52, 0x0e, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x54, 0x65, 0x6d, 0x70, 0x6c,
-	0x61, 0x74, 0x65, 0x22, 0x40, 0x0a, 0x1a, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x4d, 0x65, 0x74,
-	0x72, 0x69, 0x63, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x52, 0x61, 0x6e, 0x67,
-	0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03,
-	0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
-	0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0xc2, 0x01, 0x0a, 0x14, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79,
-	0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x21,
-	0x0a, 0x0c, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xcc, 0x04, 0x0a, 0x14, 0x43, 0x61, 0x6e, 0x61, 0x72,
+	0x79, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x0b, 0x66, 0x6c, 0x75, 0x78, 0x5f, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x46, 0x6c, 0x75, 0x78,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x52, 0x0a, 0x66, 0x6c, 0x75, 0x78, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x12, 0x65, 0x0a, 0x16, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x5f, 0x69, 0x6d,
+	0x61, 0x67, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x65,
+	0x64, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x14, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x68, 0x0a, 0x17, 0x70, 0x72, 0x6f,
+	0x6d, 0x6f, 0x74, 0x65, 0x64, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x43, 0x61, 0x6e,
+	0x61, 0x72, 0x79, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
+	0x64, 0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x64, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x15, 0x70, 0x72,
+	0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x64, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x3d, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x5f, 0x64, 0x69, 0x66, 0x66, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x43,
+	0x61, 0x6e, 0x61, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x44, 0x69,
+	0x66, 0x66, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x44, 0x69, 0x66,
+	0x66, 0x73, 0x1a, 0x47, 0x0a, 0x19, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x48, 0x0a, 0x1a, 0x50,
+	0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x64, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa9, 0x03, 0x0a, 0x13, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79,
+	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x44, 0x69, 0x66, 0x66, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x38, 0x0a, 0x0c, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x6d,
+	0x61, 0x67, 0x65, 0x12, 0x3c, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x64, 0x5f,
+	0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x43, 0x61,
+	0x6e, 0x61, 0x72, 0x79, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x64, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x5f,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x72,
+	0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64,
+	0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x67, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x74, 0x61, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x64, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x64, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18,
+	0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x45, 0x6e,
+	0x76, 0x56, 0x61, 0x72, 0x44, 0x69, 0x66, 0x66, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x2b, 0x0a,
+	0x11, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0d, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x62, 0x65,
+	0x73, 0x22, 0x60, 0x0a, 0x14, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x49, 0x6d, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72,
+	0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x64,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x22, 0x88, 0x01, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x45, 0x6e,
+	0x76, 0x56, 0x61, 0x72, 0x44, 0x69, 0x66, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x6d, 0x6f,
+	0x74, 0x65, 0x64, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x64,
+	0x0a, 0x0a, 0x46, 0x6c, 0x75, 0x78, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x2f, 0x0a, 0x13,
+	0x6b, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x6b, 0x75, 0x73, 0x74, 0x6f,
+	0x6d, 0x69, 0x7a, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x25, 0x0a,
+	0x0e, 0x6b, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6b, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x69, 0x7a, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x22, 0xc1, 0x02, 0x0a, 0x0a, 0x41, 0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x73,
+	0x70, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x75,
+	0x73, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x12, 0x32, 0x0a, 0x15, 0x6c, 0x61, 0x73,
+	0x74, 0x5f, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x6c, 0x61, 0x73, 0x74, 0x41, 0x70,
+	0x70, 0x6c, 0x69, 0x65, 0x64, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x36, 0x0a,
+	0x17, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x65, 0x64, 0x5f,
+	0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15,
+	0x6c, 0x61, 0x73, 0x74, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x65, 0x64, 0x52, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x29, 0x0a,
+	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x41, 0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0xa8, 0x01, 0x0a, 0x10, 0x41, 0x75, 0x74,
+	0x6f, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
 	0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
-	0x61, 0x63, 0x65, 0x12, 0x2b, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x50, 0x72,
-	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
-	0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x79, 0x61, 0x6d, 0x6c, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x79, 0x61, 0x6d, 0x6c, 0x22, 0x91, 0x01, 0x0a, 0x0e, 0x4d,
-	0x65, 0x74, 0x72, 0x69, 0x63, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73,
-	0x65, 0x63, 0x72, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0a, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x30, 0x0a, 0x14,
-	0x69, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x5f, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x76, 0x65,
-	0x72, 0x69, 0x66, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x69, 0x6e, 0x73, 0x65,
-	0x63, 0x75, 0x72, 0x65, 0x53, 0x6b, 0x69, 0x70, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x22, 0x56,
-	0x0a, 0x10, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4b, 0x69,
-	0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07,
-	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xb3, 0x02, 0x0a, 0x12, 0x55, 0x6e, 0x73, 0x74, 0x72,
-	0x75, 0x63, 0x74, 0x75, 0x72, 0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x3d, 0x0a,
-	0x10, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x56,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x10, 0x67, 0x72, 0x6f, 0x75,
-	0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x10,
-	0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64,
-	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2a, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x64,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x43,
-	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x65,
-	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64,
-	0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d,
-	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
-	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x18, 0x09,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x22, 0x87, 0x01, 0x0a,
-	0x09, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79,
-	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16,
-	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18,
-	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x61, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x20, 0x0a, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0a, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x72, 0x65,
+	0x61, 0x64, 0x79, 0x22, 0xfb, 0x02, 0x0a, 0x0e, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x41, 0x6e,
+	0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x77, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x69, 0x72, 0x72, 0x6f,
+	0x72, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x77,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x61, 0x78,
+	0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x65, 0x70, 0x5f, 0x77,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74, 0x65,
+	0x70, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x73, 0x74, 0x65, 0x70, 0x5f,
+	0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x73, 0x74, 0x65, 0x70, 0x57, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x74,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09,
+	0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x65,
+	0x70, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x05, 0x52,
+	0x0b, 0x73, 0x74, 0x65, 0x70, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6d, 0x69,
+	0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x79, 0x61, 0x6d, 0x6c, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x79, 0x61, 0x6d, 0x6c, 0x12, 0x27, 0x0a, 0x07, 0x6d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x43, 0x61, 0x6e, 0x61,
+	0x72, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x73, 0x22, 0xe2, 0x01, 0x0a, 0x0c, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x12, 0x44, 0x0a, 0x0f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
+	0x64, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x54, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0e, 0x74, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x3e, 0x0a, 0x0f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x5f, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x54, 0x65,
+	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x0e, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x54, 0x65,
+	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x22, 0x40, 0x0a, 0x1a, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79,
+	0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0x8a, 0x03, 0x0a, 0x0c, 0x43, 0x61, 0x6e,
+	0x61, 0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12,
+	0x31, 0x0a, 0x06, 0x70, 0x68, 0x61, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2e, 0x50,
+	0x68, 0x61, 0x73, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x70, 0x68, 0x61, 0x73,
+	0x65, 0x73, 0x12, 0x3d, 0x0a, 0x0a, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x69, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x69, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x69, 0x65,
+	0x73, 0x12, 0x3a, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x1a, 0x39, 0x0a,
+	0x0b, 0x50, 0x68, 0x61, 0x73, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3d, 0x0a, 0x0f, 0x53, 0x74, 0x72, 0x61,
+	0x74, 0x65, 0x67, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3c, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x76, 0x69,
+	0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xe9, 0x01, 0x0a, 0x11, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79,
+	0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x1f, 0x0a, 0x0b, 0x63,
+	0x61, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x63, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x10,
+	0x63, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x4e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x65, 0x74, 0x72, 0x69,
+	0x63, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x44, 0x0a, 0x0f, 0x74, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0e,
+	0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x21,
+	0x0a, 0x0c, 0x77, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x77, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x22, 0xe2, 0x01, 0x0a, 0x14, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6c,
+	0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12,
+	0x2b, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x79, 0x61, 0x6d, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x79, 0x61, 0x6d, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x6c, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x22, 0x91, 0x01, 0x0a, 0x0e, 0x4d, 0x65, 0x74, 0x72, 0x69,
+	0x63, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x30, 0x0a, 0x14, 0x69, 0x6e, 0x73, 0x65,
+	0x63, 0x75, 0x72, 0x65, 0x5f, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x69, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65,
+	0x53, 0x6b, 0x69, 0x70, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x22, 0x82, 0x02, 0x0a, 0x0b, 0x43,
+	0x61, 0x6e, 0x61, 0x72, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61,
+	0x6e, 0x61, 0x72, 0x79, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0c, 0x63, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22,
+	0xb3, 0x05, 0x0a, 0x0e, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x12, 0x25, 0x0a,
+	0x0e, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x70, 0x68, 0x61, 0x73, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x50,
+	0x68, 0x61, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x77, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x63, 0x61, 0x6e, 0x61, 0x72,
+	0x79, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c,
+	0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0a, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2f, 0x0a, 0x13,
+	0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x65, 0x67, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x64, 0x65, 0x70, 0x6c, 0x6f,
+	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x5f, 0x0a,
+	0x16, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e,
+	0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x41,
+	0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x14, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65,
+	0x64, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x62,
+	0x0a, 0x17, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x64, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2a, 0x2e, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x64, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x15, 0x70, 0x72, 0x6f,
+	0x6d, 0x6f, 0x74, 0x65, 0x64, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x1a, 0x47, 0x0a, 0x19, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x48, 0x0a, 0x1a, 0x50,
+	0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x64, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x56, 0x0a, 0x10, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12,
+	0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xb2, 0x03,
+	0x0a, 0x12, 0x55, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72, 0x65, 0x64, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x12, 0x3d, 0x0a, 0x10, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e,
+	0x64, 0x52, 0x10, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4b,
+	0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x2a, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x73, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6c, 0x75,
+	0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x69,
+	0x6d, 0x61, 0x67, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x69, 0x6d, 0x61,
+	0x67, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x18,
+	0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x55, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x75, 0x72, 0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x08, 0x63, 0x68, 0x69, 0x6c,
+	0x64, 0x72, 0x65, 0x6e, 0x12, 0x30, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x73, 0x22, 0x95, 0x02, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x72,
+	0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x36, 0x0a, 0x17, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x74, 0x65, 0x72, 0x6d, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x15, 0x6c, 0x61, 0x73, 0x74, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x87, 0x01, 0x0a, 0x09, 0x43,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x22, 0x5b, 0x0a, 0x0d, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x46, 0x69,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0xe7, 0x01, 0x0a, 0x10, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x33, 0x0a, 0x09, 0x72, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x72, 0x52, 0x09, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x73, 0x12,
+	0x32, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x50, 0x68, 0x61, 0x73, 0x65, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x12,
+	0x1e, 0x0a, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x3e, 0x0a, 0x14, 0x4e,
+	0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x35, 0x0a, 0x0f, 0x50,
+	0x68, 0x61, 0x73, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12,
+	0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x74, 0x6f, 0x22, 0x48, 0x0a, 0x14, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xd1, 0x02, 0x0a,
+	0x13, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6c, 0x75, 0x73,
+	0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x07, 0x66, 0x6c, 0x61, 0x67, 0x67,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6c, 0x6c, 0x65, 0x64, 0x41, 0x50, 0x49, 0x52, 0x07, 0x66, 0x6c, 0x61, 0x67, 0x67, 0x65, 0x72,
+	0x12, 0x27, 0x0a, 0x0f, 0x66, 0x6c, 0x61, 0x67, 0x67, 0x65, 0x72, 0x5f, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x66, 0x6c, 0x61, 0x67, 0x67,
+	0x65, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x65, 0x73,
+	0x68, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0d, 0x6d, 0x65, 0x73, 0x68, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73,
+	0x12, 0x2e, 0x0a, 0x0b, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x5f, 0x61, 0x70, 0x69, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65,
+	0x64, 0x41, 0x50, 0x49, 0x52, 0x0a, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x41, 0x70, 0x69,
+	0x12, 0x3a, 0x0a, 0x10, 0x66, 0x6c, 0x75, 0x78, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x6c, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x46, 0x6c, 0x75,
+	0x78, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x52, 0x0f, 0x66, 0x6c, 0x75,
+	0x78, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x73, 0x12, 0x32, 0x0a, 0x0d,
+	0x61, 0x72, 0x67, 0x6f, 0x5f, 0x72, 0x6f, 0x6c, 0x6c, 0x6f, 0x75, 0x74, 0x73, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x41,
+	0x50, 0x49, 0x52, 0x0c, 0x61, 0x72, 0x67, 0x6f, 0x52, 0x6f, 0x6c, 0x6c, 0x6f, 0x75, 0x74, 0x73,
+	0x22, 0x48, 0x0a, 0x0c, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x41, 0x50, 0x49,
+	0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x08, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x3a, 0x0a, 0x0e, 0x46, 0x6c,
+	0x75, 0x78, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x6b, 0x69, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x05, 0x6b, 0x69, 0x6e, 0x64, 0x73, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
 	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x77, 0x65, 0x61, 0x76, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x2f,
 	0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x69, 0x76, 0x65, 0x2d, 0x64, 0x65, 0x6c, 0x69,
 	0x76, 0x65, 0x72, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
@@ -1588,7 +3507,7 @@ func file_api_prog_types_proto_rawDescGZIP() []byte {
 	return file_api_prog_types_proto_rawDescData
 }
 
-var file_api_prog_types_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_api_prog_types_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
 var file_api_prog_types_proto_goTypes = []interface{}{
 	(*Pagination)(nil),                 // 0: Pagination
 	(*ListError)(nil),                  // 1: ListError
@@ -1597,39 +3516,89 @@ var file_api_prog_types_proto_goTypes = []interface{}{
 	(*CanaryStatus)(nil),               // 4: CanaryStatus
 	(*CanaryCondition)(nil),            // 5: CanaryCondition
 	(*CanaryTargetDeployment)(nil),     // 6: CanaryTargetDeployment
-	(*FluxLabels)(nil),                 // 7: FluxLabels
-	(*Automation)(nil),                 // 8: Automation
-	(*CanaryAnalysis)(nil),             // 9: CanaryAnalysis
-	(*CanaryMetric)(nil),               // 10: CanaryMetric
-	(*CanaryMetricThresholdRange)(nil), // 11: CanaryMetricThresholdRange
-	(*CanaryMetricTemplate)(nil),       // 12: CanaryMetricTemplate
-	(*MetricProvider)(nil),             // 13: MetricProvider
-	(*GroupVersionKind)(nil),           // 14: GroupVersionKind
-	(*UnstructuredObject)(nil),         // 15: UnstructuredObject
-	(*Condition)(nil),                  // 16: Condition
-	nil,                                // 17: CanaryTargetDeployment.AppliedImageVersionsEntry
-	nil,                                // 18: CanaryTargetDeployment.PromotedImageVersionsEntry
+	(*CanaryTargetWorkload)(nil),       // 7: CanaryTargetWorkload
+	(*CanaryContainerDiff)(nil),        // 8: CanaryContainerDiff
+	(*CanaryImageReference)(nil),       // 9: CanaryImageReference
+	(*CanaryEnvVarDiff)(nil),           // 10: CanaryEnvVarDiff
+	(*FluxLabels)(nil),                 // 11: FluxLabels
+	(*Automation)(nil),                 // 12: Automation
+	(*AutomationSource)(nil),           // 13: AutomationSource
+	(*CanaryAnalysis)(nil),             // 14: CanaryAnalysis
+	(*CanaryMetric)(nil),               // 15: CanaryMetric
+	(*CanaryMetricThresholdRange)(nil), // 16: CanaryMetricThresholdRange
+	(*CanaryCounts)(nil),               // 17: CanaryCounts
+	(*CanaryMetricCheck)(nil),          // 18: CanaryMetricCheck
+	(*CanaryMetricTemplate)(nil),       // 19: CanaryMetricTemplate
+	(*MetricProvider)(nil),             // 20: MetricProvider
+	(*CanaryEvent)(nil),                // 21: CanaryEvent
+	(*CanaryRevision)(nil),             // 22: CanaryRevision
+	(*GroupVersionKind)(nil),           // 23: GroupVersionKind
+	(*UnstructuredObject)(nil),         // 24: UnstructuredObject
+	(*ContainerStatus)(nil),            // 25: ContainerStatus
+	(*Condition)(nil),                  // 26: Condition
+	(*CanaryFinding)(nil),              // 27: CanaryFinding
+	(*NotificationRule)(nil),           // 28: NotificationRule
+	(*NotificationReceiver)(nil),       // 29: NotificationReceiver
+	(*PhaseTransition)(nil),            // 30: PhaseTransition
+	(*NotificationDelivery)(nil),       // 31: NotificationDelivery
+	(*ClusterCapabilities)(nil),        // 32: ClusterCapabilities
+	(*InstalledAPI)(nil),               // 33: InstalledAPI
+	(*FluxController)(nil),             // 34: FluxController
+	nil,                                // 35: CanaryTargetDeployment.AppliedImageVersionsEntry
+	nil,                                // 36: CanaryTargetDeployment.PromotedImageVersionsEntry
+	nil,                                // 37: CanaryTargetWorkload.AppliedImageVersionsEntry
+	nil,                                // 38: CanaryTargetWorkload.PromotedImageVersionsEntry
+	nil,                                // 39: CanaryCounts.PhasesEntry
+	nil,                                // 40: CanaryCounts.StrategiesEntry
+	nil,                                // 41: CanaryCounts.ProvidersEntry
+	nil,                                // 42: CanaryRevision.AppliedImageVersionsEntry
+	nil,                                // 43: CanaryRevision.PromotedImageVersionsEntry
 }
 var file_api_prog_types_proto_depIdxs = []int32{
 	3,  // 0: Canary.target_reference:type_name -> CanaryTargetReference
 	6,  // 1: Canary.target_deployment:type_name -> CanaryTargetDeployment
 	4,  // 2: Canary.status:type_name -> CanaryStatus
-	9,  // 3: Canary.analysis:type_name -> CanaryAnalysis
-	5,  // 4: CanaryStatus.conditions:type_name -> CanaryCondition
-	7,  // 5: CanaryTargetDeployment.flux_labels:type_name -> FluxLabels
-	17, // 6: CanaryTargetDeployment.applied_image_versions:type_name -> CanaryTargetDeployment.AppliedImageVersionsEntry
-	18, // 7: CanaryTargetDeployment.promoted_image_versions:type_name -> CanaryTargetDeployment.PromotedImageVersionsEntry
-	10, // 8: CanaryAnalysis.metrics:type_name -> CanaryMetric
-	11, // 9: CanaryMetric.threshold_range:type_name -> CanaryMetricThresholdRange
-	12, // 10: CanaryMetric.metric_template:type_name -> CanaryMetricTemplate
-	13, // 11: CanaryMetricTemplate.provider:type_name -> MetricProvider
-	14, // 12: UnstructuredObject.groupVersionKind:type_name -> GroupVersionKind
-	16, // 13: UnstructuredObject.conditions:type_name -> Condition
-	14, // [14:14] is the sub-list for method output_type
-	14, // [14:14] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	14, // 3: Canary.analysis:type_name -> CanaryAnalysis
+	7,  // 4: Canary.target_workload:type_name -> CanaryTargetWorkload
+	5,  // 5: CanaryStatus.conditions:type_name -> CanaryCondition
+	11, // 6: CanaryTargetDeployment.flux_labels:type_name -> FluxLabels
+	35, // 7: CanaryTargetDeployment.applied_image_versions:type_name -> CanaryTargetDeployment.AppliedImageVersionsEntry
+	36, // 8: CanaryTargetDeployment.promoted_image_versions:type_name -> CanaryTargetDeployment.PromotedImageVersionsEntry
+	11, // 9: CanaryTargetWorkload.flux_labels:type_name -> FluxLabels
+	37, // 10: CanaryTargetWorkload.applied_image_versions:type_name -> CanaryTargetWorkload.AppliedImageVersionsEntry
+	38, // 11: CanaryTargetWorkload.promoted_image_versions:type_name -> CanaryTargetWorkload.PromotedImageVersionsEntry
+	8,  // 12: CanaryTargetWorkload.container_diffs:type_name -> CanaryContainerDiff
+	9,  // 13: CanaryContainerDiff.target_image:type_name -> CanaryImageReference
+	9,  // 14: CanaryContainerDiff.promoted_image:type_name -> CanaryImageReference
+	10, // 15: CanaryContainerDiff.env:type_name -> CanaryEnvVarDiff
+	26, // 16: Automation.ready:type_name -> Condition
+	13, // 17: Automation.source:type_name -> AutomationSource
+	26, // 18: AutomationSource.ready:type_name -> Condition
+	15, // 19: CanaryAnalysis.metrics:type_name -> CanaryMetric
+	16, // 20: CanaryMetric.threshold_range:type_name -> CanaryMetricThresholdRange
+	19, // 21: CanaryMetric.metric_template:type_name -> CanaryMetricTemplate
+	39, // 22: CanaryCounts.phases:type_name -> CanaryCounts.PhasesEntry
+	40, // 23: CanaryCounts.strategies:type_name -> CanaryCounts.StrategiesEntry
+	41, // 24: CanaryCounts.providers:type_name -> CanaryCounts.ProvidersEntry
+	16, // 25: CanaryMetricCheck.threshold_range:type_name -> CanaryMetricThresholdRange
+	20, // 26: CanaryMetricTemplate.provider:type_name -> MetricProvider
+	42, // 27: CanaryRevision.applied_image_versions:type_name -> CanaryRevision.AppliedImageVersionsEntry
+	43, // 28: CanaryRevision.promoted_image_versions:type_name -> CanaryRevision.PromotedImageVersionsEntry
+	23, // 29: UnstructuredObject.groupVersionKind:type_name -> GroupVersionKind
+	26, // 30: UnstructuredObject.conditions:type_name -> Condition
+	24, // 31: UnstructuredObject.children:type_name -> UnstructuredObject
+	25, // 32: UnstructuredObject.containers:type_name -> ContainerStatus
+	29, // 33: NotificationRule.receivers:type_name -> NotificationReceiver
+	30, // 34: NotificationRule.transitions:type_name -> PhaseTransition
+	33, // 35: ClusterCapabilities.flagger:type_name -> InstalledAPI
+	33, // 36: ClusterCapabilities.gateway_api:type_name -> InstalledAPI
+	34, // 37: ClusterCapabilities.flux_controllers:type_name -> FluxController
+	33, // 38: ClusterCapabilities.argo_rollouts:type_name -> InstalledAPI
+	39, // [39:39] is the sub-list for method output_type
+	39, // [39:39] is the sub-list for method input_type
+	39, // [39:39] is the sub-list for extension type_name
+	39, // [39:39] is the sub-list for extension extendee
+	0,  // [0:39] is the sub-list for field type_name
 }
 
 func init() { file_api_prog_types_proto_init() }
@@ -1723,7 +3692,7 @@ func file_api_prog_types_proto_init() {
 			}
 		}
 		file_api_prog_types_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FluxLabels); i {
+			switch v := v.(*CanaryTargetWorkload); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1735,7 +3704,7 @@ func file_api_prog_types_proto_init() {
 			}
 		}
 		file_api_prog_types_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Automation); i {
+			switch v := v.(*CanaryContainerDiff); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1747,7 +3716,7 @@ func file_api_prog_types_proto_init() {
 			}
 		}
 		file_api_prog_types_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CanaryAnalysis); i {
+			switch v := v.(*CanaryImageReference); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1759,7 +3728,7 @@ func file_api_prog_types_proto_init() {
 			}
 		}
 		file_api_prog_types_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CanaryMetric); i {
+			switch v := v.(*CanaryEnvVarDiff); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1771,7 +3740,7 @@ func file_api_prog_types_proto_init() {
 			}
 		}
 		file_api_prog_types_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CanaryMetricThresholdRange); i {
+			switch v := v.(*FluxLabels); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1783,7 +3752,7 @@ func file_api_prog_types_proto_init() {
 			}
 		}
 		file_api_prog_types_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CanaryMetricTemplate); i {
+			switch v := v.(*Automation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1795,7 +3764,7 @@ func file_api_prog_types_proto_init() {
 			}
 		}
 		file_api_prog_types_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MetricProvider); i {
+			switch v := v.(*AutomationSource); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1807,7 +3776,7 @@ func file_api_prog_types_proto_init() {
 			}
 		}
 		file_api_prog_types_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GroupVersionKind); i {
+			switch v := v.(*CanaryAnalysis); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1819,7 +3788,7 @@ func file_api_prog_types_proto_init() {
 			}
 		}
 		file_api_prog_types_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UnstructuredObject); i {
+			switch v := v.(*CanaryMetric); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1831,6 +3800,126 @@ func file_api_prog_types_proto_init() {
 			}
 		}
 		file_api_prog_types_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CanaryMetricThresholdRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_prog_types_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CanaryCounts); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_prog_types_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CanaryMetricCheck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_prog_types_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CanaryMetricTemplate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_prog_types_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MetricProvider); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_prog_types_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CanaryEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_prog_types_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CanaryRevision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_prog_types_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupVersionKind); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_prog_types_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnstructuredObject); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_prog_types_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContainerStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_prog_types_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Condition); i {
 			case 0:
 				return &v.state
@@ -1842,6 +3931,102 @@ func file_api_prog_types_proto_init() {
 				return nil
 			}
 		}
+		file_api_prog_types_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CanaryFinding); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_prog_types_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NotificationRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_prog_types_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NotificationReceiver); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_prog_types_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PhaseTransition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_prog_types_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NotificationDelivery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_prog_types_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClusterCapabilities); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_prog_types_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InstalledAPI); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_prog_types_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FluxController); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1849,7 +4034,7 @@ func file_api_prog_types_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_prog_types_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   19,
+			NumMessages:   44,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,157 @@
+// Package authn derives the principal of requests, so the clients of the
+// server impersonate the user making them.
+package authn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/go-logr/logr"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+	"google.golang.org/grpc/metadata"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrUnauthenticated is returned when no method finds the principal of a
+// request.
+var ErrUnauthenticated = errors.New("could not find valid principal")
+
+type Method string
+
+const (
+	// BearerPassthroughMethod passes bearer tokens through to the API
+	// servers, after they have been reviewed by the management cluster.
+	BearerPassthroughMethod Method = "bearer-passthrough"
+	// OIDCMethod verifies bearer tokens issued by an OIDC provider.
+	OIDCMethod Method = "oidc"
+	// TokenFileMethod looks bearer tokens up in a static token file.
+	TokenFileMethod Method = "token-file"
+)
+
+type OIDCOptions struct {
+	IssuerURL string
+	ClientID  string
+	// JWKSURL is the URL of the signing keys, they are discovered from the
+	// issuer if empty.
+	JWKSURL       string
+	UsernameClaim string
+	GroupsClaim   string
+}
+
+type Options struct {
+	Methods   []Method
+	OIDC      OIDCOptions
+	TokenFile string
+}
+
+// NewPrincipalGetter returns a getter trying every method in order, the first
+// one finding a principal wins.
+func NewPrincipalGetter(ctx context.Context, opts Options, kubeClient client.Client, log logr.Logger) (auth.PrincipalGetter, error) {
+	if len(opts.Methods) == 0 {
+		return nil, fmt.Errorf("no authentication method configured")
+	}
+
+	getters := []auth.PrincipalGetter{}
+
+	for _, method := range opts.Methods {
+		switch method {
+		case BearerPassthroughMethod:
+			getters = append(getters, auth.NewBearerTokenPassthroughPrincipalGetter(log, nil, authorizationHeader, kubeClient))
+		case OIDCMethod:
+			verifier, err := newOIDCVerifier(ctx, opts.OIDC)
+			if err != nil {
+				return nil, err
+			}
+
+			getters = append(getters, auth.NewJWTAuthorizationHeaderPrincipalGetter(log, verifier, &auth.ClaimsConfig{
+				Username: opts.OIDC.UsernameClaim,
+				Groups:   opts.OIDC.GroupsClaim,
+			}))
+		case TokenFileMethod:
+			getter, err := NewTokenFilePrincipalGetter(opts.TokenFile)
+			if err != nil {
+				return nil, err
+			}
+
+			getters = append(getters, getter)
+		default:
+			return nil, fmt.Errorf("unsupported authentication method: %s", method)
+		}
+	}
+
+	return multiPrincipalGetter(getters), nil
+}
+
+// multiPrincipalGetter differs from auth.MultiAuthPrincipal in that a method
+// rejecting a token doesn't prevent the following ones from accepting it.
+type multiPrincipalGetter []auth.PrincipalGetter
+
+func (m multiPrincipalGetter) Principal(r *http.Request) (*auth.UserPrincipal, error) {
+	var firstErr error
+
+	for _, getter := range m {
+		principal, err := getter.Principal(r)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+
+			continue
+		}
+
+		if principal != nil {
+			return principal, nil
+		}
+	}
+
+	if firstErr != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnauthenticated, firstErr)
+	}
+
+	return nil, ErrUnauthenticated
+}
+
+func newOIDCVerifier(ctx context.Context, opts OIDCOptions) (*oidc.IDTokenVerifier, error) {
+	if opts.IssuerURL == "" {
+		return nil, fmt.Errorf("the oidc issuer url is required")
+	}
+
+	config := &oidc.Config{
+		ClientID:          opts.ClientID,
+		SkipClientIDCheck: opts.ClientID == "",
+	}
+
+	if opts.JWKSURL != "" {
+		return oidc.NewVerifier(opts.IssuerURL, oidc.NewRemoteKeySet(ctx, opts.JWKSURL), config), nil
+	}
+
+	provider, err := oidc.NewProvider(ctx, opts.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("could not discover oidc provider %s: %w", opts.IssuerURL, err)
+	}
+
+	return provider.Verifier(config), nil
+}
+
+const authorizationHeader = "Authorization"
+
+// RequestFromIncomingContext returns a request carrying the authorization
+// metadata of a gRPC call, principal getters read it from HTTP requests.
+func RequestFromIncomingContext(ctx context.Context) *http.Request {
+	req := (&http.Request{Header: http.Header{}}).WithContext(ctx)
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return req
+	}
+
+	for _, value := range md.Get(strings.ToLower(authorizationHeader)) {
+		req.Header.Add(authorizationHeader, value)
+	}
+
+	return req
+}
@@ -0,0 +1,51 @@
+package authn_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/pkg/authn"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestRequestFromIncomingContext(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer my-token"))
+
+	req := authn.RequestFromIncomingContext(ctx)
+	assert.Equal(t, "Bearer my-token", req.Header.Get("Authorization"))
+
+	req = authn.RequestFromIncomingContext(context.Background())
+	assert.Empty(t, req.Header.Get("Authorization"))
+}
+
+func TestNewPrincipalGetter(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := authn.NewPrincipalGetter(ctx, authn.Options{}, nil, logr.Discard())
+	assert.Error(t, err)
+
+	_, err = authn.NewPrincipalGetter(ctx, authn.Options{Methods: []authn.Method{"unknown"}}, nil, logr.Discard())
+	assert.Error(t, err)
+
+	_, err = authn.NewPrincipalGetter(ctx, authn.Options{Methods: []authn.Method{authn.OIDCMethod}}, nil, logr.Discard())
+	assert.Error(t, err)
+
+	getter, err := authn.NewPrincipalGetter(ctx, authn.Options{
+		Methods:   []authn.Method{authn.TokenFileMethod},
+		TokenFile: writeTokenFile(t, "alice-token,alice,1\n"),
+	}, nil, logr.Discard())
+	assert.NoError(t, err)
+
+	principal, err := getter.Principal(requestWithToken("alice-token"))
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", principal.ID)
+
+	_, err = getter.Principal(requestWithToken("unknown"))
+	assert.True(t, errors.Is(err, authn.ErrUnauthenticated))
+
+	_, err = getter.Principal(requestWithToken(""))
+	assert.True(t, errors.Is(err, authn.ErrUnauthenticated))
+}
@@ -0,0 +1,99 @@
+package authn
+
+import (
+	"crypto/subtle"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+type staticToken struct {
+	token     string
+	principal auth.UserPrincipal
+}
+
+// TokenFilePrincipalGetter authenticates bearer tokens listed in a CSV file
+// with the format of the Kubernetes static token file: token, user name, user
+// uid, and optionally a quoted, comma separated list of groups.
+type TokenFilePrincipalGetter struct {
+	tokens []staticToken
+}
+
+func NewTokenFilePrincipalGetter(path string) (*TokenFilePrincipalGetter, error) {
+	if path == "" {
+		return nil, fmt.Errorf("the token file is required")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open token file: %w", err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("could not read token file: %w", err)
+	}
+
+	getter := &TokenFilePrincipalGetter{}
+
+	for i, record := range records {
+		if len(record) < 3 || record[0] == "" || record[1] == "" {
+			return nil, fmt.Errorf("invalid token file line %d: expected token, user, uid", i+1)
+		}
+
+		groups := []string{}
+
+		if len(record) > 3 {
+			for _, group := range strings.Split(record[3], ",") {
+				if group = strings.TrimSpace(group); group != "" {
+					groups = append(groups, group)
+				}
+			}
+		}
+
+		getter.tokens = append(getter.tokens, staticToken{
+			token:     record[0],
+			principal: auth.UserPrincipal{ID: record[1], Groups: groups},
+		})
+	}
+
+	return getter, nil
+}
+
+// Principal returns nil for requests without a bearer token, and an error for
+// unknown tokens.
+func (g *TokenFilePrincipalGetter) Principal(r *http.Request) (*auth.UserPrincipal, error) {
+	token := bearerToken(r.Header.Get(authorizationHeader))
+	if token == "" {
+		return nil, nil
+	}
+
+	for _, entry := range g.tokens {
+		if subtle.ConstantTimeCompare([]byte(entry.token), []byte(token)) == 1 {
+			principal := entry.principal
+
+			return &principal, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid token")
+}
+
+func bearerToken(header string) string {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || scheme != "Bearer" {
+		return ""
+	}
+
+	return strings.TrimSpace(token)
+}
@@ -0,0 +1,63 @@
+package authn_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/pkg/authn"
+)
+
+func writeTokenFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "tokens.csv")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func requestWithToken(token string) *http.Request {
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return req
+}
+
+func TestTokenFilePrincipalGetter(t *testing.T) {
+	path := writeTokenFile(t, `# token,user,uid,groups
+alice-token,alice,1,"dev,ops"
+bob-token,bob,2
+`)
+
+	getter, err := authn.NewTokenFilePrincipalGetter(path)
+	assert.NoError(t, err)
+
+	principal, err := getter.Principal(requestWithToken("alice-token"))
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", principal.ID)
+	assert.Equal(t, []string{"dev", "ops"}, principal.Groups)
+
+	principal, err = getter.Principal(requestWithToken("bob-token"))
+	assert.NoError(t, err)
+	assert.Equal(t, "bob", principal.ID)
+	assert.Empty(t, principal.Groups)
+
+	principal, err = getter.Principal(requestWithToken("unknown"))
+	assert.Error(t, err)
+	assert.Nil(t, principal)
+
+	principal, err = getter.Principal(requestWithToken(""))
+	assert.NoError(t, err)
+	assert.Nil(t, principal)
+}
+
+func TestNewTokenFilePrincipalGetter_Invalid(t *testing.T) {
+	_, err := authn.NewTokenFilePrincipalGetter("")
+	assert.Error(t, err)
+
+	_, err = authn.NewTokenFilePrincipalGetter(writeTokenFile(t, "token-only\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// accessReviewTTL is how long the result of an access review is reused, it
+// bounds how long a revoked permission still allows reads from the cache.
+const accessReviewTTL = time.Minute
+
+type accessReview struct {
+	allowed bool
+	expires time.Time
+}
+
+// accessReviews remembers whether users can get or list a resource in a
+// namespace, by object name for gets since roles may be limited to some
+// names. They're checked with a SelfSubjectAccessReview of the impersonated
+// client.
+type accessReviews struct {
+	mu      sync.Mutex
+	reviews map[string]accessReview
+}
+
+func newAccessReviews() *accessReviews {
+	return &accessReviews{
+		reviews: map[string]accessReview{},
+	}
+}
+
+func (r *accessReviews) allowed(
+	ctx context.Context,
+	clusterName string,
+	clusterClient clustersmngr.Client,
+	user *auth.UserPrincipal,
+	verb string,
+	resource schema.GroupResource,
+	namespace, name string,
+) (bool, error) {
+	key := strings.Join([]string{userKey(user), clusterName, verb, resource.String(), namespace, name}, "/")
+
+	r.mu.Lock()
+	review, ok := r.reviews[key]
+	r.mu.Unlock()
+
+	if ok && time.Now().Before(review.expires) {
+		return review.allowed, nil
+	}
+
+	ssar := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Name:      name,
+				Verb:      verb,
+				Group:     resource.Group,
+				Resource:  resource.Resource,
+			},
+		},
+	}
+
+	if err := clusterClient.Create(ctx, clusterName, ssar); err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+
+	for k, review := range r.reviews {
+		if !now.Before(review.expires) {
+			delete(r.reviews, k)
+		}
+	}
+
+	r.reviews[key] = accessReview{allowed: ssar.Status.Allowed, expires: now.Add(accessReviewTTL)}
+
+	return ssar.Status.Allowed, nil
+}
+
+// userKey identifies the user, hashed so tokens aren't kept in memory.
+func userKey(user *auth.UserPrincipal) string {
+	hash := sha256.New()
+
+	hash.Write([]byte(user.ID))
+	hash.Write([]byte{0})
+	hash.Write([]byte(strings.Join(user.Groups, ",")))
+	hash.Write([]byte{0})
+	hash.Write([]byte(user.Token()))
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,284 @@
+// Package cache serves reads of the objects the server looks up for every
+// Canary from informers, instead of a request to the API server per object.
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// startRetryInterval is how often starting the informers of an unreachable
+// cluster is retried.
+const startRetryInterval = 30 * time.Second
+
+// cachedResources are the kinds served from the cache, with the resource
+// access reviews are made for.
+var cachedResources = map[schema.GroupVersionKind]schema.GroupResource{
+	flaggerv1.SchemeGroupVersion.WithKind("Canary"):         {Group: flaggerv1.SchemeGroupVersion.Group, Resource: "canaries"},
+	flaggerv1.SchemeGroupVersion.WithKind("MetricTemplate"): {Group: flaggerv1.SchemeGroupVersion.Group, Resource: "metrictemplates"},
+	appsv1.SchemeGroupVersion.WithKind("Deployment"):        {Group: appsv1.GroupName, Resource: "deployments"},
+}
+
+func cachedObjects() []client.Object {
+	return []client.Object{&flaggerv1.Canary{}, &flaggerv1.MetricTemplate{}, &appsv1.Deployment{}}
+}
+
+// Cache holds the informers of every cluster of the clusters manager. They
+// use the credentials of the server, so reads are only served once an access
+// review of the user of the request allows them.
+type Cache struct {
+	clustersManager clustersmngr.ClustersManager
+	scheme          *runtime.Scheme
+	logger          logr.Logger
+	reviews         *accessReviews
+
+	mu       sync.RWMutex
+	clusters map[string]*clusterCache
+}
+
+func New(clustersManager clustersmngr.ClustersManager, scheme *runtime.Scheme, logger logr.Logger) *Cache {
+	return &Cache{
+		clustersManager: clustersManager,
+		scheme:          scheme,
+		logger:          logger,
+		reviews:         newAccessReviews(),
+		clusters:        map[string]*clusterCache{},
+	}
+}
+
+// clusterCache is the cache of a cluster, it's only read once synced.
+type clusterCache struct {
+	cache  ctrlcache.Cache
+	kinds  map[schema.GroupVersionKind]bool
+	synced chan struct{}
+	cancel context.CancelFunc
+}
+
+func (cc *clusterCache) isSynced() bool {
+	select {
+	case <-cc.synced:
+		return true
+	default:
+		return false
+	}
+}
+
+// Start runs the informers of the clusters until ctx is done, they are
+// started and stopped as clusters are added and removed.
+func (c *Cache) Start(ctx context.Context) {
+	watcher := c.clustersManager.Subscribe()
+	defer watcher.Unsubscribe()
+
+	for _, cl := range c.clustersManager.GetClusters() {
+		c.addCluster(ctx, cl)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.mu.Lock()
+			for name, cc := range c.clusters {
+				cc.cancel()
+				delete(c.clusters, name)
+			}
+			c.mu.Unlock()
+
+			return
+		case update := <-watcher.Updates:
+			for _, cl := range update.Removed {
+				c.removeCluster(cl.GetName())
+			}
+
+			for _, cl := range update.Added {
+				c.addCluster(ctx, cl)
+			}
+		}
+	}
+}
+
+func (c *Cache) addCluster(ctx context.Context, cl cluster.Cluster) {
+	ctx, cancel := context.WithCancel(ctx)
+	cc := &clusterCache{
+		kinds:  map[schema.GroupVersionKind]bool{},
+		synced: make(chan struct{}),
+		cancel: cancel,
+	}
+
+	c.mu.Lock()
+	if previous, ok := c.clusters[cl.GetName()]; ok {
+		previous.cancel()
+	}
+	c.clusters[cl.GetName()] = cc
+	c.mu.Unlock()
+
+	go func() {
+		_ = wait.PollImmediateUntilWithContext(ctx, startRetryInterval, func(ctx context.Context) (bool, error) {
+			if err := c.start(ctx, cl, cc); err != nil {
+				c.logger.Error(err, "failed starting cache", "cluster", cl.GetName())
+				return false, nil
+			}
+
+			return true, nil
+		})
+	}()
+}
+
+func (c *Cache) removeCluster(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cc, ok := c.clusters[name]; ok {
+		cc.cancel()
+		delete(c.clusters, name)
+	}
+}
+
+// start creates the informers of the kinds available on the cluster, and
+// marks the cache synced once they are.
+func (c *Cache) start(ctx context.Context, cl cluster.Cluster, cc *clusterCache) error {
+	config, err := cl.GetServerConfig()
+	if err != nil {
+		return err
+	}
+
+	mapper, err := apiutil.NewDynamicRESTMapper(config)
+	if err != nil {
+		return fmt.Errorf("could not create RESTMapper: %w", err)
+	}
+
+	informers, err := ctrlcache.New(config, ctrlcache.Options{Scheme: c.scheme, Mapper: mapper})
+	if err != nil {
+		return fmt.Errorf("could not create cache: %w", err)
+	}
+
+	for _, obj := range cachedObjects() {
+		gvk, err := apiutil.GVKForObject(obj, c.scheme)
+		if err != nil {
+			return err
+		}
+
+		// Kinds whose CRD isn't installed aren't cached, their reads go to
+		// the API server.
+		if _, err := informers.GetInformer(ctx, obj); err != nil {
+			c.logger.V(1).Info("kind not cached", "cluster", cl.GetName(), "kind", gvk.Kind, "reason", err.Error())
+			continue
+		}
+
+		cc.kinds[gvk] = true
+	}
+
+	go func() {
+		if err := informers.Start(ctx); err != nil {
+			c.logger.Error(err, "cache stopped", "cluster", cl.GetName())
+		}
+	}()
+
+	if !informers.WaitForCacheSync(ctx) {
+		return fmt.Errorf("cache of cluster %s not synced", cl.GetName())
+	}
+
+	cc.cache = informers
+	close(cc.synced)
+
+	c.logger.Info("Cache synced", "cluster", cl.GetName())
+
+	return nil
+}
+
+// Get reads the object from the cache of the cluster for the user of the
+// context. It returns false if the cache can't serve the object, because the
+// kind isn't cached, the cache isn't synced yet, or the request has no user:
+// the caller reads it with the client then.
+func (c *Cache) Get(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, key client.ObjectKey, obj client.Object) (bool, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return false, nil
+	}
+
+	cc, served, err := c.authorize(ctx, clusterName, clusterClient, gvk, "get", key.Namespace, key.Name)
+	if !served || err != nil {
+		return served, err
+	}
+
+	return true, cc.cache.Get(ctx, key, obj)
+}
+
+// List lists the objects from the cache of the cluster for the user of the
+// context, it returns false if the cache can't serve them like Get.
+func (c *Cache) List(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, list client.ObjectList, opts ...client.ListOption) (bool, error) {
+	gvk, err := apiutil.GVKForObject(list, c.scheme)
+	if err != nil {
+		return false, nil
+	}
+
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+
+	cc, served, err := c.authorize(ctx, clusterName, clusterClient, gvk, "list", listOpts.Namespace, "")
+	if !served || err != nil {
+		return served, err
+	}
+
+	return true, cc.cache.List(ctx, list, opts...)
+}
+
+// authorize returns the synced cache of the cluster if it can serve the kind
+// to the user of the context, and a Forbidden error if an access review
+// doesn't allow the user to. It returns false if the cache can't serve the
+// kind.
+func (c *Cache) authorize(
+	ctx context.Context,
+	clusterName string,
+	clusterClient clustersmngr.Client,
+	gvk schema.GroupVersionKind,
+	verb, namespace, name string,
+) (*clusterCache, bool, error) {
+	user := auth.Principal(ctx)
+	if user == nil {
+		return nil, false, nil
+	}
+
+	resource, ok := cachedResources[gvk]
+	if !ok {
+		return nil, false, nil
+	}
+
+	c.mu.RLock()
+	cc, ok := c.clusters[clusterName]
+	c.mu.RUnlock()
+
+	if !ok || !cc.isSynced() || !cc.kinds[gvk] {
+		return nil, false, nil
+	}
+
+	allowed, err := c.reviews.allowed(ctx, clusterName, clusterClient, user, verb, resource, namespace, name)
+	if err != nil {
+		c.logger.Error(err, "access review failed", "cluster", clusterName, "resource", resource.String(), "namespace", namespace)
+		return nil, false, nil
+	}
+
+	if !allowed {
+		return nil, true, apierrors.NewForbidden(resource, name, fmt.Errorf("user %q cannot %s %s in namespace %q", user.ID, verb, resource.String(), namespace))
+	}
+
+	return cc, true, nil
+}
@@ -0,0 +1,141 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
+	"github.com/weaveworks/progressive-delivery/pkg/cache"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCache_Get(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, clustersManager, err := pdtesting.CreateClient(k8sEnv)
+	assert.NoError(t, err)
+
+	objectCache := cache.New(clustersManager, kube.CreateScheme(), logr.Discard())
+
+	go objectCache.Start(ctx)
+
+	ns := pdtesting.NewNamespace(ctx, t, k8sEnv.Client)
+	deployment := pdtesting.NewDeployment(ctx, t, k8sEnv.Client, "app", ns.Name)
+	key := client.ObjectKeyFromObject(deployment)
+
+	admin := auth.NewUserPrincipal(auth.ID("admin"), auth.Groups([]string{"system:masters"}))
+	adminCtx := auth.WithPrincipal(ctx, admin)
+	adminClient, err := clustersManager.GetImpersonatedClient(adminCtx, admin)
+	assert.NoError(t, err)
+
+	cached := appsv1.Deployment{}
+
+	assert.Eventually(t, func() bool {
+		served, err := objectCache.Get(adminCtx, "Default", adminClient, key, &cached)
+
+		return served && err == nil
+	}, 10*time.Second, 100*time.Millisecond)
+	assert.Equal(t, deployment.GetUID(), cached.GetUID())
+
+	// Users are only served what an access review allows.
+	user := auth.NewUserPrincipal(auth.ID("user"))
+	userCtx := auth.WithPrincipal(ctx, user)
+	userClient, err := clustersManager.GetImpersonatedClient(userCtx, user)
+	assert.NoError(t, err)
+
+	served, err := objectCache.Get(userCtx, "Default", userClient, key, &appsv1.Deployment{})
+	assert.True(t, served)
+	assert.True(t, apierrors.IsForbidden(err))
+
+	// Requests without user, kinds not cached and unknown clusters go to the
+	// API server.
+	served, _ = objectCache.Get(ctx, "Default", adminClient, key, &appsv1.Deployment{})
+	assert.False(t, served)
+
+	served, _ = objectCache.Get(adminCtx, "Default", adminClient, key, &corev1.Service{})
+	assert.False(t, served)
+
+	served, _ = objectCache.Get(adminCtx, "unknown", adminClient, key, &appsv1.Deployment{})
+	assert.False(t, served)
+
+	served, err = objectCache.Get(adminCtx, "Default", adminClient, client.ObjectKey{Name: "missing", Namespace: ns.Name}, &appsv1.Deployment{})
+	assert.True(t, served)
+	assert.True(t, apierrors.IsNotFound(err))
+
+	// Lists are reviewed like gets.
+	deployments := &appsv1.DeploymentList{}
+	served, err = objectCache.List(adminCtx, "Default", adminClient, deployments, client.InNamespace(ns.Name))
+	assert.True(t, served)
+	assert.NoError(t, err)
+	assert.Len(t, deployments.Items, 1)
+
+	served, err = objectCache.List(userCtx, "Default", userClient, &appsv1.DeploymentList{}, client.InNamespace(ns.Name))
+	assert.True(t, served)
+	assert.True(t, apierrors.IsForbidden(err))
+
+	served, _ = objectCache.List(adminCtx, "Default", adminClient, &corev1.ServiceList{}, client.InNamespace(ns.Name))
+	assert.False(t, served)
+}
+
+func TestCache_Get_ResourceNames(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, clustersManager, err := pdtesting.CreateClient(k8sEnv)
+	assert.NoError(t, err)
+
+	objectCache := cache.New(clustersManager, kube.CreateScheme(), logr.Discard())
+
+	go objectCache.Start(ctx)
+
+	ns := pdtesting.NewNamespace(ctx, t, k8sEnv.Client)
+	allowed := pdtesting.NewDeployment(ctx, t, k8sEnv.Client, "allowed", ns.Name)
+	denied := pdtesting.NewDeployment(ctx, t, k8sEnv.Client, "denied", ns.Name)
+
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "named", Namespace: ns.Name},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups:     []string{appsv1.GroupName},
+			Resources:     []string{"deployments"},
+			ResourceNames: []string{allowed.GetName()},
+			Verbs:         []string{"get"},
+		}},
+	}
+	assert.NoError(t, k8sEnv.Client.Create(ctx, role))
+
+	binding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "named", Namespace: ns.Name},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: role.Name},
+		Subjects:   []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: "named"}},
+	}
+	assert.NoError(t, k8sEnv.Client.Create(ctx, binding))
+
+	user := auth.NewUserPrincipal(auth.ID("named"))
+	userCtx := auth.WithPrincipal(ctx, user)
+	userClient, err := clustersManager.GetImpersonatedClient(userCtx, user)
+	assert.NoError(t, err)
+
+	cached := appsv1.Deployment{}
+
+	assert.Eventually(t, func() bool {
+		served, err := objectCache.Get(userCtx, "Default", userClient, client.ObjectKeyFromObject(allowed), &cached)
+
+		return served && err == nil
+	}, 10*time.Second, 100*time.Millisecond)
+	assert.Equal(t, allowed.GetUID(), cached.GetUID())
+
+	served, err := objectCache.Get(userCtx, "Default", userClient, client.ObjectKeyFromObject(denied), &appsv1.Deployment{})
+	assert.True(t, served)
+	assert.True(t, apierrors.IsForbidden(err))
+}
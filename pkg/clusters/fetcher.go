@@ -0,0 +1,48 @@
+// Package clusters discovers the clusters served by the server, from
+// kubeconfig files and the kubeconfig secrets of the management cluster.
+package clusters
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// multiFetcher returns the clusters of all its fetchers. A failing fetcher is
+// logged and skipped, so one unreachable source doesn't hide the others.
+type multiFetcher struct {
+	fetchers []clustersmngr.ClusterFetcher
+	logger   logr.Logger
+}
+
+func NewMultiFetcher(logger logr.Logger, fetchers ...clustersmngr.ClusterFetcher) clustersmngr.ClusterFetcher {
+	return multiFetcher{fetchers: fetchers, logger: logger}
+}
+
+func (f multiFetcher) Fetch(ctx context.Context) ([]cluster.Cluster, error) {
+	clusters := []cluster.Cluster{}
+	names := sets.NewString()
+
+	for _, fetcher := range f.fetchers {
+		fetched, err := fetcher.Fetch(ctx)
+		if err != nil {
+			f.logger.Error(err, "failed fetching clusters")
+			continue
+		}
+
+		for _, c := range fetched {
+			if names.Has(c.GetName()) {
+				f.logger.Info("Skipping cluster with a duplicate name", "cluster", c.GetName())
+				continue
+			}
+
+			names.Insert(c.GetName())
+			clusters = append(clusters, c)
+		}
+	}
+
+	return clusters, nil
+}
@@ -0,0 +1,42 @@
+package clusters_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/pkg/clusters"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+)
+
+func TestMultiFetcher(t *testing.T) {
+	dir := t.TempDir()
+	scheme := kube.CreateScheme()
+
+	path := filepath.Join(dir, "config")
+	writeKubeconfig(t, path, newKubeconfig("dev", map[string]string{
+		"dev":  "https://dev.example.com",
+		"prod": "https://prod.example.com",
+	}))
+
+	other := filepath.Join(dir, "other")
+	writeKubeconfig(t, other, newKubeconfig("prod", map[string]string{
+		"prod": "https://other-prod.example.com",
+	}))
+
+	fetcher := clusters.NewMultiFetcher(
+		logr.Discard(),
+		clusters.NewKubeconfigFetcher(path, []string{clusters.AllContexts}, scheme),
+		clusters.NewKubeconfigFetcher(filepath.Join(dir, "missing"), []string{clusters.AllContexts}, scheme),
+		clusters.NewKubeconfigFetcher(other, []string{"prod"}, scheme),
+	)
+
+	fetched, err := fetcher.Fetch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"dev":  "https://dev.example.com",
+		"prod": "https://prod.example.com",
+	}, clusterHosts(fetched))
+}
@@ -0,0 +1,137 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster"
+	apiruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// AllContexts selects every context of a kubeconfig.
+const AllContexts = "*"
+
+type kubeconfigFetcher struct {
+	path     string
+	contexts []string
+	scheme   *apiruntime.Scheme
+}
+
+// NewKubeconfigFetcher returns a cluster per context of the kubeconfig, named
+// after the context. Contexts lists the contexts to serve, or AllContexts.
+// The kubeconfig is read on every fetch, so contexts can be added without
+// restarting.
+func NewKubeconfigFetcher(path string, contexts []string, scheme *apiruntime.Scheme) clustersmngr.ClusterFetcher {
+	return kubeconfigFetcher{path: path, contexts: contexts, scheme: scheme}
+}
+
+func (f kubeconfigFetcher) Fetch(ctx context.Context) ([]cluster.Cluster, error) {
+	config, err := clientcmd.LoadFromFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("could not load kubeconfig %s: %w", f.path, err)
+	}
+
+	names := f.contexts
+	if len(names) == 1 && names[0] == AllContexts {
+		names = []string{}
+
+		for name := range config.Contexts {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+	}
+
+	clusters := []cluster.Cluster{}
+
+	for _, name := range names {
+		if _, ok := config.Contexts[name]; !ok {
+			return nil, fmt.Errorf("context %s not found in kubeconfig %s", name, f.path)
+		}
+
+		c, err := newCluster(name, *config, name, f.scheme)
+		if err != nil {
+			return nil, err
+		}
+
+		clusters = append(clusters, c)
+	}
+
+	return clusters, nil
+}
+
+type directoryFetcher struct {
+	dir    string
+	scheme *apiruntime.Scheme
+	logger logr.Logger
+}
+
+// NewDirectoryFetcher returns a cluster per kubeconfig file of the directory,
+// named after the file without its extension and using its current context.
+// Hidden files are ignored and invalid ones are logged and skipped.
+func NewDirectoryFetcher(dir string, scheme *apiruntime.Scheme, logger logr.Logger) clustersmngr.ClusterFetcher {
+	return directoryFetcher{dir: dir, scheme: scheme, logger: logger}
+}
+
+func (f directoryFetcher) Fetch(ctx context.Context) ([]cluster.Cluster, error) {
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not read kubeconfig directory %s: %w", f.dir, err)
+	}
+
+	clusters := []cluster.Cluster{}
+
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(f.dir, entry.Name())
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+
+		config, err := clientcmd.LoadFromFile(path)
+		if err != nil {
+			f.logger.Error(err, "invalid kubeconfig", "path", path)
+			continue
+		}
+
+		c, err := newCluster(name, *config, config.CurrentContext, f.scheme)
+		if err != nil {
+			f.logger.Error(err, "invalid kubeconfig", "path", path)
+			continue
+		}
+
+		clusters = append(clusters, c)
+	}
+
+	return clusters, nil
+}
+
+// newCluster creates the cluster of a context of the kubeconfig. Unlike the
+// management cluster, it doesn't query the flow control of the cluster:
+// clusters are fetched on every request, and an unreachable one mustn't fail
+// them.
+func newCluster(name string, config clientcmdapi.Config, context string, scheme *apiruntime.Scheme) (cluster.Cluster, error) {
+	restConfig, err := clientcmd.NewNonInteractiveClientConfig(config, context, &clientcmd.ConfigOverrides{}, nil).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("could not create client config of cluster %s: %w", name, err)
+	}
+
+	restConfig.QPS = cluster.ClientQPS
+	restConfig.Burst = cluster.ClientBurst
+
+	c, err := cluster.NewSingleCluster(name, restConfig, scheme)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create cluster %s: %w", name, err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,91 @@
+package clusters_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/pkg/clusters"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// newKubeconfig returns a kubeconfig with a context per host, named after
+// the keys, the first one being current.
+func newKubeconfig(current string, hosts map[string]string) *clientcmdapi.Config {
+	config := clientcmdapi.NewConfig()
+
+	for name, host := range hosts {
+		config.Clusters[name] = &clientcmdapi.Cluster{Server: host}
+		config.AuthInfos[name] = &clientcmdapi.AuthInfo{Token: "token"}
+		config.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name}
+	}
+
+	config.CurrentContext = current
+
+	return config
+}
+
+func writeKubeconfig(t *testing.T, path string, config *clientcmdapi.Config) {
+	assert.NoError(t, clientcmd.WriteToFile(*config, path))
+}
+
+func clusterHosts(clusters []cluster.Cluster) map[string]string {
+	hosts := map[string]string{}
+
+	for _, c := range clusters {
+		hosts[c.GetName()] = c.GetHost()
+	}
+
+	return hosts
+}
+
+func TestKubeconfigFetcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	writeKubeconfig(t, path, newKubeconfig("dev", map[string]string{
+		"dev":     "https://dev.example.com",
+		"staging": "https://staging.example.com",
+		"prod":    "https://prod.example.com",
+	}))
+
+	fetched, err := clusters.NewKubeconfigFetcher(path, []string{"dev", "prod"}, kube.CreateScheme()).Fetch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"dev":  "https://dev.example.com",
+		"prod": "https://prod.example.com",
+	}, clusterHosts(fetched))
+
+	fetched, err = clusters.NewKubeconfigFetcher(path, []string{clusters.AllContexts}, kube.CreateScheme()).Fetch(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, fetched, 3)
+
+	_, err = clusters.NewKubeconfigFetcher(path, []string{"unknown"}, kube.CreateScheme()).Fetch(context.Background())
+	assert.Error(t, err)
+}
+
+func TestDirectoryFetcher(t *testing.T) {
+	dir := t.TempDir()
+
+	writeKubeconfig(t, filepath.Join(dir, "eu-west.yaml"), newKubeconfig("admin", map[string]string{
+		"admin": "https://eu-west.example.com",
+	}))
+	writeKubeconfig(t, filepath.Join(dir, "us-east"), newKubeconfig("admin", map[string]string{
+		"admin": "https://us-east.example.com",
+	}))
+	writeKubeconfig(t, filepath.Join(dir, ".hidden"), newKubeconfig("admin", map[string]string{
+		"admin": "https://hidden.example.com",
+	}))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.yaml"), []byte("not: [a kubeconfig"), 0o600))
+
+	fetched, err := clusters.NewDirectoryFetcher(dir, kube.CreateScheme(), logr.Discard()).Fetch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"eu-west": "https://eu-west.example.com",
+		"us-east": "https://us-east.example.com",
+	}, clusterHosts(fetched))
+}
@@ -0,0 +1,162 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apiruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	// gitopsClusterListGVK lists the objects weave-gitops declares leaf clusters with.
+	gitopsClusterListGVK = schema.GroupVersionKind{Group: "gitops.weave.works", Version: "v1alpha1", Kind: "GitopsClusterList"}
+	// capiClusterListGVK lists Cluster API clusters.
+	capiClusterListGVK = schema.GroupVersionKind{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "ClusterList"}
+)
+
+// kubeconfigSecretKeys are the keys kubeconfig secrets store the kubeconfig
+// in, Cluster API uses the first one.
+var kubeconfigSecretKeys = []string{"value", "value.yaml"}
+
+type secretsFetcher struct {
+	client client.Client
+	scheme *apiruntime.Scheme
+	logger logr.Logger
+}
+
+// NewSecretsFetcher returns the clusters of the GitopsCluster and Cluster API
+// Cluster objects of the management cluster, from their kubeconfig secrets.
+// Clusters are named namespace/name after the object declaring them, kinds
+// whose CRD isn't installed are ignored.
+func NewSecretsFetcher(managementClient client.Client, scheme *apiruntime.Scheme, logger logr.Logger) clustersmngr.ClusterFetcher {
+	return secretsFetcher{client: managementClient, scheme: scheme, logger: logger}
+}
+
+// kubeconfigSecret is the secret of a cluster.
+type kubeconfigSecret struct {
+	cluster types.NamespacedName
+	secret  types.NamespacedName
+}
+
+func (f secretsFetcher) Fetch(ctx context.Context) ([]cluster.Cluster, error) {
+	secrets, err := f.kubeconfigSecrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := []cluster.Cluster{}
+
+	for _, ref := range secrets {
+		c, err := f.clusterFromSecret(ctx, ref)
+		if err != nil {
+			f.logger.Error(err, "skipping cluster", "cluster", ref.cluster.String())
+			continue
+		}
+
+		clusters = append(clusters, c)
+	}
+
+	return clusters, nil
+}
+
+// kubeconfigSecrets returns the secrets of GitopsClusters, then the ones of
+// Cluster API clusters no GitopsCluster references.
+func (f secretsFetcher) kubeconfigSecrets(ctx context.Context) ([]kubeconfigSecret, error) {
+	secrets := []kubeconfigSecret{}
+	seen := map[types.NamespacedName]bool{}
+
+	gitopsClusters, err := f.list(ctx, gitopsClusterListGVK)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, obj := range gitopsClusters {
+		name := types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}
+
+		secretName, _, _ := unstructured.NestedString(obj.Object, "spec", "secretRef", "name")
+		if secretName == "" {
+			capiName, _, _ := unstructured.NestedString(obj.Object, "spec", "capiClusterRef", "name")
+			if capiName == "" {
+				continue
+			}
+
+			secretName = capiKubeconfigSecretName(capiName)
+		}
+
+		secret := types.NamespacedName{Name: secretName, Namespace: obj.GetNamespace()}
+		seen[secret] = true
+		secrets = append(secrets, kubeconfigSecret{cluster: name, secret: secret})
+	}
+
+	capiClusters, err := f.list(ctx, capiClusterListGVK)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, obj := range capiClusters {
+		secret := types.NamespacedName{Name: capiKubeconfigSecretName(obj.GetName()), Namespace: obj.GetNamespace()}
+		if seen[secret] {
+			continue
+		}
+
+		secrets = append(secrets, kubeconfigSecret{
+			cluster: types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()},
+			secret:  secret,
+		})
+	}
+
+	return secrets, nil
+}
+
+func (f secretsFetcher) list(ctx context.Context, gvk schema.GroupVersionKind) ([]unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk)
+
+	if err := f.client.List(ctx, list); err != nil {
+		if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed listing %s: %w", gvk.Kind, err)
+	}
+
+	return list.Items, nil
+}
+
+func (f secretsFetcher) clusterFromSecret(ctx context.Context, ref kubeconfigSecret) (cluster.Cluster, error) {
+	secret := corev1.Secret{}
+	if err := f.client.Get(ctx, ref.secret, &secret); err != nil {
+		return nil, fmt.Errorf("failed getting kubeconfig secret %s: %w", ref.secret, err)
+	}
+
+	for _, key := range kubeconfigSecretKeys {
+		data, ok := secret.Data[key]
+		if !ok {
+			continue
+		}
+
+		config, err := clientcmd.Load(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kubeconfig in secret %s: %w", ref.secret, err)
+		}
+
+		return newCluster(ref.cluster.String(), *config, config.CurrentContext, f.scheme)
+	}
+
+	return nil, fmt.Errorf("no kubeconfig found in secret %s", ref.secret)
+}
+
+func capiKubeconfigSecretName(clusterName string) string {
+	return clusterName + "-kubeconfig"
+}
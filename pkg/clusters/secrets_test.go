@@ -0,0 +1,72 @@
+package clusters_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
+	"github.com/weaveworks/progressive-delivery/pkg/clusters"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func newKubeconfigSecret(ctx context.Context, t *testing.T, name, namespace, key, host string) {
+	data, err := clientcmd.Write(*newKubeconfig("admin", map[string]string{"admin": host}))
+	assert.NoError(t, err)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Data:       map[string][]byte{key: data},
+	}
+	assert.NoError(t, k8sEnv.Client.Create(ctx, secret))
+}
+
+func newClusterObject(ctx context.Context, t *testing.T, apiVersion, kind, name, namespace string, spec map[string]interface{}) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+
+	assert.NoError(t, k8sEnv.Client.Create(ctx, obj))
+}
+
+func TestSecretsFetcher(t *testing.T) {
+	ctx := context.Background()
+	ns := pdtesting.NewNamespace(ctx, t, k8sEnv.Client)
+
+	// A GitopsCluster referencing a kubeconfig secret.
+	newKubeconfigSecret(ctx, t, "leaf-a-kubeconfig", ns.Name, "value.yaml", "https://leaf-a.example.com")
+	newClusterObject(ctx, t, "gitops.weave.works/v1alpha1", "GitopsCluster", "leaf-a", ns.Name, map[string]interface{}{
+		"secretRef": map[string]interface{}{"name": "leaf-a-kubeconfig"},
+	})
+
+	// A GitopsCluster referencing a CAPI cluster, served once.
+	newKubeconfigSecret(ctx, t, "capi-b-kubeconfig", ns.Name, "value", "https://capi-b.example.com")
+	newClusterObject(ctx, t, "cluster.x-k8s.io/v1beta1", "Cluster", "capi-b", ns.Name, map[string]interface{}{})
+	newClusterObject(ctx, t, "gitops.weave.works/v1alpha1", "GitopsCluster", "leaf-b", ns.Name, map[string]interface{}{
+		"capiClusterRef": map[string]interface{}{"name": "capi-b"},
+	})
+
+	// A CAPI cluster without GitopsCluster.
+	newKubeconfigSecret(ctx, t, "capi-c-kubeconfig", ns.Name, "value", "https://capi-c.example.com")
+	newClusterObject(ctx, t, "cluster.x-k8s.io/v1beta1", "Cluster", "capi-c", ns.Name, map[string]interface{}{})
+
+	// A CAPI cluster still provisioning, without secret.
+	newClusterObject(ctx, t, "cluster.x-k8s.io/v1beta1", "Cluster", "capi-d", ns.Name, map[string]interface{}{})
+
+	fetched, err := clusters.NewSecretsFetcher(k8sEnv.Client, kube.CreateScheme(), logr.Discard()).Fetch(ctx)
+	assert.NoError(t, err)
+
+	hosts := clusterHosts(fetched)
+	assert.Equal(t, "https://leaf-a.example.com", hosts[ns.Name+"/leaf-a"])
+	assert.Equal(t, "https://capi-b.example.com", hosts[ns.Name+"/leaf-b"])
+	assert.Equal(t, "https://capi-c.example.com", hosts[ns.Name+"/capi-c"])
+	assert.NotContains(t, hosts, ns.Name+"/capi-b")
+	assert.NotContains(t, hosts, ns.Name+"/capi-d")
+}
@@ -0,0 +1,240 @@
+package convert
+
+import (
+	"fmt"
+	"time"
+
+	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/models"
+	"github.com/weaveworks/progressive-delivery/pkg/services/argo"
+	"gopkg.in/yaml.v3"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func ArgoRolloutToProto(rollout argo.Rollout, clusterName string, template v1.PodTemplateSpec, promoted []v1.Container, analysisTemplates []argo.AnalysisTemplate, analysisRuns []argo.AnalysisRun) *pb.Canary {
+	conditions := []*pb.CanaryCondition{}
+	lastTransition := metav1.Time{}
+
+	for _, condition := range rollout.Status.Conditions {
+		conditions = append(conditions, &pb.CanaryCondition{
+			Type:               condition.Type,
+			Status:             condition.Status,
+			LastUpdateTime:     condition.LastUpdateTime.Format(time.RFC3339),
+			LastTransitionTime: condition.LastTransitionTime.Format(time.RFC3339),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+		})
+
+		if lastTransition.Before(&condition.LastTransitionTime) {
+			lastTransition = condition.LastTransitionTime
+		}
+	}
+
+	labels := rollout.GetLabels()
+	fluxLabels := &pb.FluxLabels{
+		KustomizeNamespace: labels["kustomize.toolkit.fluxcd.io/namespace"],
+		KustomizeName:      labels["kustomize.toolkit.fluxcd.io/name"],
+	}
+
+	rolloutYaml, _ := yaml.Marshal(rollout.Object.Object)
+	strategy, _, _ := unstructured.NestedFieldNoCopy(rollout.Object.Object, "spec", "strategy")
+	strategyYaml, _ := yaml.Marshal(strategy)
+
+	images := map[string]string{}
+	for _, container := range template.Spec.Containers {
+		images[container.Name] = container.Image
+	}
+
+	promotedImages := map[string]string{}
+	for _, c := range promoted {
+		promotedImages[c.Name] = c.Image
+	}
+
+	targetReference := &pb.CanaryTargetReference{
+		Kind: argo.RolloutKind,
+		Name: rollout.GetName(),
+	}
+	if ref := rollout.Spec.WorkloadRef; ref != nil {
+		targetReference = &pb.CanaryTargetReference{
+			Kind: ref.Kind,
+			Name: ref.Name,
+		}
+	}
+
+	stepWeights := []int32{}
+	maxWeight := int32(0)
+
+	if canary := rollout.Spec.Strategy.Canary; canary != nil {
+		for _, step := range canary.Steps {
+			if step.SetWeight == nil {
+				continue
+			}
+
+			stepWeights = append(stepWeights, *step.SetWeight)
+
+			if *step.SetWeight > maxWeight {
+				maxWeight = *step.SetWeight
+			}
+		}
+	}
+
+	metrics := []*pb.CanaryMetric{}
+	for _, template := range analysisTemplates {
+		for _, metric := range template.Spec.Metrics {
+			metrics = append(metrics, &pb.CanaryMetric{
+				Name:           metric.Name,
+				Interval:       metric.Interval,
+				MetricTemplate: argoMetricToProto(template, metric, clusterName),
+			})
+		}
+	}
+
+	iterations := int32(0)
+	if rollout.Status.CurrentStepIndex != nil {
+		iterations = *rollout.Status.CurrentStepIndex
+	}
+
+	return &pb.Canary{
+		Name:            rollout.GetName(),
+		Namespace:       rollout.GetNamespace(),
+		ClusterName:     clusterName,
+		Provider:        rollout.TrafficRouter(),
+		TargetReference: targetReference,
+		TargetDeployment: &pb.CanaryTargetDeployment{
+			Uid:                   string(rollout.GetUID()),
+			ResourceVersion:       rollout.GetResourceVersion(),
+			FluxLabels:            fluxLabels,
+			AppliedImageVersions:  images,
+			PromotedImageVersions: promotedImages,
+		},
+		TargetWorkload: &pb.CanaryTargetWorkload{
+			Kind:                  "Rollout",
+			Name:                  rollout.GetName(),
+			Uid:                   string(rollout.GetUID()),
+			ResourceVersion:       rollout.GetResourceVersion(),
+			FluxLabels:            fluxLabels,
+			AppliedImageVersions:  images,
+			PromotedImageVersions: promotedImages,
+		},
+		Analysis: &pb.CanaryAnalysis{
+			MaxWeight:   maxWeight,
+			StepWeights: stepWeights,
+			Yaml:        string(strategyYaml),
+			Metrics:     metrics,
+		},
+		Status: &pb.CanaryStatus{
+			Phase:              rollout.CanaryPhase(),
+			FailedChecks:       argoFailedChecks(analysisRuns),
+			CanaryWeight:       argoCanaryWeight(rollout),
+			Iterations:         iterations,
+			LastTransitionTime: lastTransition.Format(time.RFC3339),
+			Conditions:         conditions,
+		},
+		Yaml:       string(rolloutYaml),
+		Controller: models.ArgoRolloutsController,
+	}
+}
+
+// ArgoAnalysisTemplateToProto returns a metric template for each metric of
+// the AnalysisTemplate. Templates with more than one metric are named
+// <template>/<metric>.
+func ArgoAnalysisTemplateToProto(template argo.AnalysisTemplate, clusterName string) []*pb.CanaryMetricTemplate {
+	result := []*pb.CanaryMetricTemplate{}
+
+	for _, metric := range template.Spec.Metrics {
+		pbObject := argoMetricToProto(template, metric, clusterName)
+
+		if len(template.Spec.Metrics) > 1 {
+			pbObject.Name = fmt.Sprintf("%s/%s", template.GetName(), metric.Name)
+		}
+
+		result = append(result, pbObject)
+	}
+
+	return result
+}
+
+func argoMetricToProto(template argo.AnalysisTemplate, metric argo.Metric, clusterName string) *pb.CanaryMetricTemplate {
+	provider := &pb.MetricProvider{}
+	query := ""
+
+	for providerType, value := range metric.Provider {
+		provider.Type = providerType
+
+		config, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if address, ok := config["address"].(string); ok {
+			provider.Address = address
+		}
+
+		if url, ok := config["url"].(string); ok {
+			provider.Address = url
+		}
+
+		if insecure, ok := config["insecure"].(bool); ok {
+			provider.InsecureSkipVerify = insecure
+		}
+
+		if q, ok := config["query"].(string); ok {
+			query = q
+		}
+	}
+
+	templateYaml, _ := yaml.Marshal(template.Object.Object)
+
+	return &pb.CanaryMetricTemplate{
+		ClusterName: clusterName,
+		Name:        template.GetName(),
+		Namespace:   template.GetNamespace(),
+		Provider:    provider,
+		Query:       query,
+		Yaml:        string(templateYaml),
+		Controller:  models.ArgoRolloutsController,
+	}
+}
+
+// argoCanaryWeight returns the traffic weight of the canary, as reported by
+// the traffic router, or as set by the last step reached otherwise.
+func argoCanaryWeight(rollout argo.Rollout) int32 {
+	if weights := rollout.Status.Canary.Weights; weights != nil {
+		return weights.Canary.Weight
+	}
+
+	canary := rollout.Spec.Strategy.Canary
+	if canary == nil || rollout.Status.CurrentStepIndex == nil || rollout.Status.Phase == "Healthy" {
+		return 0
+	}
+
+	weight := int32(0)
+
+	for i, step := range canary.Steps {
+		if int32(i) >= *rollout.Status.CurrentStepIndex {
+			break
+		}
+
+		if step.SetWeight != nil {
+			weight = *step.SetWeight
+		}
+	}
+
+	return weight
+}
+
+// argoFailedChecks counts the failed measurements of the latest analysis run.
+func argoFailedChecks(runs []argo.AnalysisRun) int32 {
+	if len(runs) == 0 {
+		return 0
+	}
+
+	failed := int32(0)
+	for _, result := range runs[len(runs)-1].Status.MetricResults {
+		failed += result.Failed
+	}
+
+	return failed
+}
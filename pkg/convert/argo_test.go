@@ -0,0 +1,92 @@
+package convert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/pkg/models"
+	"github.com/weaveworks/progressive-delivery/pkg/services/argo"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+func TestArgoRolloutToProto(t *testing.T) {
+	rollout := argo.Rollout{
+		Spec: argo.RolloutSpec{
+			Strategy: argo.RolloutStrategy{
+				Canary: &argo.CanaryStrategy{
+					Steps: []argo.CanaryStep{
+						{SetWeight: int32Ptr(20)},
+						{Pause: &argo.RolloutPause{}},
+						{SetWeight: int32Ptr(60)},
+					},
+					TrafficRouting: map[string]interface{}{"istio": map[string]interface{}{}},
+				},
+			},
+		},
+		Status: argo.RolloutStatus{
+			Phase:            "Paused",
+			CurrentStepIndex: int32Ptr(1),
+		},
+		Object: unstructured.Unstructured{Object: map[string]interface{}{}},
+	}
+	rollout.SetName("example")
+	rollout.SetNamespace("default")
+
+	runs := []argo.AnalysisRun{
+		{Status: argo.AnalysisRunStatus{MetricResults: []argo.MetricResult{{Name: "success-rate", Failed: 2}}}},
+	}
+
+	canary := ArgoRolloutToProto(rollout, "Default", rollout.Spec.Template, nil, nil, runs)
+
+	assert.Equal(t, models.ArgoRolloutsController, canary.Controller)
+	assert.Equal(t, "istio", canary.Provider)
+	assert.Equal(t, "Rollout", canary.TargetReference.Kind)
+	assert.Equal(t, "Waiting", canary.Status.Phase)
+	assert.Equal(t, int32(20), canary.Status.CanaryWeight)
+	assert.Equal(t, int32(2), canary.Status.FailedChecks)
+	assert.Equal(t, []int32{20, 60}, canary.Analysis.StepWeights)
+	assert.Equal(t, int32(60), canary.Analysis.MaxWeight)
+
+	rollout.Status.Abort = true
+	assert.Equal(t, "Failed", ArgoRolloutToProto(rollout, "Default", rollout.Spec.Template, nil, nil, nil).Status.Phase)
+}
+
+func TestArgoAnalysisTemplateToProto(t *testing.T) {
+	template := argo.AnalysisTemplate{
+		Spec: argo.AnalysisTemplateSpec{
+			Metrics: []argo.Metric{
+				{
+					Name: "success-rate",
+					Provider: map[string]interface{}{
+						"prometheus": map[string]interface{}{
+							"address": "http://prometheus:9090",
+							"query":   "custom query",
+						},
+					},
+				},
+				{
+					Name: "webcheck",
+					Provider: map[string]interface{}{
+						"web": map[string]interface{}{"url": "http://example"},
+					},
+				},
+			},
+		},
+	}
+	template.SetName("checks")
+	template.SetNamespace("default")
+
+	templates := ArgoAnalysisTemplateToProto(template, "Default")
+
+	assert.Len(t, templates, 2)
+	assert.Equal(t, "checks/success-rate", templates[0].Name)
+	assert.Equal(t, "prometheus", templates[0].Provider.Type)
+	assert.Equal(t, "http://prometheus:9090", templates[0].Provider.Address)
+	assert.Equal(t, "custom query", templates[0].Query)
+	assert.Equal(t, "web", templates[1].Provider.Type)
+	assert.Equal(t, "http://example", templates[1].Provider.Address)
+}
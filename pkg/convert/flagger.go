@@ -10,15 +10,15 @@ import (
 	"github.com/go-asset/generics/list"
 	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
 	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"github.com/weaveworks/progressive-delivery/pkg/models"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
 	"gopkg.in/yaml.v3"
-	appsv1 "k8s.io/api/apps/v1"
-	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func FlaggerCanaryToProto(canary v1beta1.Canary, clusterName string, deployment appsv1.Deployment, promoted []v1.Container, metricTemplates []v1beta1.MetricTemplate) *pb.Canary {
+func FlaggerCanaryToProto(canary v1beta1.Canary, clusterName string, target, promoted flagger.Workload, metricTemplates []v1beta1.MetricTemplate) *pb.Canary {
 	conditions := []*pb.CanaryCondition{}
 
 	for _, condition := range canary.Status.Conditions {
@@ -33,22 +33,19 @@ func FlaggerCanaryToProto(canary v1beta1.Canary, clusterName string, deployment
 	}
 
 	fluxLabels := &pb.FluxLabels{
-		KustomizeNamespace: deployment.Labels["kustomize.toolkit.fluxcd.io/namespace"],
-		KustomizeName:      deployment.Labels["kustomize.toolkit.fluxcd.io/name"],
+		KustomizeNamespace: target.Labels["kustomize.toolkit.fluxcd.io/namespace"],
+		KustomizeName:      target.Labels["kustomize.toolkit.fluxcd.io/name"],
 	}
 
 	canaryYaml, _ := serializeObj(&canary)
 	analysisYaml, _ := yaml.Marshal(canary.Spec.Analysis)
 
-	images := map[string]string{}
+	images := target.Images()
+	promotedImages := promoted.Images()
 
-	for _, container := range deployment.Spec.Template.Spec.Containers {
-		images[container.Name] = container.Image
-	}
-
-	promotedImages := map[string]string{}
-	for _, c := range promoted {
-		promotedImages[c.Name] = c.Image
+	containerDiffs := []*pb.CanaryContainerDiff{}
+	if promoted.Name != "" {
+		containerDiffs = list.Map(flagger.DiffContainers(target, promoted), ContainerDiffToProto)
 	}
 
 	//canary metrics
@@ -80,8 +77,9 @@ func FlaggerCanaryToProto(canary v1beta1.Canary, clusterName string, deployment
 							SecretName:         secretRefName,
 							InsecureSkipVerify: mt.Spec.Provider.InsecureSkipVerify,
 						},
-						Query: mt.Spec.Query,
-						Yaml:  string(metricTemplateYaml),
+						Query:      mt.Spec.Query,
+						Yaml:       string(metricTemplateYaml),
+						Controller: models.FlaggerController,
 					}
 				}
 			}
@@ -89,18 +87,7 @@ func FlaggerCanaryToProto(canary v1beta1.Canary, clusterName string, deployment
 
 		var thresholdRange *pb.CanaryMetricThresholdRange
 		if metric.ThresholdRange != nil {
-			var min float64
-			var max float64
-			if metric.ThresholdRange.Min != nil {
-				min = *metric.ThresholdRange.Min
-			}
-			if metric.ThresholdRange.Max != nil {
-				max = *metric.ThresholdRange.Max
-			}
-			thresholdRange = &pb.CanaryMetricThresholdRange{
-				Min: min,
-				Max: max,
-			}
+			thresholdRange = thresholdRangeToProto(*metric.ThresholdRange)
 		}
 
 		metrics = append(metrics, &pb.CanaryMetric{
@@ -121,12 +108,22 @@ func FlaggerCanaryToProto(canary v1beta1.Canary, clusterName string, deployment
 			Name: canary.Spec.TargetRef.Name,
 		},
 		TargetDeployment: &pb.CanaryTargetDeployment{
-			Uid:                   string(deployment.GetObjectMeta().GetUID()),
-			ResourceVersion:       deployment.GetObjectMeta().GetResourceVersion(),
+			Uid:                   string(target.UID),
+			ResourceVersion:       target.ResourceVersion,
 			FluxLabels:            fluxLabels,
 			AppliedImageVersions:  images,
 			PromotedImageVersions: promotedImages,
 		},
+		TargetWorkload: &pb.CanaryTargetWorkload{
+			Kind:                  flagger.TargetKind(canary),
+			Name:                  canary.Spec.TargetRef.Name,
+			Uid:                   string(target.UID),
+			ResourceVersion:       target.ResourceVersion,
+			FluxLabels:            fluxLabels,
+			AppliedImageVersions:  images,
+			PromotedImageVersions: promotedImages,
+			ContainerDiffs:        containerDiffs,
+		},
 		Analysis: &pb.CanaryAnalysis{
 			Interval:            canary.Spec.Analysis.Interval,
 			Iterations:          int32(canary.Spec.Analysis.Iterations),
@@ -151,7 +148,38 @@ func FlaggerCanaryToProto(canary v1beta1.Canary, clusterName string, deployment
 			LastTransitionTime: canary.Status.LastTransitionTime.Format(time.RFC3339),
 			Conditions:         conditions,
 		},
-		Yaml: string(canaryYaml),
+		Yaml:       string(canaryYaml),
+		Controller: models.FlaggerController,
+	}
+}
+
+func ContainerDiffToProto(diff flagger.ContainerDiff) *pb.CanaryContainerDiff {
+	return &pb.CanaryContainerDiff{
+		Name:              diff.Name,
+		Change:            string(diff.Change),
+		TargetImage:       imageReferenceToProto(diff.TargetImage),
+		PromotedImage:     imageReferenceToProto(diff.PromotedImage),
+		RepositoryChanged: diff.RepositoryChanged,
+		TagChanged:        diff.TagChanged,
+		DigestChanged:     diff.DigestChanged,
+		Env: list.Map(diff.Env, func(env flagger.EnvVarDiff) *pb.CanaryEnvVarDiff {
+			return &pb.CanaryEnvVarDiff{
+				Name:          env.Name,
+				Change:        string(env.Change),
+				TargetValue:   env.TargetValue,
+				PromotedValue: env.PromotedValue,
+			}
+		}),
+		ResourcesChanged: diff.ResourcesChanged,
+		ChangedProbes:    diff.ChangedProbes,
+	}
+}
+
+func imageReferenceToProto(ref flagger.ImageReference) *pb.CanaryImageReference {
+	return &pb.CanaryImageReference{
+		Repository: ref.Repository,
+		Tag:        ref.Tag,
+		Digest:     ref.Digest,
 	}
 }
 
@@ -173,6 +201,33 @@ func FlaggerMetricTemplateToProto(template v1beta1.MetricTemplate, clusterName s
 			SecretName:         secretName,
 			InsecureSkipVerify: template.Spec.Provider.InsecureSkipVerify,
 		},
+		Controller: models.FlaggerController,
+	}
+}
+
+func FlaggerMetricCheckToProto(check flagger.MetricCheck) *pb.CanaryMetricCheck {
+	return &pb.CanaryMetricCheck{
+		CanaryName:      check.CanaryName,
+		CanaryNamespace: check.CanaryNamespace,
+		MetricName:      check.MetricName,
+		ThresholdRange:  thresholdRangeToProto(check.ThresholdRange),
+		WithinRange:     check.WithinRange,
+	}
+}
+
+func thresholdRangeToProto(thresholdRange v1beta1.CanaryThresholdRange) *pb.CanaryMetricThresholdRange {
+	var min float64
+	var max float64
+	if thresholdRange.Min != nil {
+		min = *thresholdRange.Min
+	}
+	if thresholdRange.Max != nil {
+		max = *thresholdRange.Max
+	}
+
+	return &pb.CanaryMetricThresholdRange{
+		Min: min,
+		Max: max,
 	}
 }
 
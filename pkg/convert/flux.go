@@ -0,0 +1,49 @@
+package convert
+
+import (
+	"time"
+
+	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flux"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func FluxAutomationToProto(automation flux.Automation) *pb.Automation {
+	result := &pb.Automation{
+		Kind:                  automation.Kind,
+		Name:                  automation.Name,
+		Namespace:             automation.Namespace,
+		Suspended:             automation.Suspended,
+		Ready:                 conditionToProto(automation.Ready),
+		LastAppliedRevision:   automation.LastAppliedRevision,
+		LastAttemptedRevision: automation.LastAttemptedRevision,
+		Commit:                automation.Commit,
+	}
+
+	if source := automation.Source; source != nil {
+		result.Source = &pb.AutomationSource{
+			Kind:      source.Kind,
+			Name:      source.Name,
+			Namespace: source.Namespace,
+			Url:       source.URL,
+			Revision:  source.Revision,
+			Ready:     conditionToProto(source.Ready),
+		}
+	}
+
+	return result
+}
+
+func conditionToProto(condition *metav1.Condition) *pb.Condition {
+	if condition == nil {
+		return nil
+	}
+
+	return &pb.Condition{
+		Type:      condition.Type,
+		Status:    string(condition.Status),
+		Reason:    condition.Reason,
+		Message:   condition.Message,
+		Timestamp: condition.LastTransitionTime.Format(time.RFC3339),
+	}
+}
@@ -6,6 +6,7 @@ import (
 	kustomizev2 "github.com/fluxcd/kustomize-controller/api/v1beta2"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
 	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	hpav2 "k8s.io/api/autoscaling/v2beta1"
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
@@ -22,6 +23,7 @@ func CreateScheme() *apiruntime.Scheme {
 	_ = corev1.AddToScheme(scheme)
 	_ = extensionsv1.AddToScheme(scheme)
 	_ = appsv1.AddToScheme(scheme)
+	_ = authorizationv1.AddToScheme(scheme)
 	_ = rbacv1.AddToScheme(scheme)
 	_ = netv1.AddToScheme(scheme)
 	_ = hpav2.AddToScheme(scheme)
@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+)
+
+const (
+	namespace      = "progressive_delivery"
+	collectTimeout = 10 * time.Second
+)
+
+var (
+	canariesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "canaries"),
+		"Number of canaries by cluster and phase.",
+		[]string{"cluster", "phase"}, nil,
+	)
+	canaryWeightDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "canary", "weight"),
+		"Percentage of the traffic routed to the canary.",
+		[]string{"cluster", "namespace", "name"}, nil,
+	)
+	canaryFailedChecksDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "canary", "failed_checks"),
+		"Number of failed checks of the current analysis of the canary.",
+		[]string{"cluster", "namespace", "name"}, nil,
+	)
+	flaggerAvailableDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "flagger", "available"),
+		"Whether Flagger is installed on the cluster.",
+		[]string{"cluster"}, nil,
+	)
+)
+
+// canaryCollector reads canaries from every cluster when it's scraped, so
+// values are never older than the scrape.
+type canaryCollector struct {
+	clustersManager clustersmngr.ClustersManager
+	flagger         flagger.Fetcher
+	crd             crd.Fetcher
+	logger          logr.Logger
+}
+
+// NewCanaryCollector returns a collector of canary gauges, canaries are listed
+// with the client of the server, not impersonating any user.
+func NewCanaryCollector(clustersManager clustersmngr.ClustersManager, flaggerFetcher flagger.Fetcher, crdFetcher crd.Fetcher, logger logr.Logger) prometheus.Collector {
+	return &canaryCollector{
+		clustersManager: clustersManager,
+		flagger:         flaggerFetcher,
+		crd:             crdFetcher,
+		logger:          logger,
+	}
+}
+
+func (c *canaryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- canariesDesc
+	ch <- canaryWeightDesc
+	ch <- canaryFailedChecksDesc
+	ch <- flaggerAvailableDesc
+}
+
+func (c *canaryCollector) Collect(ch chan<- prometheus.Metric) {
+	for clusterName, available := range c.crd.IsAvailableOnClusters(crd.FlaggerCRDName) {
+		value := 0.0
+		if available {
+			value = 1
+		}
+
+		ch <- prometheus.MustNewConstMetric(flaggerAvailableDesc, prometheus.GaugeValue, value, clusterName)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	clusterClient, err := c.clustersManager.GetServerClient(ctx)
+	if err != nil {
+		c.logger.Error(err, "unable to get server client")
+		return
+	}
+
+	canaries, _, listErr, err := c.flagger.ListCanaryDeployments(ctx, clusterClient, flagger.ListCanaryDeploymentsOptions{})
+	if err != nil {
+		c.logger.Error(err, "unable to list canaries")
+		return
+	}
+
+	for _, err := range listErr {
+		c.logger.Error(err, "unable to list canaries", "cluster", err.ClusterName)
+	}
+
+	for clusterName, list := range canaries {
+		counts := flagger.NewCanaryCounts()
+
+		for _, canary := range list {
+			counts.Add(c.flagger.AttributesOf(clusterName, canary))
+
+			ch <- prometheus.MustNewConstMetric(canaryWeightDesc, prometheus.GaugeValue,
+				float64(canary.Status.CanaryWeight), clusterName, canary.GetNamespace(), canary.GetName())
+			ch <- prometheus.MustNewConstMetric(canaryFailedChecksDesc, prometheus.GaugeValue,
+				float64(canary.Status.FailedChecks), clusterName, canary.GetNamespace(), canary.GetName())
+		}
+
+		for phase, count := range counts.Phases {
+			ch <- prometheus.MustNewConstMetric(canariesDesc, prometheus.GaugeValue, float64(count), clusterName, phase)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package metrics_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"github.com/weaveworks/progressive-delivery/pkg/metrics"
+	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCanaryCollector(t *testing.T) {
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	defer cancelFn()
+
+	k, err := client.New(k8sEnv.Rest, client.Options{
+		Scheme: kube.CreateScheme(),
+	})
+	assert.NoError(t, err)
+
+	ns := pdtesting.NewNamespace(ctx, t, k)
+
+	canary := pdtesting.NewCanary(ctx, t, k, pdtesting.CanaryInfo{
+		Name:      "podinfo",
+		Namespace: ns.GetName(),
+	})
+	defer pdtesting.Cleanup(ctx, t, k, &canary)
+
+	canary.Status.Phase = v1beta1.CanaryPhaseProgressing
+	canary.Status.CanaryWeight = 20
+	canary.Status.FailedChecks = 2
+	assert.NoError(t, k.Status().Update(ctx, &canary))
+
+	_, clustersManager, err := pdtesting.CreateClient(k8sEnv)
+	assert.NoError(t, err)
+
+	crdService := crd.NewNoCacheFetcher(clustersManager)
+
+	reg := prometheus.NewRegistry()
+	assert.NoError(t, reg.Register(metrics.NewCanaryCollector(clustersManager, flagger.NewFetcher(crdService, logr.Discard()), crdService, logr.Discard())))
+
+	expected := fmt.Sprintf(`
+# HELP progressive_delivery_canary_failed_checks Number of failed checks of the current analysis of the canary.
+# TYPE progressive_delivery_canary_failed_checks gauge
+progressive_delivery_canary_failed_checks{cluster="Default",name="podinfo",namespace="%[1]s"} 2
+# HELP progressive_delivery_canary_weight Percentage of the traffic routed to the canary.
+# TYPE progressive_delivery_canary_weight gauge
+progressive_delivery_canary_weight{cluster="Default",name="podinfo",namespace="%[1]s"} 20
+# HELP progressive_delivery_canaries Number of canaries by cluster and phase.
+# TYPE progressive_delivery_canaries gauge
+progressive_delivery_canaries{cluster="Default",phase="Progressing"} 1
+# HELP progressive_delivery_flagger_available Whether Flagger is installed on the cluster.
+# TYPE progressive_delivery_flagger_available gauge
+progressive_delivery_flagger_available{cluster="Default"} 1
+`, ns.GetName())
+
+	err = testutil.GatherAndCompare(reg, strings.NewReader(expected))
+	assert.NoError(t, err)
+}
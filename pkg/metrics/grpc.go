@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCMetrics records the latency and status code of every RPC.
+type GRPCMetrics struct {
+	duration *prometheus.HistogramVec
+}
+
+func NewGRPCMetrics(reg prometheus.Registerer) (*GRPCMetrics, error) {
+	m := &GRPCMetrics{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of RPCs by method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+	}
+
+	if err := reg.Register(m.duration); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *GRPCMetrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		m.observe(info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records streams when they end, the duration is the
+// lifetime of the stream.
+func (m *GRPCMetrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+
+		m.observe(info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+func (m *GRPCMetrics) observe(method string, start time.Time, err error) {
+	m.duration.
+		WithLabelValues(method, status.Code(err).String()).
+		Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,71 @@
+package metrics_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/pkg/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCMetrics_UnaryServerInterceptor(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	m, err := metrics.NewGRPCMetrics(reg)
+	assert.NoError(t, err)
+
+	interceptor := m.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/prog.ProgressiveDeliveryService/GetCanary"}
+
+	_, err = interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+
+	_, err = interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	})
+	assert.Error(t, err)
+
+	count, err := testutil.GatherAndCount(reg, "progressive_delivery_grpc_request_duration_seconds")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count, "one series per status code")
+
+	families, err := reg.Gather()
+	assert.NoError(t, err)
+
+	codesSeen := map[string]uint64{}
+	for _, metric := range families[0].GetMetric() {
+		for _, label := range metric.GetLabel() {
+			if label.GetName() == "code" {
+				codesSeen[label.GetValue()] = metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+
+	assert.Equal(t, map[string]uint64{"OK": 1, "NotFound": 1}, codesSeen)
+}
+
+func TestGRPCMetrics_StreamServerInterceptor(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	m, err := metrics.NewGRPCMetrics(reg)
+	assert.NoError(t, err)
+
+	interceptor := m.StreamServerInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/prog.ProgressiveDeliveryService/WatchCanaries", IsServerStream: true}
+
+	err = interceptor(nil, nil, info, func(srv interface{}, stream grpc.ServerStream) error {
+		return status.Error(codes.Canceled, "canceled")
+	})
+	assert.Error(t, err)
+
+	count, err := testutil.GatherAndCount(reg, "progressive_delivery_grpc_request_duration_seconds")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// otherRoute is recorded for requests whose handler didn't set a route, e.g.
+// unknown paths, so paths don't become labels.
+const otherRoute = "other"
+
+type routeKey struct{}
+
+// route is set by the handler of a request and read by the middleware once
+// the request is served.
+type route struct {
+	mu    sync.Mutex
+	value string
+}
+
+// SetRoute sets the route requests of the context are recorded with, e.g. the
+// path pattern of the gateway handler serving them.
+func SetRoute(ctx context.Context, value string) {
+	if r, ok := ctx.Value(routeKey{}).(*route); ok {
+		r.mu.Lock()
+		r.value = value
+		r.mu.Unlock()
+	}
+}
+
+// HTTPMetrics records the latency and status code of every request of the
+// REST gateway, which calls the server directly so gRPC interceptors don't
+// run.
+type HTTPMetrics struct {
+	duration *prometheus.HistogramVec
+}
+
+func NewHTTPMetrics(reg prometheus.Registerer) (*HTTPMetrics, error) {
+	m := &HTTPMetrics{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of HTTP requests by method, route and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "code"}),
+	}
+
+	if err := reg.Register(m.duration); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Middleware records the requests served by next, streams are recorded when
+// they end.
+func (m *HTTPMetrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		requestRoute := &route{value: otherRoute}
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), routeKey{}, requestRoute)))
+
+		requestRoute.mu.Lock()
+		defer requestRoute.mu.Unlock()
+
+		m.duration.
+			WithLabelValues(r.Method, requestRoute.value, strconv.Itoa(recorder.status)).
+			Observe(time.Since(start).Seconds())
+	})
+}
+
+// statusRecorder keeps the status code of a response, and can be flushed for
+// streams.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,54 @@
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/pkg/metrics"
+)
+
+func TestHTTPMetrics_Middleware(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	m, err := metrics.NewHTTPMetrics(reg)
+	assert.NoError(t, err)
+
+	handler := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/pd/canaries/podinfo" {
+			http.NotFound(w, r)
+			return
+		}
+
+		metrics.SetRoute(r.Context(), "/v1/pd/canaries/{name}")
+
+		_, isFlusher := w.(http.Flusher)
+		assert.True(t, isFlusher, "streams should still be flushed")
+	}))
+
+	for _, path := range []string{"/v1/pd/canaries/podinfo", "/v1/pd/canaries/podinfo", "/unknown"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	count, err := testutil.GatherAndCount(reg, "progressive_delivery_http_request_duration_seconds")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count, "one series per route and status code")
+
+	families, err := reg.Gather()
+	assert.NoError(t, err)
+
+	routes := map[string]uint64{}
+	for _, metric := range families[0].GetMetric() {
+		labels := map[string]string{}
+		for _, label := range metric.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+
+		routes[labels["route"]+" "+labels["code"]] = metric.GetHistogram().GetSampleCount()
+	}
+
+	assert.Equal(t, map[string]uint64{"/v1/pd/canaries/{name} 200": 2, "other 404": 1}, routes)
+}
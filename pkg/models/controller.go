@@ -0,0 +1,8 @@
+package models
+
+// Progressive delivery controllers, as reported in the controller field of
+// Canaries and metric templates.
+const (
+	FlaggerController      = "flagger"
+	ArgoRolloutsController = "argo-rollouts"
+)
@@ -0,0 +1,345 @@
+package pagination
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultConcurrency is the number of namespaces listed at once when the list
+// isn't paginated, if not set.
+const defaultConcurrency = 8
+
+// ListOptions configures a paginated list across clusters.
+type ListOptions struct {
+	// PageSize is the maximum number of objects returned, everything is
+	// returned if it's zero.
+	PageSize  int32
+	PageToken string
+	// Namespace restricts the list to a single namespace if set.
+	Namespace string
+	// SkipCluster excludes clusters from the list if set.
+	SkipCluster func(clusterName string) bool
+	// Keep drops objects from the results if set and it returns false.
+	// Dropped objects don't count towards the page size.
+	Keep func(clusterName string, obj runtime.Object) bool
+	// ListOptions are added to every request, e.g. label selectors.
+	ListOptions []client.ListOption
+	// Concurrency is the number of namespaces listed at once when PageSize
+	// is zero, pages are listed one namespace after the other.
+	Concurrency int
+}
+
+// Item is an object listed from a cluster.
+type Item struct {
+	ClusterName string
+	Object      runtime.Object
+}
+
+// cursor points to the next object to return. Objects are listed cluster by
+// cluster and namespace by namespace, both in name order, in chunks of the
+// page size. The chunk holding the next object is listed again from its
+// continue token, or from the resource version of the first chunk, so the
+// offset into it points to the same object.
+type cursor struct {
+	Cluster         string `json:"cluster"`
+	Namespace       string `json:"namespace"`
+	Continue        string `json:"continue,omitempty"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+	Offset          int    `json:"offset,omitempty"`
+}
+
+func decodeCursor(token string) (*cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	buf, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token: %w", err)
+	}
+
+	c := &cursor{}
+	if err := json.Unmarshal(buf, c); err != nil {
+		return nil, fmt.Errorf("invalid page token: %w", err)
+	}
+
+	return c, nil
+}
+
+func (c cursor) encode() string {
+	buf, _ := json.Marshal(c)
+
+	return base64.URLEncoding.EncodeToString(buf)
+}
+
+type location struct {
+	cluster   string
+	namespace string
+}
+
+// List returns a page of objects across all clusters and namespaces the
+// client has access to, and the token of the next page. The token is empty
+// once every object has been returned. Pages never overlap nor skip objects
+// as long as the continue tokens of the API servers are valid, which is five
+// minutes by default.
+func List(
+	ctx context.Context,
+	clusterClient clustersmngr.Client,
+	newList func() client.ObjectList,
+	opts ListOptions,
+) ([]Item, string, []clustersmngr.ListError, error) {
+	start, err := decodeCursor(opts.PageToken)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	locations := listLocations(clusterClient, opts)
+
+	if opts.PageSize <= 0 && start == nil {
+		items, listErrors := listAll(ctx, clusterClient, newList, opts, locations)
+
+		return items, "", listErrors, nil
+	}
+
+	first := 0
+	if start != nil {
+		first = len(locations)
+
+		for i, loc := range locations {
+			if loc.cluster > start.Cluster || (loc.cluster == start.Cluster && loc.namespace >= start.Namespace) {
+				first = i
+				break
+			}
+		}
+
+		// The namespace of the cursor is gone, start from the next one.
+		if first < len(locations) && (locations[first].cluster != start.Cluster || locations[first].namespace != start.Namespace) {
+			start = nil
+		}
+	}
+
+	items := []Item{}
+	listErrors := []clustersmngr.ListError{}
+
+	for i := first; i < len(locations); i++ {
+		loc := locations[i]
+
+		position := cursor{Cluster: loc.cluster, Namespace: loc.namespace}
+		if i == first && start != nil {
+			position = *start
+		}
+
+		for {
+			list := newList()
+
+			listOpts := append([]client.ListOption{client.InNamespace(loc.namespace)}, opts.ListOptions...)
+			if opts.PageSize > 0 {
+				listOpts = append(listOpts, client.Limit(opts.PageSize))
+			}
+
+			switch {
+			case position.Continue != "":
+				listOpts = append(listOpts, client.Continue(position.Continue))
+			case position.ResourceVersion != "":
+				listOpts = append(listOpts, &client.ListOptions{Raw: &metav1.ListOptions{
+					ResourceVersion:      position.ResourceVersion,
+					ResourceVersionMatch: metav1.ResourceVersionMatchExact,
+				}})
+			}
+
+			if err := clusterClient.List(ctx, loc.cluster, list, listOpts...); err != nil {
+				// Skipping the namespace would leave a gap, the client has to
+				// start over.
+				if (position.Continue != "" || position.ResourceVersion != "") && (k8serrors.IsResourceExpired(err) || k8serrors.IsGone(err)) {
+					return nil, "", nil, fmt.Errorf("page token expired: %w", err)
+				}
+
+				listErrors = append(listErrors, clustersmngr.ListError{Cluster: loc.cluster, Namespace: loc.namespace, Err: err})
+				break
+			}
+
+			if position.Continue == "" {
+				position.ResourceVersion = list.GetResourceVersion()
+			}
+
+			objects, err := apimeta.ExtractList(list)
+			if err != nil {
+				return nil, "", nil, fmt.Errorf("failed extracting list items: %w", err)
+			}
+
+			for j := position.Offset; j < len(objects); j++ {
+				if opts.Keep != nil && !opts.Keep(loc.cluster, objects[j]) {
+					continue
+				}
+
+				items = append(items, Item{ClusterName: loc.cluster, Object: objects[j]})
+
+				if opts.PageSize > 0 && len(items) == int(opts.PageSize) {
+					next := position
+					next.Offset = j + 1
+
+					return items, nextToken(locations, i, next, len(objects), list.GetContinue()), listErrors, nil
+				}
+			}
+
+			if list.GetContinue() == "" {
+				break
+			}
+
+			position = cursor{Cluster: loc.cluster, Namespace: loc.namespace, Continue: list.GetContinue()}
+		}
+	}
+
+	return items, "", listErrors, nil
+}
+
+// listAll returns every object of the locations, listed concurrently. Items
+// are returned in the order of the locations, the same as when paginated.
+func listAll(
+	ctx context.Context,
+	clusterClient clustersmngr.Client,
+	newList func() client.ObjectList,
+	opts ListOptions,
+	locations []location,
+) ([]Item, []clustersmngr.ListError) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	var (
+		wg        sync.WaitGroup
+		semaphore = make(chan struct{}, concurrency)
+		results   = make([][]Item, len(locations))
+		errs      = make([]error, len(locations))
+	)
+
+	for i, loc := range locations {
+		wg.Add(1)
+
+		go func(i int, loc location) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			results[i], errs[i] = listNamespace(ctx, clusterClient, newList, opts, loc)
+		}(i, loc)
+	}
+
+	wg.Wait()
+
+	items := []Item{}
+	listErrors := []clustersmngr.ListError{}
+
+	for i, loc := range locations {
+		items = append(items, results[i]...)
+
+		if errs[i] != nil {
+			listErrors = append(listErrors, clustersmngr.ListError{Cluster: loc.cluster, Namespace: loc.namespace, Err: errs[i]})
+		}
+	}
+
+	return items, listErrors
+}
+
+// listNamespace returns every kept object of a namespace. The objects listed
+// before a failure are returned with it.
+func listNamespace(
+	ctx context.Context,
+	clusterClient clustersmngr.Client,
+	newList func() client.ObjectList,
+	opts ListOptions,
+	loc location,
+) ([]Item, error) {
+	items := []Item{}
+	continueToken := ""
+
+	for {
+		list := newList()
+
+		listOpts := append([]client.ListOption{client.InNamespace(loc.namespace)}, opts.ListOptions...)
+		if continueToken != "" {
+			listOpts = append(listOpts, client.Continue(continueToken))
+		}
+
+		if err := clusterClient.List(ctx, loc.cluster, list, listOpts...); err != nil {
+			return items, err
+		}
+
+		objects, err := apimeta.ExtractList(list)
+		if err != nil {
+			return items, fmt.Errorf("failed extracting list items: %w", err)
+		}
+
+		for _, obj := range objects {
+			if opts.Keep == nil || opts.Keep(loc.cluster, obj) {
+				items = append(items, Item{ClusterName: loc.cluster, Object: obj})
+			}
+		}
+
+		continueToken = list.GetContinue()
+		if continueToken == "" {
+			return items, nil
+		}
+	}
+}
+
+// nextToken returns the token pointing after the last returned object, it
+// moves to the next chunk or namespace if the current one is exhausted.
+func nextToken(locations []location, index int, next cursor, chunkLen int, continueToken string) string {
+	if next.Offset < chunkLen {
+		return next.encode()
+	}
+
+	if continueToken != "" {
+		return cursor{Cluster: next.Cluster, Namespace: next.Namespace, Continue: continueToken}.encode()
+	}
+
+	if index+1 < len(locations) {
+		loc := locations[index+1]
+
+		return cursor{Cluster: loc.cluster, Namespace: loc.namespace}.encode()
+	}
+
+	return ""
+}
+
+func listLocations(clusterClient clustersmngr.Client, opts ListOptions) []location {
+	locations := []location{}
+
+	for clusterName, namespaces := range clusterClient.Namespaces() {
+		if opts.SkipCluster != nil && opts.SkipCluster(clusterName) {
+			continue
+		}
+
+		for _, ns := range namespaces {
+			if opts.Namespace != "" && opts.Namespace != ns.Name {
+				continue
+			}
+
+			locations = append(locations, location{cluster: clusterName, namespace: ns.Name})
+		}
+	}
+
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].cluster != locations[j].cluster {
+			return locations[i].cluster < locations[j].cluster
+		}
+
+		return locations[i].namespace < locations[j].namespace
+	})
+
+	return locations
+}
@@ -0,0 +1,205 @@
+package pagination_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"github.com/weaveworks/progressive-delivery/pkg/pagination"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestList_MultipleClusters(t *testing.T) {
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	defer cancelFn()
+
+	expected := map[string]bool{}
+	clients := map[string]client.Client{}
+
+	for clusterName, env := range k8sEnvs {
+		k, err := client.New(env.Rest, client.Options{
+			Scheme: kube.CreateScheme(),
+		})
+		assert.NoError(t, err)
+
+		clients[clusterName] = k
+
+		for i := 0; i < 2; i++ {
+			ns := pdtesting.NewNamespace(ctx, t, k)
+
+			for j := 0; j < 3; j++ {
+				tpl := pdtesting.NewMetricTemplate(ctx, t, k, pdtesting.MetricTemplateInfo{
+					Name:         fmt.Sprintf("template-%d", j),
+					Namespace:    ns.GetName(),
+					ProviderType: "prometheus",
+					Query:        "custom query",
+				})
+				defer pdtesting.Cleanup(ctx, t, k, tpl)
+
+				expected[key(clusterName, tpl)] = true
+			}
+		}
+	}
+
+	cl, err := pdtesting.CreateMultiClusterClient(k8sEnvs)
+	assert.NoError(t, err)
+
+	newList := func() client.ObjectList { return &v1beta1.MetricTemplateList{} }
+
+	for _, pageSize := range []int32{1, 2, 4, 5, 100} {
+		t.Run(fmt.Sprintf("page size %d", pageSize), func(t *testing.T) {
+			seen := map[string]bool{}
+			token := ""
+			pages := 0
+
+			for {
+				items, next, listErrors, err := pagination.List(ctx, cl, newList, pagination.ListOptions{
+					PageSize:  pageSize,
+					PageToken: token,
+				})
+				assert.NoError(t, err)
+				assert.Empty(t, listErrors)
+				assert.LessOrEqual(t, len(items), int(pageSize))
+
+				for _, item := range items {
+					k := key(item.ClusterName, item.Object)
+					assert.False(t, seen[k], "duplicate object: %s", k)
+
+					seen[k] = true
+				}
+
+				pages++
+
+				if next == "" {
+					break
+				}
+
+				token = next
+
+				// Objects created between pages must not shift the next one.
+				if pages == 1 {
+					for clusterName, k := range clients {
+						ns := pdtesting.NewNamespace(ctx, t, k)
+
+						tpl := pdtesting.NewMetricTemplate(ctx, t, k, pdtesting.MetricTemplateInfo{
+							Name:         "late",
+							Namespace:    ns.GetName(),
+							ProviderType: "prometheus",
+						})
+						defer pdtesting.Cleanup(ctx, t, k, tpl)
+
+						expected[key(clusterName, tpl)] = false
+					}
+				}
+			}
+
+			for k, required := range expected {
+				if required {
+					assert.True(t, seen[k], "missing object: %s", k)
+				}
+			}
+		})
+	}
+	t.Run("no page size", func(t *testing.T) {
+		items, next, listErrors, err := pagination.List(ctx, cl, newList, pagination.ListOptions{})
+		assert.NoError(t, err)
+		assert.Empty(t, listErrors)
+		assert.Empty(t, next)
+
+		seen := map[string]bool{}
+		for i, item := range items {
+			seen[key(item.ClusterName, item.Object)] = true
+
+			// Namespaces are listed concurrently, but returned in order.
+			if i > 0 {
+				assert.LessOrEqual(t, items[i-1].ClusterName, item.ClusterName)
+			}
+		}
+
+		for k, required := range expected {
+			if required {
+				assert.True(t, seen[k], "missing object: %s", k)
+			}
+		}
+	})
+}
+
+func TestList_Keep(t *testing.T) {
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	defer cancelFn()
+
+	clusterName := "cluster-a"
+
+	k, err := client.New(k8sEnvs[clusterName].Rest, client.Options{
+		Scheme: kube.CreateScheme(),
+	})
+	assert.NoError(t, err)
+
+	ns := pdtesting.NewNamespace(ctx, t, k)
+
+	for i := 0; i < 6; i++ {
+		tpl := pdtesting.NewMetricTemplate(ctx, t, k, pdtesting.MetricTemplateInfo{
+			Name:         fmt.Sprintf("template-%d", i),
+			Namespace:    ns.GetName(),
+			ProviderType: "prometheus",
+		})
+		defer pdtesting.Cleanup(ctx, t, k, tpl)
+	}
+
+	cl, err := pdtesting.CreateMultiClusterClient(k8sEnvs)
+	assert.NoError(t, err)
+
+	names := []string{}
+	token := ""
+
+	for {
+		items, next, _, err := pagination.List(ctx, cl, func() client.ObjectList { return &v1beta1.MetricTemplateList{} }, pagination.ListOptions{
+			PageSize:    2,
+			PageToken:   token,
+			Namespace:   ns.GetName(),
+			SkipCluster: func(name string) bool { return name != clusterName },
+			Keep: func(_ string, obj runtime.Object) bool {
+				name := obj.(*v1beta1.MetricTemplate).GetName()
+
+				return name != "template-1" && name != "template-4"
+			},
+		})
+		assert.NoError(t, err)
+
+		for _, item := range items {
+			names = append(names, item.Object.(*v1beta1.MetricTemplate).GetName())
+		}
+
+		if next == "" {
+			break
+		}
+
+		token = next
+	}
+
+	assert.Equal(t, []string{"template-0", "template-2", "template-3", "template-5"}, names)
+}
+
+func TestList_InvalidToken(t *testing.T) {
+	cl, err := pdtesting.CreateMultiClusterClient(k8sEnvs)
+	assert.NoError(t, err)
+
+	_, _, _, err = pagination.List(context.Background(), cl, func() client.ObjectList { return &v1beta1.MetricTemplateList{} }, pagination.ListOptions{
+		PageSize:  2,
+		PageToken: "not a token",
+	})
+	assert.Error(t, err)
+}
+
+func key(clusterName string, obj runtime.Object) string {
+	o := obj.(client.Object)
+
+	return fmt.Sprintf("%s/%s/%s", clusterName, o.GetNamespace(), o.GetName())
+}
@@ -0,0 +1,32 @@
+package pagination_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
+	"github.com/weaveworks/weave-gitops/pkg/testutils"
+)
+
+var k8sEnvs map[string]*testutils.K8sTestEnv
+
+func TestMain(m *testing.M) {
+	k8sEnvs = map[string]*testutils.K8sTestEnv{}
+
+	for _, name := range []string{"cluster-a", "cluster-b"} {
+		env, err := pdtesting.CreateTestEnv()
+		if err != nil {
+			panic(err)
+		}
+
+		k8sEnvs[name] = env
+	}
+
+	code := m.Run()
+
+	for _, env := range k8sEnvs {
+		env.Stop()
+	}
+
+	os.Exit(code)
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/models"
+	"github.com/weaveworks/progressive-delivery/pkg/services/argo"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flux"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+type canaryAction func(ctx context.Context, clusterClient clustersmngr.Client, opts flagger.CanaryActionOptions) (*v1beta1.Canary, error)
+
+func (pd *pdServer) PromoteCanary(ctx context.Context, msg *pb.PromoteCanaryRequest) (*pb.PromoteCanaryResponse, error) {
+	canary, err := pd.runCanaryAction(ctx, pd.flagger.PromoteCanary, flagger.CanaryActionOptions{
+		Name:        msg.Name,
+		Namespace:   msg.Namespace,
+		ClusterName: msg.ClusterName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("promoting canary: %w", err)
+	}
+
+	return &pb.PromoteCanaryResponse{Canary: canary}, nil
+}
+
+func (pd *pdServer) RollbackCanary(ctx context.Context, msg *pb.RollbackCanaryRequest) (*pb.RollbackCanaryResponse, error) {
+	canary, err := pd.runCanaryAction(ctx, pd.flagger.RollbackCanary, flagger.CanaryActionOptions{
+		Name:        msg.Name,
+		Namespace:   msg.Namespace,
+		ClusterName: msg.ClusterName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rolling back canary: %w", err)
+	}
+
+	return &pb.RollbackCanaryResponse{Canary: canary}, nil
+}
+
+func (pd *pdServer) PauseCanary(ctx context.Context, msg *pb.PauseCanaryRequest) (*pb.PauseCanaryResponse, error) {
+	canary, err := pd.runCanaryAction(ctx, pd.flagger.PauseCanary, flagger.CanaryActionOptions{
+		Name:        msg.Name,
+		Namespace:   msg.Namespace,
+		ClusterName: msg.ClusterName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pausing canary: %w", err)
+	}
+
+	return &pb.PauseCanaryResponse{Canary: canary}, nil
+}
+
+func (pd *pdServer) ResumeCanary(ctx context.Context, msg *pb.ResumeCanaryRequest) (*pb.ResumeCanaryResponse, error) {
+	canary, err := pd.runCanaryAction(ctx, pd.flagger.ResumeCanary, flagger.CanaryActionOptions{
+		Name:        msg.Name,
+		Namespace:   msg.Namespace,
+		ClusterName: msg.ClusterName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("resuming canary: %w", err)
+	}
+
+	return &pb.ResumeCanaryResponse{Canary: canary}, nil
+}
+
+func (pd *pdServer) ReconcileCanaryAutomation(ctx context.Context, msg *pb.ReconcileCanaryAutomationRequest) (*pb.ReconcileCanaryAutomationResponse, error) {
+	clusterClient, err := pd.clustersManager.GetImpersonatedClient(ctx, auth.Principal(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error getting impersonated client: %w", err)
+	}
+
+	var labels map[string]string
+
+	if msg.Controller == models.ArgoRolloutsController {
+		rollout, err := pd.argo.GetRollout(ctx, clusterClient, argo.GetRolloutOptions{
+			Name:        msg.Name,
+			Namespace:   msg.Namespace,
+			ClusterName: msg.ClusterName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("getting rollout: %w", err)
+		}
+
+		labels = rollout.GetLabels()
+	} else {
+		canary, err := pd.flagger.GetCanary(ctx, clusterClient, flagger.GetCanaryOptions{
+			Name:        msg.Name,
+			Namespace:   msg.Namespace,
+			ClusterName: msg.ClusterName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("getting canary: %w", err)
+		}
+
+		target, err := pd.flagger.FetchTargetRef(ctx, msg.ClusterName, clusterClient, canary)
+		if err != nil {
+			return nil, fmt.Errorf("fetching target ref: %w", err)
+		}
+
+		labels = target.Labels
+	}
+
+	automation := automationFromLabels(labels)
+	if automation == nil {
+		return nil, fmt.Errorf("canary %s/%s is not applied by a Kustomization or a HelmRelease", msg.Namespace, msg.Name)
+	}
+
+	requestedAt, err := pd.flux.RequestReconcile(ctx, msg.ClusterName, clusterClient, flux.AutomationReference{
+		Kind:      automation.Kind,
+		Name:      automation.Name,
+		Namespace: automation.Namespace,
+	}, flux.ReconcileOptions{WithSource: msg.WithSource})
+	if err != nil {
+		return nil, fmt.Errorf("reconciling automation: %w", err)
+	}
+
+	return &pb.ReconcileCanaryAutomationResponse{
+		Automation:  automation,
+		RequestedAt: requestedAt.Format(time.RFC3339Nano),
+	}, nil
+}
+
+func (pd *pdServer) runCanaryAction(ctx context.Context, action canaryAction, opts flagger.CanaryActionOptions) (*pb.Canary, error) {
+	clusterClient, err := pd.clustersManager.GetImpersonatedClient(ctx, auth.Principal(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error getting impersonated client: %w", err)
+	}
+
+	opts.GateHosts = pd.loadtesterHosts
+
+	canary, err := action(ctx, clusterClient, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return pd.canaryToProto(ctx, opts.ClusterName, clusterClient, *canary), nil
+}
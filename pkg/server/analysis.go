@@ -0,0 +1,43 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/convert"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+func (pd *pdServer) RunMetricTemplate(ctx context.Context, msg *pb.RunMetricTemplateRequest) (*pb.RunMetricTemplateResponse, error) {
+	clusterClient, err := pd.clustersManager.GetImpersonatedClient(ctx, auth.Principal(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error getting impersonated client: %w", err)
+	}
+
+	run, err := pd.flagger.RunMetricTemplate(ctx, clusterClient, flagger.RunMetricTemplateOptions{
+		Name:            msg.Name,
+		Namespace:       msg.Namespace,
+		ClusterName:     msg.ClusterName,
+		CanaryName:      msg.CanaryName,
+		CanaryNamespace: msg.CanaryNamespace,
+		Interval:        msg.Interval,
+		ProviderHosts:   pd.metricProviderHosts,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("running metric template: %w", err)
+	}
+
+	response := &pb.RunMetricTemplateResponse{
+		Query:  run.Query,
+		Value:  run.Value,
+		Checks: []*pb.CanaryMetricCheck{},
+	}
+
+	for _, check := range run.Checks {
+		response.Checks = append(response.Checks, convert.FlaggerMetricCheckToProto(check))
+	}
+
+	return response, nil
+}
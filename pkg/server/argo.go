@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/convert"
+	"github.com/weaveworks/progressive-delivery/pkg/services/argo"
+	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+func (pd *pdServer) IsArgoRolloutsAvailable(ctx context.Context, msg *pb.IsArgoRolloutsAvailableRequest) (*pb.IsArgoRolloutsAvailableResponse, error) {
+	return &pb.IsArgoRolloutsAvailableResponse{
+		Clusters: pd.crd.IsAvailableOnClusters(crd.ArgoRolloutsCRDName),
+	}, nil
+}
+
+// isArgoRolloutsInstalled reports whether Argo Rollouts is available on any
+// cluster, listing its objects can be skipped entirely otherwise.
+func (pd *pdServer) isArgoRolloutsInstalled() bool {
+	return isInstalled(pd.crd, crd.ArgoRolloutsCRDName)
+}
+
+func (pd *pdServer) getRollout(ctx context.Context, msg *pb.GetCanaryRequest) (*pb.GetCanaryResponse, error) {
+	clusterClient, err := pd.clustersManager.GetImpersonatedClient(ctx, auth.Principal(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error getting impersonated client: %w", err)
+	}
+
+	rollout, err := pd.argo.GetRollout(ctx, clusterClient, argo.GetRolloutOptions{
+		Name:        msg.Name,
+		Namespace:   msg.Namespace,
+		ClusterName: msg.ClusterName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting rollout: %w", err)
+	}
+
+	return &pb.GetCanaryResponse{
+		Canary:     pd.rolloutToProto(ctx, msg.ClusterName, clusterClient, *rollout),
+		Automation: pd.automationFor(ctx, msg.ClusterName, clusterClient, rollout.GetLabels()),
+	}, nil
+}
+
+// rolloutToProto converts a Rollout with its pod template, stable ReplicaSet,
+// referenced AnalysisTemplates and AnalysisRuns. Missing related objects are
+// ignored, the same way they are for Canaries.
+func (pd *pdServer) rolloutToProto(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, rollout argo.Rollout) *pb.Canary {
+	objects := argo.RolloutObjects{AnalysisTemplates: []argo.AnalysisTemplate{}}
+
+	objects.Template, _ = pd.argo.FetchPodTemplate(ctx, clusterName, clusterClient, &rollout)
+
+	objects.Stable, _ = pd.argo.FetchStable(ctx, clusterName, clusterClient, &rollout)
+
+	for _, ref := range rollout.AnalysisTemplateRefs() {
+		analysisTemplate, err := pd.argo.GetAnalysisTemplate(ctx, clusterName, clusterClient, ref, rollout.GetNamespace())
+		if err != nil {
+			pd.logger.Error(err, "unable to fetch analysis template from reference")
+			continue
+		}
+
+		objects.AnalysisTemplates = append(objects.AnalysisTemplates, analysisTemplate)
+	}
+
+	var err error
+
+	objects.AnalysisRuns, err = pd.argo.ListAnalysisRuns(ctx, clusterName, clusterClient, &rollout)
+	if err != nil {
+		pd.logger.Error(err, "unable to list analysis runs", "rollout", rollout.GetName())
+	}
+
+	return pd.rolloutObjectsToProto(clusterName, rollout, objects)
+}
+
+func (pd *pdServer) rolloutObjectsToProto(clusterName string, rollout argo.Rollout, objects argo.RolloutObjects) *pb.Canary {
+	pbObject := convert.ArgoRolloutToProto(rollout, clusterName, objects.Template, objects.Stable.Spec.Template.Spec.Containers, objects.AnalysisTemplates, objects.AnalysisRuns)
+
+	pbObject.DeploymentStrategy = string(pd.argo.DeploymentStrategyFor(rollout))
+
+	return pbObject
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flux"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+func (pd *pdServer) GetClusterCapabilities(ctx context.Context, msg *pb.GetClusterCapabilitiesRequest) (*pb.GetClusterCapabilitiesResponse, error) {
+	// Only the clusters the user can reach are returned.
+	userClient, err := pd.clustersManager.GetImpersonatedClient(ctx, auth.Principal(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error getting impersonating client: %w", err)
+	}
+
+	// The Deployment of Flagger is looked up with the permissions of the
+	// server, like CRDs, users may not have access to its namespace.
+	clusterClient, err := pd.clustersManager.GetServerClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting server client: %w", err)
+	}
+
+	reachable := userClient.ClientsPool().Clients()
+	clusters := []*pb.ClusterCapabilities{}
+
+	for _, cl := range pd.clustersManager.GetClusters() {
+		clusterName := cl.GetName()
+
+		if msg.ClusterName != "" && msg.ClusterName != clusterName {
+			continue
+		}
+
+		if _, ok := reachable[clusterName]; !ok {
+			continue
+		}
+
+		capabilities := &pb.ClusterCapabilities{
+			ClusterName:  clusterName,
+			Flagger:      pd.installedAPI(clusterName, crd.FlaggerCRDName),
+			GatewayApi:   pd.installedAPI(clusterName, crd.GatewayAPICRDName),
+			ArgoRollouts: pd.installedAPI(clusterName, crd.ArgoRolloutsCRDName),
+		}
+
+		if capabilities.Flagger.Installed {
+			capabilities.MeshProviders = flagger.AvailableProviders(pd.crd, clusterName)
+		}
+
+		for _, controller := range flux.InstalledControllers(pd.crd, clusterName) {
+			capabilities.FluxControllers = append(capabilities.FluxControllers, &pb.FluxController{
+				Name:  controller.Name,
+				Kinds: controller.Kinds,
+			})
+		}
+
+		clusters = append(clusters, capabilities)
+	}
+
+	pd.setFlaggerVersions(ctx, clusterClient, clusters)
+
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].ClusterName < clusters[j].ClusterName
+	})
+
+	return &pb.GetClusterCapabilitiesResponse{Clusters: clusters}, nil
+}
+
+// setFlaggerVersions looks up the version of Flagger of the clusters it is
+// installed on concurrently, so an unresponsive cluster only delays the
+// response up to enrichTimeout. The version is left empty if not found.
+func (pd *pdServer) setFlaggerVersions(ctx context.Context, clusterClient clustersmngr.Client, clusters []*pb.ClusterCapabilities) {
+	ctx, cancel := context.WithTimeout(ctx, enrichTimeout)
+	defer cancel()
+
+	var (
+		wg        sync.WaitGroup
+		semaphore = make(chan struct{}, enrichConcurrency)
+	)
+
+	for _, capabilities := range clusters {
+		if !capabilities.Flagger.Installed {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(capabilities *pb.ClusterCapabilities) {
+			defer wg.Done()
+
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				pd.logger.Error(ctx.Err(), "unable to find flagger version", "cluster", capabilities.ClusterName)
+				return
+			}
+
+			version, err := pd.flagger.FlaggerVersion(ctx, capabilities.ClusterName, clusterClient)
+			if err != nil {
+				pd.logger.Error(err, "unable to find flagger version", "cluster", capabilities.ClusterName)
+				return
+			}
+
+			capabilities.FlaggerVersion = version
+		}(capabilities)
+	}
+
+	wg.Wait()
+}
+
+func (pd *pdServer) installedAPI(clusterName, name string) *pb.InstalledAPI {
+	definition, found := pd.crd.Get(clusterName, name)
+	if !found {
+		return &pb.InstalledAPI{}
+	}
+
+	return &pb.InstalledAPI{Installed: true, Versions: crd.ServedVersions(definition)}
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster/clusterfakes"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/clustersmngrfakes"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// flaggerCRDs serves the Flagger CRD as installed on every cluster.
+type flaggerCRDs struct {
+	crd.Fetcher
+}
+
+func (flaggerCRDs) IsAvailable(_, name string) bool {
+	return name == crd.FlaggerCRDName
+}
+
+func (flaggerCRDs) Get(_, name string) (apiextensionsv1.CustomResourceDefinition, bool) {
+	if name != crd.FlaggerCRDName {
+		return apiextensionsv1.CustomResourceDefinition{}, false
+	}
+
+	return apiextensionsv1.CustomResourceDefinition{
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1beta1", Served: true}},
+		},
+	}, true
+}
+
+func TestGetClusterCapabilities_Reachable(t *testing.T) {
+	labels := map[string]string{"app.kubernetes.io/name": "flagger"}
+	c := fake.NewClientBuilder().WithScheme(kube.CreateScheme()).WithObjects(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "flagger", Namespace: "flagger-system", Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "flagger", Image: "ghcr.io/fluxcd/flagger:1.30.0"}}},
+			},
+		},
+	}).Build()
+
+	clusters := []cluster.Cluster{}
+	for _, name := range []string{"Default", "Staging", "Production"} {
+		cl := &clusterfakes.FakeCluster{}
+		cl.GetNameReturns(name)
+		clusters = append(clusters, cl)
+	}
+
+	serverPool := &clustersmngrfakes.FakeClientsPool{}
+	serverPool.ClientReturns(c, nil)
+
+	// The user can't reach the production cluster.
+	userPool := &clustersmngrfakes.FakeClientsPool{}
+	userPool.ClientsReturns(map[string]client.Client{"Default": c, "Staging": c})
+
+	clustersManager := &clustersmngrfakes.FakeClustersManager{}
+	clustersManager.GetClustersReturns(clusters)
+	clustersManager.GetServerClientReturns(clustersmngr.NewClient(serverPool, nil, logr.Discard()), nil)
+	clustersManager.GetImpersonatedClientReturns(clustersmngr.NewClient(userPool, nil, logr.Discard()), nil)
+
+	pd := &pdServer{
+		clustersManager: clustersManager,
+		crd:             flaggerCRDs{},
+		flagger:         flagger.NewFetcher(flaggerCRDs{}, logr.Discard()),
+		logger:          logr.Discard(),
+	}
+
+	response, err := pd.GetClusterCapabilities(context.Background(), &pb.GetClusterCapabilitiesRequest{})
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, capabilities := range response.GetClusters() {
+		names = append(names, capabilities.ClusterName)
+		assert.Equal(t, "1.30.0", capabilities.FlaggerVersion, "cluster %s", capabilities.ClusterName)
+	}
+
+	assert.Equal(t, []string{"Default", "Staging"}, names)
+
+	response, err = pd.GetClusterCapabilities(context.Background(), &pb.GetClusterCapabilitiesRequest{ClusterName: "Production"})
+	require.NoError(t, err)
+	assert.Empty(t, response.GetClusters())
+}
@@ -0,0 +1,73 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
+	api "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestGetClusterCapabilities(t *testing.T) {
+	ctx := context.Background()
+	c := pdtesting.MakeGRPCServer(t, k8sEnv.Rest, k8sEnv)
+
+	k, err := client.New(k8sEnv.Rest, client.Options{
+		Scheme: kube.CreateScheme(),
+	})
+	require.NoError(t, err)
+
+	ns := pdtesting.NewNamespace(ctx, t, k)
+
+	labels := map[string]string{"app.kubernetes.io/name": "flagger"}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "flagger", Namespace: ns.Name, Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "flagger", Image: "ghcr.io/fluxcd/flagger:1.30.0"}},
+				},
+			},
+		},
+	}
+	require.NoError(t, k.Create(ctx, deployment))
+	defer pdtesting.Cleanup(ctx, t, k, deployment)
+
+	response, err := c.GetClusterCapabilities(ctx, &api.GetClusterCapabilitiesRequest{})
+	require.NoError(t, err)
+	require.Len(t, response.GetClusters(), 1)
+
+	capabilities := response.GetClusters()[0]
+	assert.Equal(t, "Default", capabilities.ClusterName)
+	assert.True(t, capabilities.GetFlagger().GetInstalled())
+	assert.Equal(t, []string{"v1beta1"}, capabilities.GetFlagger().GetVersions())
+	assert.Equal(t, "1.30.0", capabilities.FlaggerVersion)
+	assert.Contains(t, capabilities.MeshProviders, "linkerd")
+	assert.Contains(t, capabilities.MeshProviders, "kubernetes")
+	assert.NotContains(t, capabilities.MeshProviders, "istio")
+	assert.False(t, capabilities.GetGatewayApi().GetInstalled())
+	assert.True(t, capabilities.GetArgoRollouts().GetInstalled())
+
+	controllers := map[string][]string{}
+	for _, controller := range capabilities.FluxControllers {
+		controllers[controller.Name] = controller.Kinds
+	}
+
+	assert.Equal(t, []string{"Kustomization"}, controllers["kustomize-controller"])
+	assert.Equal(t, []string{"HelmRelease"}, controllers["helm-controller"])
+	assert.Contains(t, controllers["source-controller"], "GitRepository")
+	assert.NotContains(t, controllers, "notification-controller")
+
+	response, err = c.GetClusterCapabilities(ctx, &api.GetClusterCapabilitiesRequest{ClusterName: "Other"})
+	require.NoError(t, err)
+	assert.Empty(t, response.GetClusters())
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+func (pd *pdServer) ListCanaryEvents(ctx context.Context, msg *pb.ListCanaryEventsRequest) (*pb.ListCanaryEventsResponse, error) {
+	clusterClient, err := pd.clustersManager.GetImpersonatedClient(ctx, auth.Principal(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error getting impersonated client: %w", err)
+	}
+
+	events, err := pd.flagger.ListCanaryEvents(ctx, clusterClient, flagger.ListCanaryEventsOptions{
+		Name:        msg.Name,
+		Namespace:   msg.Namespace,
+		ClusterName: msg.ClusterName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed listing canary events: %w", err)
+	}
+
+	response := &pb.ListCanaryEventsResponse{
+		Events: []*pb.CanaryEvent{},
+	}
+
+	for _, item := range events {
+		response.Events = append(response.Events, &pb.CanaryEvent{
+			Kind:         string(item.Kind),
+			Type:         item.Event.Type,
+			Reason:       item.Event.Reason,
+			Message:      item.Event.Message,
+			Timestamp:    flagger.EventTime(item.Event).Format(time.RFC3339),
+			Count:        item.Event.Count,
+			ObjectKind:   item.Event.InvolvedObject.Kind,
+			ObjectName:   item.Event.InvolvedObject.Name,
+			CanaryWeight: item.CanaryWeight,
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"sort"
+	"time"
+
+	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/services/delivery"
+)
+
+func canaryFilterFor(msg *pb.ListCanariesRequest) delivery.Filter {
+	return delivery.Filter{
+		ClusterName:   msg.ClusterName,
+		Namespace:     msg.Namespace,
+		Phases:        msg.Phases,
+		Providers:     msg.Providers,
+		Strategies:    msg.Strategies,
+		LabelSelector: msg.LabelSelector,
+		NameContains:  msg.NameContains,
+	}
+}
+
+// sortCanaries orders the canaries of every cluster and controller together,
+// services only sort the results of each cluster. Requests are not paginated
+// when they're sorted, so these are all the canaries.
+func sortCanaries(canaries []*pb.Canary, opts delivery.SortOptions) {
+	if opts.By == "" {
+		return
+	}
+
+	sort.SliceStable(canaries, func(i, j int) bool {
+		return opts.Less(canaryAttributes(canaries[i]), canaryAttributes(canaries[j]))
+	})
+}
+
+func canaryAttributes(canary *pb.Canary) delivery.Attributes {
+	attrs := delivery.Attributes{
+		ClusterName: canary.GetClusterName(),
+		Namespace:   canary.GetNamespace(),
+		Name:        canary.GetName(),
+		Phase:       canary.GetStatus().GetPhase(),
+		Provider:    canary.GetProvider(),
+		Strategy:    delivery.DeploymentStrategy(canary.GetDeploymentStrategy()),
+	}
+
+	// Left zero if it can't be parsed, those canaries are sorted first.
+	attrs.LastTransitionTime, _ = time.Parse(time.RFC3339, canary.GetStatus().GetLastTransitionTime())
+
+	return attrs
+}
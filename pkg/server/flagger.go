@@ -3,15 +3,29 @@ package server
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
 
 	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
 	"github.com/weaveworks/progressive-delivery/pkg/convert"
+	"github.com/weaveworks/progressive-delivery/pkg/models"
+	"github.com/weaveworks/progressive-delivery/pkg/services/argo"
 	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+	"github.com/weaveworks/progressive-delivery/pkg/services/delivery"
 	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flux"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
 	"github.com/weaveworks/weave-gitops/pkg/server/auth"
-	v1 "k8s.io/api/apps/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// enrichConcurrency and enrichTimeout bound the lists of the objects of the
+// listed Canaries and Rollouts, per cluster and per request.
+const (
+	enrichConcurrency = 4
+	enrichTimeout     = 10 * time.Second
 )
 
 const (
@@ -33,79 +47,86 @@ func (pd *pdServer) ListCanaries(ctx context.Context, msg *pb.ListCanariesReques
 		return nil, fmt.Errorf("error getting impersonated client: %w", err)
 	}
 
-	opts := flagger.ListCanaryDeploymentsOptions{}
+	token := pageToken{Controller: pd.sources[0].Controller()}
+	opts := delivery.ListOptions{
+		Filter: canaryFilterFor(msg),
+		Sort: delivery.SortOptions{
+			By:         delivery.SortField(msg.SortBy),
+			Descending: msg.SortDescending,
+		},
+	}
 	if msg.Pagination != nil {
+		if token, err = decodePageToken(msg.Pagination.PageToken, pd.controllers()); err != nil {
+			return nil, err
+		}
+
 		opts.PageSize = msg.Pagination.PageSize
-		opts.PageToken = msg.Pagination.PageToken
 	}
 
-	results, nextPageToken, listErr, err := pd.flagger.ListCanaryDeployments(
-		ctx,
-		clusterClient,
-		opts,
-	)
-	if err != nil {
-		return nil, err
+	if opts.Sort.By != "" && opts.PageSize > 0 {
+		// Pages follow the order of the clusters and controllers, only the
+		// canaries of each page could be sorted.
+		return nil, status.Error(codes.InvalidArgument, "sort_by can't be combined with a page size")
 	}
 
 	response := &pb.ListCanariesResponse{
-		Canaries:      []*pb.Canary{},
-		NextPageToken: nextPageToken,
-		Errors:        []*pb.ListError{},
+		Canaries: []*pb.Canary{},
+		Errors:   []*pb.ListError{},
 	}
 
-	for _, err := range listErr {
-		response.Errors = append(response.Errors, &pb.ListError{
-			ClusterName: err.ClusterName,
-			Namespace:   "",
-			Message:     err.Error(),
-		})
-	}
+	listing := false
 
-	for clusterName, canaries := range results {
-		for _, canary := range canaries {
-			// Ignored intentioannly. The function returns with an error, but here we
-			// don't care about it, if it's not found, we can return to the client
-			// with an empty deployment.
-			deployment, _ := pd.flagger.FetchTargetRef(ctx, clusterName, clusterClient, &canary)
-
-			promoted, _ := pd.flagger.FetchPromoted(ctx, clusterName, clusterClient, &canary)
-
-			containers := promoted.Spec.Template.Spec.Containers
-
-			canaryMetricTemplates := []v1beta1.MetricTemplate{}
-			for _, canaryMetric := range canary.GetAnalysis().Metrics {
-				if canaryMetric.TemplateRef != nil {
-					template, err := pd.flagger.GetMetricTemplate(
-						ctx,
-						msg.ClusterName,
-						clusterClient,
-						canaryMetric.TemplateRef.Name,
-						canaryMetric.TemplateRef.Namespace,
-					)
-					if err != nil {
-						pd.logger.Error(err, "unable to fetch metric template from reference")
-						continue
-					}
-					canaryMetricTemplates = append(
-						canaryMetricTemplates,
-						template,
-					)
-				}
-			}
+	for _, source := range pd.sources {
+		// Sources before the one of the token were listed in previous pages.
+		if !listing && source.Controller() != token.Controller {
+			continue
+		}
+
+		sourceOpts := opts
+		sourceOpts.PageToken = ""
+
+		if !listing {
+			sourceOpts.PageToken = token.Token
+			listing = true
+		}
+
+		if !source.IsInstalled() {
+			continue
+		}
+
+		sourceOpts.PageSize = remainingPageSize(opts.PageSize, len(response.Canaries))
+		if opts.PageSize > 0 && sourceOpts.PageSize == 0 {
+			// The page is full, the source is listed from the next one.
+			response.NextPageToken = pageToken{Controller: source.Controller()}.String()
+
+			break
+		}
+
+		canaries, nextPageToken, listErr, err := source.List(ctx, clusterClient, sourceOpts)
+		if err != nil {
+			return nil, err
+		}
 
-			pbObject := convert.FlaggerCanaryToProto(canary, clusterName, deployment, containers, canaryMetricTemplates)
+		response.Canaries = append(response.Canaries, canaries...)
+		response.Errors = append(response.Errors, listErr...)
 
-			pbObject.DeploymentStrategy = string(pd.flagger.DeploymentStrategyFor(canary))
+		if nextPageToken != "" {
+			response.NextPageToken = pageToken{Controller: source.Controller(), Token: nextPageToken}.String()
 
-			response.Canaries = append(response.Canaries, pbObject)
+			break
 		}
 	}
 
+	sortCanaries(response.Canaries, opts.Sort)
+
 	return response, nil
 }
 
 func (pd *pdServer) GetCanary(ctx context.Context, msg *pb.GetCanaryRequest) (*pb.GetCanaryResponse, error) {
+	if msg.Controller == models.ArgoRolloutsController {
+		return pd.getRollout(ctx, msg)
+	}
+
 	clusterClient, err := pd.clustersManager.GetImpersonatedClient(ctx, auth.Principal(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("error getting impersonated client: %w", err)
@@ -120,7 +141,7 @@ func (pd *pdServer) GetCanary(ctx context.Context, msg *pb.GetCanaryRequest) (*p
 		return nil, fmt.Errorf("getting canary: %w", err)
 	}
 
-	deployment, err := pd.flagger.FetchTargetRef(ctx, msg.ClusterName, clusterClient, canary)
+	target, err := pd.flagger.FetchTargetRef(ctx, msg.ClusterName, clusterClient, canary)
 	if err != nil {
 		return nil, fmt.Errorf("fetching target ref: %w", err)
 	}
@@ -130,36 +151,24 @@ func (pd *pdServer) GetCanary(ctx context.Context, msg *pb.GetCanaryRequest) (*p
 		return nil, fmt.Errorf("fetching target ref: %w", err)
 	}
 
-	containers := promoted.Spec.Template.Spec.Containers
-
 	canaryMetricTemplates := []v1beta1.MetricTemplate{}
-	for _, item := range canary.GetAnalysis().Metrics {
-		if item.TemplateRef != nil {
-			template, err := pd.flagger.GetMetricTemplate(
-				ctx,
-				msg.ClusterName,
-				clusterClient,
-				item.TemplateRef.Name,
-				item.TemplateRef.Namespace,
-			)
-			if err != nil {
-				pd.logger.Error(err, "unable to fetch metric template from reference")
-				continue
-			}
-			canaryMetricTemplates = append(
-				canaryMetricTemplates,
-				template,
-			)
+	for _, ref := range flagger.MetricTemplateRefs(*canary) {
+		template, err := pd.flagger.GetMetricTemplate(ctx, msg.ClusterName, clusterClient, ref.Name, ref.Namespace)
+		if err != nil {
+			pd.logger.Error(err, "unable to fetch metric template from reference")
+			continue
 		}
+
+		canaryMetricTemplates = append(canaryMetricTemplates, template)
 	}
 
-	pbObject := convert.FlaggerCanaryToProto(*canary, msg.ClusterName, deployment, containers, canaryMetricTemplates)
+	pbObject := convert.FlaggerCanaryToProto(*canary, msg.ClusterName, target, promoted, canaryMetricTemplates)
 
 	pbObject.DeploymentStrategy = string(pd.flagger.DeploymentStrategyFor(*canary))
 
 	response := &pb.GetCanaryResponse{
 		Canary:     pbObject,
-		Automation: getAutomation(deployment),
+		Automation: pd.automationFor(ctx, msg.ClusterName, clusterClient, target.Labels),
 	}
 
 	return response, nil
@@ -171,59 +180,162 @@ func (pd *pdServer) ListMetricTemplates(ctx context.Context, msg *pb.ListMetricT
 		return nil, fmt.Errorf("error getting impersonated client: %w", err)
 	}
 
-	opts := flagger.ListMetricTemplatesOptions{}
+	token := pageToken{Controller: models.FlaggerController}
+	opts := flagger.ListMetricTemplatesOptions{
+		ClusterName: msg.ClusterName,
+	}
 	if msg.Pagination != nil {
+		if token, err = decodePageToken(msg.Pagination.PageToken, []string{models.FlaggerController, models.ArgoRolloutsController}); err != nil {
+			return nil, err
+		}
+
 		opts.PageSize = msg.Pagination.PageSize
-		opts.PageToken = msg.Pagination.PageToken
 	}
 
-	results, nextPageToken, listErr, err := pd.flagger.ListMetricTemplates(
-		ctx,
-		clusterClient,
-		opts,
-	)
-	if err != nil {
-		return nil, err
+	response := &pb.ListMetricTemplatesResponse{
+		Templates: []*pb.CanaryMetricTemplate{},
+		Errors:    []*pb.ListError{},
 	}
 
-	response := &pb.ListMetricTemplatesResponse{
-		Templates:     []*pb.CanaryMetricTemplate{},
-		NextPageToken: nextPageToken,
-		Errors:        []*pb.ListError{},
+	if token.Controller == models.FlaggerController {
+		opts.PageToken = token.Token
+
+		results, nextPageToken, listErr, err := pd.flagger.ListMetricTemplates(
+			ctx,
+			clusterClient,
+			opts,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, err := range listErr {
+			response.Errors = append(response.Errors, &pb.ListError{
+				ClusterName: err.ClusterName,
+				Namespace:   "",
+				Message:     err.Error(),
+			})
+		}
+
+		for clusterName, list := range results {
+			for _, item := range list {
+				pbObject := convert.FlaggerMetricTemplateToProto(item, clusterName)
+				response.Templates = append(response.Templates, pbObject)
+			}
+		}
+
+		if nextPageToken != "" {
+			response.NextPageToken = pageToken{Controller: models.FlaggerController, Token: nextPageToken}.String()
+		}
+
+		token = pageToken{Controller: models.ArgoRolloutsController}
 	}
 
-	for _, err := range listErr {
-		response.Errors = append(response.Errors, &pb.ListError{
-			ClusterName: err.ClusterName,
-			Namespace:   "",
-			Message:     err.Error(),
+	pageSize := remainingPageSize(opts.PageSize, len(response.Templates))
+
+	switch {
+	case response.NextPageToken != "", !pd.isArgoRolloutsInstalled():
+	case opts.PageSize > 0 && pageSize == 0:
+		// The page is full, analysis templates are listed from the next one.
+		response.NextPageToken = token.String()
+	default:
+		templates, nextPageToken, templateErr, err := pd.argo.ListAnalysisTemplates(ctx, clusterClient, argo.ListAnalysisTemplatesOptions{
+			ClusterName: msg.ClusterName,
+			PageSize:    pageSize,
+			PageToken:   token.Token,
 		})
-	}
+		if err != nil {
+			return nil, err
+		}
 
-	for clusterName, list := range results {
-		for _, item := range list {
-			pbObject := convert.FlaggerMetricTemplateToProto(item, clusterName)
-			response.Templates = append(response.Templates, pbObject)
+		for _, err := range templateErr {
+			response.Errors = append(response.Errors, &pb.ListError{
+				ClusterName: err.ClusterName,
+				Namespace:   "",
+				Message:     err.Error(),
+			})
+		}
+
+		for clusterName, list := range templates {
+			for _, item := range list {
+				response.Templates = append(response.Templates, convert.ArgoAnalysisTemplateToProto(item, clusterName)...)
+			}
+		}
+
+		if nextPageToken != "" {
+			response.NextPageToken = pageToken{Controller: models.ArgoRolloutsController, Token: nextPageToken}.String()
 		}
 	}
 
 	return response, nil
 }
 
-func getAutomation(dpl v1.Deployment) *pb.Automation {
-	for k, v := range dpl.Labels {
+// canaryToProto converts a Canary with its target and primary workloads and
+// referenced MetricTemplates. Missing related objects are ignored, the client
+// gets an empty workload in that case.
+func (pd *pdServer) canaryToProto(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, canary v1beta1.Canary) *pb.Canary {
+	objects := flagger.CanaryObjects{MetricTemplates: []v1beta1.MetricTemplate{}}
+
+	objects.Target, _ = pd.flagger.FetchTargetRef(ctx, clusterName, clusterClient, &canary)
+
+	objects.Promoted, _ = pd.flagger.FetchPromoted(ctx, clusterName, clusterClient, &canary)
+
+	for _, ref := range flagger.MetricTemplateRefs(canary) {
+		template, err := pd.flagger.GetMetricTemplate(ctx, clusterName, clusterClient, ref.Name, ref.Namespace)
+		if err != nil {
+			pd.logger.Error(err, "unable to fetch metric template from reference")
+			continue
+		}
+
+		objects.MetricTemplates = append(objects.MetricTemplates, template)
+	}
+
+	return pd.canaryObjectsToProto(clusterName, canary, objects)
+}
+
+func (pd *pdServer) canaryObjectsToProto(clusterName string, canary v1beta1.Canary, objects flagger.CanaryObjects) *pb.Canary {
+	pbObject := convert.FlaggerCanaryToProto(canary, clusterName, objects.Target, objects.Promoted, objects.MetricTemplates)
+
+	pbObject.DeploymentStrategy = string(pd.flagger.DeploymentStrategyFor(canary))
+
+	return pbObject
+}
+
+// automationFor returns the Flux object applying a workload, found from its
+// labels. Only the reference is returned if the object can't be read.
+func (pd *pdServer) automationFor(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, labels map[string]string) *pb.Automation {
+	automation := automationFromLabels(labels)
+	if automation == nil {
+		return nil
+	}
+
+	resolved, err := pd.flux.GetAutomation(ctx, clusterName, clusterClient, flux.AutomationReference{
+		Kind:      automation.Kind,
+		Name:      automation.Name,
+		Namespace: automation.Namespace,
+	})
+	if err != nil {
+		pd.logger.Error(err, "unable to fetch automation", "kind", automation.Kind, "name", automation.Name, "namespace", automation.Namespace)
+		return automation
+	}
+
+	return convert.FluxAutomationToProto(*resolved)
+}
+
+func automationFromLabels(labels map[string]string) *pb.Automation {
+	for k, v := range labels {
 		switch k {
 		case LabelKustomizeName:
 			return &pb.Automation{
 				Kind:      "Kustomization",
 				Name:      v,
-				Namespace: dpl.Labels[LabelKustomizeNamespace],
+				Namespace: labels[LabelKustomizeNamespace],
 			}
 		case LabelHelmReleaseName:
 			return &pb.Automation{
 				Kind:      "HelmRelease",
 				Name:      v,
-				Namespace: dpl.Labels[LabelHelmReleaseNamespace],
+				Namespace: labels[LabelHelmReleaseNamespace],
 			}
 		}
 	}
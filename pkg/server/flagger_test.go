@@ -12,7 +12,9 @@ import (
 	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
 	api "github.com/weaveworks/progressive-delivery/pkg/api/prog"
 	"github.com/weaveworks/progressive-delivery/pkg/kube"
-	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"github.com/weaveworks/progressive-delivery/pkg/services/delivery"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	hpav2 "k8s.io/api/autoscaling/v2beta1"
 	netv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -48,7 +50,7 @@ func TestListCanaries(t *testing.T) {
 	assert.Len(t, response.GetCanaries(), 1, "should return one canary object")
 	assert.Empty(t, response.GetErrors(), "should not return with errors")
 	assert.Equal(t,
-		string(flagger.BlueGreenDeploymentStrategy),
+		string(delivery.BlueGreenDeploymentStrategy),
 		response.GetCanaries()[0].GetDeploymentStrategy(),
 	)
 
@@ -58,6 +60,12 @@ func TestListCanaries(t *testing.T) {
 	assert.Equal(t, expectedImages, response.Canaries[0].TargetDeployment.AppliedImageVersions)
 
 	assert.Empty(t, response.Canaries[0].TargetDeployment.PromotedImageVersions)
+
+	assert.Equal(t, "Deployment", response.Canaries[0].TargetWorkload.Kind)
+	assert.Equal(t, expectedImages, response.Canaries[0].TargetWorkload.AppliedImageVersions)
+
+	_, err = c.ListCanaries(ctx, &api.ListCanariesRequest{SortBy: "name", Pagination: &api.Pagination{PageSize: 10}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "sorted requests can't be paginated")
 }
 
 func TestListCanaries_NoDeployment(t *testing.T) {
@@ -125,6 +133,8 @@ func TestListCanaries_Promoted(t *testing.T) {
 		"nginx": "nginx",
 	}
 	assert.Equal(t, expectedPromoted, response.Canaries[0].TargetDeployment.PromotedImageVersions)
+
+	assert.Empty(t, response.Canaries[0].TargetWorkload.ContainerDiffs, "identical containers should not differ")
 }
 
 func TestGetCanary(t *testing.T) {
@@ -213,7 +223,7 @@ func TestGetCanary(t *testing.T) {
 	assert.Equal(t, appName, response.GetAutomation().GetName())
 	assert.Equal(t, ns.Name, response.GetAutomation().GetNamespace())
 	assert.Equal(t,
-		string(flagger.BlueGreenDeploymentStrategy),
+		string(delivery.BlueGreenDeploymentStrategy),
 		response.GetCanary().GetDeploymentStrategy(),
 	)
 	assert.True(t, len(response.GetCanary().GetAnalysis().Metrics) == 4)
@@ -296,6 +306,20 @@ func TestListCanaryObjects(t *testing.T) {
 		require.Equal(t, listObjects.GetObjects()[4].GroupVersionKind.Kind, "TrafficSplit")
 		require.Equal(t, listObjects.GetObjects()[4].Name, appName)
 	})
+
+	t.Run("returns the object tree", func(t *testing.T) {
+		listObjects, err := c.ListCanaryObjects(ctx, &api.ListCanaryObjectsRequest{ClusterName: "Default", Name: canary.Name, Namespace: canary.Namespace})
+		require.NoError(t, err)
+
+		tree := listObjects.GetTree()
+		require.Equal(t, "Canary", tree.GroupVersionKind.Kind)
+		require.Len(t, tree.GetChildren(), len(listObjects.GetObjects()))
+		require.Equal(t, appName, tree.GetChildren()[0].Name)
+
+		for _, object := range listObjects.GetObjects() {
+			require.Empty(t, object.GetChildren(), "flat objects should not repeat the tree")
+		}
+	})
 }
 
 func updateOwnerReferences(t *testing.T, k client.Client, obj client.Object, canary v1beta1.Canary) {
@@ -447,3 +471,36 @@ func newTrafficSplit(ctx context.Context, t *testing.T, k client.Client, name st
 
 	return ts
 }
+
+func TestPromoteCanary(t *testing.T) {
+	ctx := context.Background()
+	c := pdtesting.MakeGRPCServer(t, k8sEnv.Rest, k8sEnv)
+
+	k, err := client.New(k8sEnv.Rest, client.Options{
+		Scheme: kube.CreateScheme(),
+	})
+	require.NoError(t, err)
+
+	appName := "promote"
+
+	ns := pdtesting.NewNamespace(ctx, t, k)
+	_ = pdtesting.NewDeployment(ctx, t, k, appName, ns.Name)
+
+	canary := pdtesting.NewCanary(ctx, t, k, pdtesting.CanaryInfo{
+		Name:      appName,
+		Namespace: ns.GetName(),
+	})
+	defer cleanup(ctx, t, k, &canary)
+
+	response, err := c.PromoteCanary(ctx, &api.PromoteCanaryRequest{ClusterName: "Default", Name: canary.Name, Namespace: canary.Namespace})
+	require.NoError(t, err)
+
+	assert.Equal(t, canary.Name, response.GetCanary().GetName())
+	assert.Equal(t,
+		string(delivery.NoAnalysisDeploymentStrategy),
+		response.GetCanary().GetDeploymentStrategy(),
+	)
+
+	_, err = c.PauseCanary(ctx, &api.PauseCanaryRequest{ClusterName: "Default", Name: canary.Name, Namespace: canary.Namespace})
+	assert.Error(t, err, "canary without gate webhook can't be paused")
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/services/history"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+var errHistoryDisabled = errors.New("rollout history is not enabled on this server")
+
+func (pd *pdServer) ListCanaryRevisions(ctx context.Context, msg *pb.ListCanaryRevisionsRequest) (*pb.ListCanaryRevisionsResponse, error) {
+	if pd.history == nil {
+		return nil, errHistoryDisabled
+	}
+
+	clusterClient, err := pd.clustersManager.GetImpersonatedClient(ctx, auth.Principal(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error getting impersonated client: %w", err)
+	}
+
+	opts := history.ListOptions{
+		ClusterName: msg.ClusterName,
+		Namespace:   msg.Namespace,
+		Name:        msg.Name,
+	}
+
+	if opts.Start, err = parseOptionalTime(msg.StartTime); err != nil {
+		return nil, fmt.Errorf("invalid start time: %w", err)
+	}
+
+	if opts.End, err = parseOptionalTime(msg.EndTime); err != nil {
+		return nil, fmt.Errorf("invalid end time: %w", err)
+	}
+
+	revisions, err := pd.history.List(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing canary revisions: %w", err)
+	}
+
+	// Revisions are recorded with the permissions of the server, only return
+	// the ones from namespaces the user has access to.
+	accessible := map[string]map[string]bool{}
+	for clusterName, namespaces := range clusterClient.Namespaces() {
+		accessible[clusterName] = map[string]bool{}
+
+		for _, ns := range namespaces {
+			accessible[clusterName][ns.Name] = true
+		}
+	}
+
+	response := &pb.ListCanaryRevisionsResponse{
+		Revisions: []*pb.CanaryRevision{},
+	}
+
+	for _, revision := range revisions {
+		if !accessible[revision.ClusterName][revision.Namespace] {
+			continue
+		}
+
+		response.Revisions = append(response.Revisions, &pb.CanaryRevision{
+			ClusterName:           revision.ClusterName,
+			Namespace:             revision.Namespace,
+			Name:                  revision.Name,
+			Phase:                 revision.Phase,
+			PreviousPhase:         revision.PreviousPhase,
+			Timestamp:             revision.Timestamp.Format(time.RFC3339),
+			CanaryWeight:          revision.CanaryWeight,
+			FailedChecks:          revision.FailedChecks,
+			Iterations:            revision.Iterations,
+			DeploymentStrategy:    revision.DeploymentStrategy,
+			AppliedImageVersions:  revision.AppliedImageVersions,
+			PromotedImageVersions: revision.PromotedImageVersions,
+		})
+	}
+
+	return response, nil
+}
+
+func parseOptionalTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
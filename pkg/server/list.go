@@ -8,6 +8,9 @@ import (
 	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
 	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
 	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/kustomize/kstatus/status"
 )
 
@@ -18,7 +21,7 @@ func (pd *pdServer) ListCanaryObjects(ctx context.Context, msg *pb.ListCanaryObj
 	}
 
 	respErrors := []*pb.ListError{}
-	result, err := pd.flagger.ListCanaryObjects(ctx, clusterClient, flagger.ListCanaryObjectsOptions{
+	tree, err := pd.flagger.ListCanaryObjectTree(ctx, clusterClient, flagger.ListCanaryObjectsOptions{
 		Name:        msg.Name,
 		Namespace:   msg.Namespace,
 		ClusterName: msg.ClusterName,
@@ -35,34 +38,82 @@ func (pd *pdServer) ListCanaryObjects(ctx context.Context, msg *pb.ListCanaryObj
 
 	objects := []*pb.UnstructuredObject{}
 
-	for _, obj := range result {
-		res, err := status.Compute(&obj)
+	for _, node := range tree.Children {
+		object, err := unstructuredToProto(msg.GetClusterName(), node.Object)
 		if err != nil {
-			return nil, fmt.Errorf("could not get status for %s: %w", obj.GetName(), err)
+			return nil, err
 		}
 
-		var images []string
+		objects = append(objects, object)
+	}
+
+	pbTree, err := objectNodeToProto(msg.GetClusterName(), tree)
+	if err != nil {
+		return nil, err
+	}
 
-		switch obj.GetKind() {
-		case "Deployment":
-			images = getDeploymentPodContainerImages(obj.Object)
+	return &pb.ListCanaryObjectsResponse{Objects: objects, Errors: respErrors, Tree: pbTree}, nil
+}
+
+func objectNodeToProto(clusterName string, node *flagger.ObjectNode) (*pb.UnstructuredObject, error) {
+	object, err := unstructuredToProto(clusterName, node.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range node.Children {
+		pbChild, err := objectNodeToProto(clusterName, child)
+		if err != nil {
+			return nil, err
 		}
 
-		objects = append(objects, &pb.UnstructuredObject{
-			GroupVersionKind: &pb.GroupVersionKind{
-				Group:   obj.GetObjectKind().GroupVersionKind().Group,
-				Version: obj.GetObjectKind().GroupVersionKind().GroupVersion().Version,
-				Kind:    obj.GetKind(),
-			},
-			Name:        obj.GetName(),
-			Namespace:   obj.GetNamespace(),
-			Images:      images,
-			Status:      res.Status.String(),
-			Uid:         string(obj.GetUID()),
-			Conditions:  mapUnstructuredConditions(res),
-			ClusterName: msg.GetClusterName(),
-		})
+		object.Children = append(object.Children, pbChild)
+	}
+
+	return object, nil
+}
+
+func unstructuredToProto(clusterName string, obj unstructured.Unstructured) (*pb.UnstructuredObject, error) {
+	res, err := status.Compute(&obj)
+	if err != nil {
+		return nil, fmt.Errorf("could not get status for %s: %w", obj.GetName(), err)
+	}
+
+	var images []string
+
+	switch obj.GetKind() {
+	case "Deployment", "DaemonSet", "ReplicaSet":
+		images = getDeploymentPodContainerImages(obj.Object)
+	case "Pod":
+		images = getPodContainerImages(obj.Object)
+	}
+
+	object := &pb.UnstructuredObject{
+		GroupVersionKind: &pb.GroupVersionKind{
+			Group:   obj.GetObjectKind().GroupVersionKind().Group,
+			Version: obj.GetObjectKind().GroupVersionKind().GroupVersion().Version,
+			Kind:    obj.GetKind(),
+		},
+		Name:        obj.GetName(),
+		Namespace:   obj.GetNamespace(),
+		Images:      images,
+		Status:      res.Status.String(),
+		Uid:         string(obj.GetUID()),
+		Conditions:  mapUnstructuredConditions(obj, res),
+		ClusterName: clusterName,
+	}
+
+	if obj.GetKind() == "Pod" {
+		pod := corev1.Pod{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &pod); err != nil {
+			return nil, fmt.Errorf("could not convert pod %s: %w", obj.GetName(), err)
+		}
+
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			object.Containers = append(object.Containers, containerStatusToProto(containerStatus))
+			object.Restarts += containerStatus.RestartCount
+		}
 	}
 
-	return &pb.ListCanaryObjectsResponse{Objects: objects, Errors: respErrors}, nil
+	return object, nil
 }
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"github.com/weaveworks/progressive-delivery/pkg/services/notify"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+var errNotificationsDisabled = errors.New("notifications are not enabled on this server")
+
+func (pd *pdServer) ListNotificationRules(ctx context.Context, msg *pb.ListNotificationRulesRequest) (*pb.ListNotificationRulesResponse, error) {
+	response := &pb.ListNotificationRulesResponse{
+		Rules: []*pb.NotificationRule{},
+	}
+
+	if pd.notifier == nil {
+		return response, nil
+	}
+
+	for _, rule := range pd.notifier.Rules() {
+		pbRule := &pb.NotificationRule{
+			Name:        rule.Name,
+			Receivers:   []*pb.NotificationReceiver{},
+			Transitions: []*pb.PhaseTransition{},
+			Clusters:    rule.Clusters,
+			Namespaces:  rule.Namespaces,
+			Selector:    rule.Selector,
+		}
+
+		for _, name := range rule.Receivers {
+			receiver, _ := pd.notifier.Receiver(name)
+			pbRule.Receivers = append(pbRule.Receivers, &pb.NotificationReceiver{Name: receiver.Name, Type: receiver.Type})
+		}
+
+		for _, transition := range rule.Transitions {
+			pbRule.Transitions = append(pbRule.Transitions, &pb.PhaseTransition{From: transition.From, To: transition.To})
+		}
+
+		response.Rules = append(response.Rules, pbRule)
+	}
+
+	return response, nil
+}
+
+func (pd *pdServer) TestNotification(ctx context.Context, msg *pb.TestNotificationRequest) (*pb.TestNotificationResponse, error) {
+	if pd.notifier == nil {
+		return nil, errNotificationsDisabled
+	}
+
+	clusterClient, err := pd.clustersManager.GetImpersonatedClient(ctx, auth.Principal(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error getting impersonated client: %w", err)
+	}
+
+	// Read with the client of the user, so only Canaries they can see are
+	// notified about.
+	canary, err := pd.flagger.GetCanary(ctx, clusterClient, flagger.GetCanaryOptions{
+		Name:        msg.Name,
+		Namespace:   msg.Namespace,
+		ClusterName: msg.ClusterName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting canary: %w", err)
+	}
+
+	phase := msg.Phase
+	if phase == "" {
+		phase = string(canary.Status.Phase)
+	}
+
+	results, err := pd.notifier.Test(ctx, msg.Rule, notify.Notification{
+		ClusterName:   msg.ClusterName,
+		Namespace:     canary.GetNamespace(),
+		Name:          canary.GetName(),
+		Labels:        canary.GetLabels(),
+		PreviousPhase: msg.PreviousPhase,
+		Phase:         phase,
+		CanaryWeight:  canary.Status.CanaryWeight,
+		FailedChecks:  canary.Status.FailedChecks,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.TestNotificationResponse{
+		Deliveries: []*pb.NotificationDelivery{},
+	}
+
+	for _, result := range results {
+		delivery := &pb.NotificationDelivery{Receiver: result.Receiver}
+		if result.Err != nil {
+			delivery.Error = result.Err.Error()
+		}
+
+		response.Deliveries = append(response.Deliveries, delivery)
+	}
+
+	return response, nil
+}
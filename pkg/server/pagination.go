@@ -0,0 +1,57 @@
+package server
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// pageToken points into the objects of one controller. Lists return the
+// objects of each controller in turn, a page may hold several.
+type pageToken struct {
+	Controller string `json:"controller"`
+	Token      string `json:"token,omitempty"`
+}
+
+// decodePageToken decodes a token of one of the controllers, listed in the
+// order their objects are returned. An empty token points to the first one.
+func decodePageToken(token string, controllers []string) (pageToken, error) {
+	result := pageToken{Controller: controllers[0]}
+
+	if token == "" {
+		return result, nil
+	}
+
+	buf, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return result, fmt.Errorf("invalid page token: %w", err)
+	}
+
+	if err := json.Unmarshal(buf, &result); err != nil {
+		return result, fmt.Errorf("invalid page token: %w", err)
+	}
+
+	for _, controller := range controllers {
+		if result.Controller == controller {
+			return result, nil
+		}
+	}
+
+	return result, fmt.Errorf("invalid page token: unknown controller %q", result.Controller)
+}
+
+func (t pageToken) String() string {
+	buf, _ := json.Marshal(t)
+
+	return base64.URLEncoding.EncodeToString(buf)
+}
+
+// remainingPageSize returns the page size left for the next controller, zero
+// meaning no limit when the request isn't paginated.
+func remainingPageSize(pageSize int32, listed int) int32 {
+	if pageSize <= 0 {
+		return 0
+	}
+
+	return pageSize - int32(listed)
+}
@@ -2,23 +2,41 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 
 	"github.com/go-logr/logr"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/services/argo"
 	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+	"github.com/weaveworks/progressive-delivery/pkg/services/delivery"
 	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flux"
+	"github.com/weaveworks/progressive-delivery/pkg/services/history"
+	"github.com/weaveworks/progressive-delivery/pkg/services/notify"
 	"github.com/weaveworks/progressive-delivery/pkg/services/version"
 	"github.com/weaveworks/weave-gitops/core/clustersmngr"
 )
 
 func Hydrate(ctx context.Context, mux *runtime.ServeMux, opts ServerOpts) error {
-	pds, err := NewProgressiveDeliveryServer(opts)
-	if err != nil {
+	return RegisterHandlers(ctx, mux, newServer(opts))
+}
+
+// RegisterHandlers registers the REST gateway of a server returned by
+// NewProgressiveDeliveryServer, so it can serve both gRPC and HTTP.
+func RegisterHandlers(ctx context.Context, mux *runtime.ServeMux, server pb.ProgressiveDeliveryServiceServer) error {
+	pds, ok := server.(*pdServer)
+	if !ok {
+		return fmt.Errorf("unsupported server type %T", server)
+	}
+
+	if err := pb.RegisterProgressiveDeliveryServiceHandlerServer(ctx, mux, pds); err != nil {
 		return err
 	}
 
-	return pb.RegisterProgressiveDeliveryServiceHandlerServer(ctx, mux, pds)
+	// Registered after the generated handlers to take precedence over them.
+	return mux.HandlePath(http.MethodGet, watchCanariesPath, handleWatchCanaries(mux, pds))
 }
 
 type pdServer struct {
@@ -28,17 +46,55 @@ type pdServer struct {
 	version         version.Fetcher
 	crd             crd.Fetcher
 	flagger         flagger.Fetcher
-	logger          logr.Logger
+	argo            argo.Fetcher
+	flux            flux.Fetcher
+	// sources list the rollouts of every controller, in the order they're
+	// returned.
+	sources         []delivery.Source
+	history         history.Store
+	notifier        *notify.Notifier
+	loadtesterHosts []string
+	// metricProviderHosts are the hosts metric templates can be run against.
+	metricProviderHosts []string
+	logger              logr.Logger
 }
 
 type ServerOpts struct {
+	// Context bounds the lifetime of the goroutines of the server: the
+	// promotion resetter, the history recorder, the notifier and the canary
+	// events broadcaster if started by the server. They run until the
+	// process exits if nil.
+	Context         context.Context
 	ClustersManager clustersmngr.ClustersManager
 	CRDService      crd.Fetcher
-	Logger          logr.Logger
+	HistoryStore    history.Store
+	// Cache serves the objects of Canaries to the flagger service, they're
+	// read from the API servers if nil.
+	Cache flagger.ObjectCache
+	// NotificationConfig enables notifications on Canary phase transitions.
+	NotificationConfig *notify.Config
+	// CanaryEvents sends the events of Canaries to the promotion resetter,
+	// the history recorder and the notifier. It's started by the caller, one
+	// is started if nil.
+	CanaryEvents *flagger.Broadcaster
+	// LoadtesterHosts are the hosts of the loadtesters whose gates can be
+	// called to roll back, pause and resume Canaries.
+	LoadtesterHosts []string
+	// MetricProviderHosts are the hosts of the metric providers templates can
+	// be run against.
+	MetricProviderHosts []string
+	Logger              logr.Logger
 }
 
 func NewProgressiveDeliveryServer(opts ServerOpts) (pb.ProgressiveDeliveryServiceServer, error) {
-	ctx := context.Background()
+	return newServer(opts), nil
+}
+
+func newServer(opts ServerOpts) *pdServer {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
 
 	versionService := version.NewFetcher()
 
@@ -47,14 +103,57 @@ func NewProgressiveDeliveryServer(opts ServerOpts) (pb.ProgressiveDeliveryServic
 	}
 
 	flaggerService := flagger.NewFetcher(opts.CRDService, opts.Logger)
+	if opts.Cache != nil {
+		flaggerService = flagger.NewCachedFetcher(opts.CRDService, opts.Cache, opts.Logger)
+	}
+
+	canaryEvents := opts.CanaryEvents
+	if canaryEvents == nil {
+		canaryEvents = flagger.NewBroadcaster(flaggerService, opts.ClustersManager, opts.Logger)
+	}
+
+	// Canaries can only be promoted through a clusters manager.
+	if opts.ClustersManager != nil {
+		resetter := flagger.NewPromotionResetter(opts.ClustersManager, opts.Logger)
+
+		go resetter.Start(ctx, canaryEvents.Subscribe())
+	}
+
+	if opts.HistoryStore != nil {
+		recorder := history.NewRecorder(opts.HistoryStore, flaggerService, opts.ClustersManager, opts.Logger)
+
+		go recorder.Start(ctx, canaryEvents.Subscribe())
+	}
+
+	var notifier *notify.Notifier
+
+	if opts.NotificationConfig != nil {
+		notifier = notify.NewNotifier(opts.NotificationConfig, opts.Logger)
+
+		go notifier.Start(ctx, canaryEvents.Subscribe())
+	}
+
+	if opts.CanaryEvents == nil {
+		go canaryEvents.Start(ctx)
+	}
+
+	pd := &pdServer{
+		clustersManager:     opts.ClustersManager,
+		version:             versionService,
+		crd:                 opts.CRDService,
+		flagger:             flaggerService,
+		argo:                argo.NewFetcher(opts.CRDService, opts.Logger),
+		flux:                flux.NewFetcher(opts.Logger),
+		history:             opts.HistoryStore,
+		notifier:            notifier,
+		loadtesterHosts:     opts.LoadtesterHosts,
+		metricProviderHosts: opts.MetricProviderHosts,
+		logger:              opts.Logger,
+	}
+
+	pd.sources = []delivery.Source{flaggerSource{pd: pd}, argoSource{pd: pd}}
 
-	return &pdServer{
-		clustersManager: opts.ClustersManager,
-		version:         versionService,
-		crd:             opts.CRDService,
-		flagger:         flaggerService,
-		logger:          opts.Logger,
-	}, nil
+	return pd
 }
 
 func (pd *pdServer) GetVersion(ctx context.Context, msg *pb.GetVersionRequest) (*pb.GetVersionResponse, error) {
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"context"
+
+	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/models"
+	"github.com/weaveworks/progressive-delivery/pkg/services/argo"
+	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+	"github.com/weaveworks/progressive-delivery/pkg/services/delivery"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+)
+
+// controllers returns the controllers of the sources, in the order their
+// rollouts are listed.
+func (pd *pdServer) controllers() []string {
+	controllers := []string{}
+	for _, source := range pd.sources {
+		controllers = append(controllers, source.Controller())
+	}
+
+	return controllers
+}
+
+// isInstalled reports whether the CRD of a controller is available on any
+// cluster.
+func isInstalled(crdService crd.Fetcher, name string) bool {
+	for _, available := range crdService.IsAvailableOnClusters(name) {
+		if available {
+			return true
+		}
+	}
+
+	return false
+}
+
+// flaggerSource lists Flagger Canaries with their workloads and metric
+// templates.
+type flaggerSource struct {
+	pd *pdServer
+}
+
+func (s flaggerSource) Controller() string {
+	return models.FlaggerController
+}
+
+func (s flaggerSource) IsInstalled() bool {
+	return isInstalled(s.pd.crd, crd.FlaggerCRDName)
+}
+
+func (s flaggerSource) List(ctx context.Context, clusterClient clustersmngr.Client, opts delivery.ListOptions) ([]*pb.Canary, string, []*pb.ListError, error) {
+	results, nextPageToken, listErr, err := s.pd.flagger.ListCanaryDeployments(ctx, clusterClient, flagger.ListCanaryDeploymentsOptions{
+		Filter:    opts.Filter,
+		Sort:      opts.Sort,
+		PageSize:  opts.PageSize,
+		PageToken: opts.PageToken,
+	})
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	errors := []*pb.ListError{}
+
+	for _, err := range listErr {
+		errors = append(errors, &pb.ListError{
+			ClusterName: err.ClusterName,
+			Namespace:   "",
+			Message:     err.Error(),
+		})
+	}
+
+	enrichCtx, cancel := context.WithTimeout(ctx, enrichTimeout)
+	objects, objectsErr := s.pd.flagger.FetchCanaryObjects(enrichCtx, clusterClient, results, flagger.FetchCanaryObjectsOptions{
+		Concurrency: enrichConcurrency,
+	})
+	cancel()
+
+	for _, err := range objectsErr {
+		errors = append(errors, &pb.ListError{
+			ClusterName: err.ClusterName,
+			Namespace:   err.Namespace,
+			Message:     err.Error(),
+		})
+	}
+
+	canaries := []*pb.Canary{}
+
+	for clusterName, list := range results {
+		for i, canary := range list {
+			canaries = append(canaries, s.pd.canaryObjectsToProto(clusterName, canary, objects[clusterName][i]))
+		}
+	}
+
+	return canaries, nextPageToken, errors, nil
+}
+
+// argoSource lists Argo Rollouts with their pod templates, stable ReplicaSets
+// and analyses.
+type argoSource struct {
+	pd *pdServer
+}
+
+func (s argoSource) Controller() string {
+	return models.ArgoRolloutsController
+}
+
+func (s argoSource) IsInstalled() bool {
+	return isInstalled(s.pd.crd, crd.ArgoRolloutsCRDName)
+}
+
+func (s argoSource) List(ctx context.Context, clusterClient clustersmngr.Client, opts delivery.ListOptions) ([]*pb.Canary, string, []*pb.ListError, error) {
+	rollouts, nextPageToken, rolloutErr, err := s.pd.argo.ListRollouts(ctx, clusterClient, argo.ListRolloutsOptions{
+		Filter:    opts.Filter,
+		Sort:      opts.Sort,
+		PageSize:  opts.PageSize,
+		PageToken: opts.PageToken,
+	})
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	errors := []*pb.ListError{}
+
+	for _, err := range rolloutErr {
+		errors = append(errors, &pb.ListError{
+			ClusterName: err.ClusterName,
+			Namespace:   "",
+			Message:     err.Error(),
+		})
+	}
+
+	enrichCtx, cancel := context.WithTimeout(ctx, enrichTimeout)
+	objects, objectsErr := s.pd.argo.FetchRolloutObjects(enrichCtx, clusterClient, rollouts, argo.FetchRolloutObjectsOptions{
+		Concurrency: enrichConcurrency,
+	})
+	cancel()
+
+	for _, err := range objectsErr {
+		errors = append(errors, &pb.ListError{
+			ClusterName: err.ClusterName,
+			Namespace:   err.Namespace,
+			Message:     err.Error(),
+		})
+	}
+
+	canaries := []*pb.Canary{}
+
+	for clusterName, list := range rollouts {
+		for i, rollout := range list {
+			canaries = append(canaries, s.pd.rolloutObjectsToProto(clusterName, rollout, objects[clusterName][i]))
+		}
+	}
+
+	return canaries, nextPageToken, errors, nil
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/services/argo"
+	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+	"github.com/weaveworks/progressive-delivery/pkg/services/delivery"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+func (pd *pdServer) GetCanarySummary(ctx context.Context, msg *pb.GetCanarySummaryRequest) (*pb.GetCanarySummaryResponse, error) {
+	clusterClient, err := pd.clustersManager.GetImpersonatedClient(ctx, auth.Principal(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error getting impersonated client: %w", err)
+	}
+
+	total := flagger.NewCanaryCounts()
+	clusters := map[string]*flagger.CanaryCounts{}
+
+	for clusterName := range clusterClient.Namespaces() {
+		clusters[clusterName] = flagger.NewCanaryCounts()
+	}
+
+	add := func(attrs delivery.Attributes) {
+		if _, ok := clusters[attrs.ClusterName]; !ok {
+			clusters[attrs.ClusterName] = flagger.NewCanaryCounts()
+		}
+
+		clusters[attrs.ClusterName].Add(attrs)
+		total.Add(attrs)
+	}
+
+	response := &pb.GetCanarySummaryResponse{
+		Clusters:                   map[string]*pb.CanaryCounts{},
+		FlaggerUnavailableClusters: []string{},
+		Errors:                     []*pb.ListError{},
+	}
+
+	canaries, _, listErr, err := pd.flagger.ListCanaryDeployments(ctx, clusterClient, flagger.ListCanaryDeploymentsOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, err := range listErr {
+		response.Errors = append(response.Errors, &pb.ListError{
+			ClusterName: err.ClusterName,
+			Message:     err.Error(),
+		})
+	}
+
+	for clusterName, list := range canaries {
+		for _, canary := range list {
+			add(pd.flagger.AttributesOf(clusterName, canary))
+		}
+	}
+
+	if pd.isArgoRolloutsInstalled() {
+		rollouts, _, rolloutErr, err := pd.argo.ListRollouts(ctx, clusterClient, argo.ListRolloutsOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, err := range rolloutErr {
+			response.Errors = append(response.Errors, &pb.ListError{
+				ClusterName: err.ClusterName,
+				Message:     err.Error(),
+			})
+		}
+
+		for clusterName, list := range rollouts {
+			for _, rollout := range list {
+				add(pd.argo.AttributesOf(clusterName, rollout))
+			}
+		}
+	}
+
+	for clusterName, available := range pd.crd.IsAvailableOnClusters(crd.FlaggerCRDName) {
+		if !available {
+			response.FlaggerUnavailableClusters = append(response.FlaggerUnavailableClusters, clusterName)
+		}
+	}
+
+	sort.Strings(response.FlaggerUnavailableClusters)
+
+	response.Total = canaryCountsToProto(total)
+
+	for clusterName, counts := range clusters {
+		response.Clusters[clusterName] = canaryCountsToProto(counts)
+	}
+
+	return response, nil
+}
+
+func canaryCountsToProto(counts *flagger.CanaryCounts) *pb.CanaryCounts {
+	return &pb.CanaryCounts{
+		Total:      counts.Total,
+		Phases:     counts.Phases,
+		Strategies: counts.Strategies,
+		Providers:  counts.Providers,
+	}
+}
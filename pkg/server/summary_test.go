@@ -0,0 +1,44 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
+	api "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"github.com/weaveworks/progressive-delivery/pkg/services/delivery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestGetCanarySummary(t *testing.T) {
+	ctx := context.Background()
+	c := pdtesting.MakeGRPCServer(t, k8sEnv.Rest, k8sEnv)
+
+	k, err := client.New(k8sEnv.Rest, client.Options{
+		Scheme: kube.CreateScheme(),
+	})
+	assert.NoError(t, err)
+
+	for _, name := range []string{"app-a", "app-b"} {
+		ns := pdtesting.NewNamespace(ctx, t, k)
+
+		canary := pdtesting.NewCanary(ctx, t, k, pdtesting.CanaryInfo{
+			Name:      name,
+			Namespace: ns.GetName(),
+		})
+		defer cleanup(ctx, t, k, &canary)
+	}
+
+	response, err := c.GetCanarySummary(ctx, &api.GetCanarySummaryRequest{})
+	assert.NoError(t, err)
+
+	assert.Empty(t, response.GetErrors())
+	assert.Empty(t, response.GetFlaggerUnavailableClusters())
+
+	assert.Equal(t, int32(2), response.GetTotal().GetTotal())
+	assert.Equal(t, map[string]int32{string(delivery.BlueGreenDeploymentStrategy): 2}, response.GetTotal().GetStrategies())
+	assert.Equal(t, map[string]int32{"linkerd": 2}, response.GetTotal().GetProviders())
+	assert.Equal(t, response.GetTotal().GetTotal(), response.GetClusters()["Default"].GetTotal())
+}
@@ -1,21 +1,99 @@
 package server
 
 import (
+	"time"
+
 	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/kustomize/kstatus/status"
 )
 
-func mapUnstructuredConditions(result *status.Result) []*pb.Condition {
+// mapUnstructuredConditions returns the conditions of the object, followed by
+// the kstatus conditions it doesn't set itself. Objects without a Ready
+// condition get one once they're Current.
+func mapUnstructuredConditions(obj unstructured.Unstructured, result *status.Result) []*pb.Condition {
 	conds := []*pb.Condition{}
+	seen := map[string]bool{}
+
+	items, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+
+	for _, item := range items {
+		condition, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
 
-	if result.Status == status.CurrentStatus {
+		cond := &pb.Condition{}
+		cond.Type, _, _ = unstructured.NestedString(condition, "type")
+		cond.Status, _, _ = unstructured.NestedString(condition, "status")
+		cond.Reason, _, _ = unstructured.NestedString(condition, "reason")
+		cond.Message, _, _ = unstructured.NestedString(condition, "message")
+
+		// Deployments only set the time of the last update of some conditions.
+		for _, field := range []string{"lastTransitionTime", "lastUpdateTime", "lastProbeTime"} {
+			if timestamp, _, _ := unstructured.NestedString(condition, field); timestamp != "" {
+				cond.Timestamp = timestamp
+				break
+			}
+		}
+
+		conds = append(conds, cond)
+		seen[cond.Type] = true
+	}
+
+	for _, condition := range result.Conditions {
+		if seen[string(condition.Type)] {
+			continue
+		}
+
+		conds = append(conds, &pb.Condition{
+			Type:    string(condition.Type),
+			Status:  string(condition.Status),
+			Reason:  condition.Reason,
+			Message: condition.Message,
+		})
+	}
+
+	if result.Status == status.CurrentStatus && !seen["Ready"] {
 		conds = append(conds, &pb.Condition{Type: "Ready", Status: "True", Message: result.Message})
 	}
 
 	return conds
 }
 
+func containerStatusToProto(containerStatus corev1.ContainerStatus) *pb.ContainerStatus {
+	result := &pb.ContainerStatus{
+		Name:         containerStatus.Name,
+		Image:        containerStatus.Image,
+		Ready:        containerStatus.Ready,
+		RestartCount: containerStatus.RestartCount,
+	}
+
+	state := containerStatus.State
+
+	switch {
+	case state.Waiting != nil:
+		result.State = "waiting"
+		result.Reason = state.Waiting.Reason
+		result.Message = state.Waiting.Message
+	case state.Running != nil:
+		result.State = "running"
+		result.StartedAt = state.Running.StartedAt.Format(time.RFC3339)
+	case state.Terminated != nil:
+		result.State = "terminated"
+		result.Reason = state.Terminated.Reason
+		result.Message = state.Terminated.Message
+		result.StartedAt = state.Terminated.StartedAt.Format(time.RFC3339)
+	}
+
+	if terminated := containerStatus.LastTerminationState.Terminated; terminated != nil {
+		result.LastTerminationReason = terminated.Reason
+	}
+
+	return result
+}
+
 func getContainerImages(containers []interface{}) []string {
 	images := []string{}
 
@@ -42,3 +120,9 @@ func getDeploymentPodContainerImages(obj map[string]interface{}) []string {
 
 	return getContainerImages(containers)
 }
+
+func getPodContainerImages(obj map[string]interface{}) []string {
+	containers, _, _ := unstructured.NestedSlice(obj, "spec", "containers")
+
+	return getContainerImages(containers)
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+func (pd *pdServer) ValidateCanary(ctx context.Context, msg *pb.ValidateCanaryRequest) (*pb.ValidateCanaryResponse, error) {
+	clusterClient, err := pd.clustersManager.GetImpersonatedClient(ctx, auth.Principal(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error getting impersonated client: %w", err)
+	}
+
+	findings := []flagger.Finding{}
+
+	var canary *v1beta1.Canary
+
+	if msg.Yaml != "" {
+		parsed, parseFindings, err := flagger.ParseCanary([]byte(msg.Yaml))
+		if err != nil {
+			return nil, err
+		}
+
+		if parsed.GetNamespace() == "" {
+			parsed.SetNamespace(msg.Namespace)
+		}
+
+		canary = parsed
+		findings = append(findings, parseFindings...)
+	} else {
+		canary, err = pd.flagger.GetCanary(ctx, clusterClient, flagger.GetCanaryOptions{
+			Name:        msg.Name,
+			Namespace:   msg.Namespace,
+			ClusterName: msg.ClusterName,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if canary.GetNamespace() == "" {
+		return nil, fmt.Errorf("canary namespace is required")
+	}
+
+	validationFindings, err := pd.flagger.ValidateCanary(ctx, msg.ClusterName, clusterClient, canary)
+	if err != nil {
+		return nil, fmt.Errorf("validating canary: %w", err)
+	}
+
+	response := &pb.ValidateCanaryResponse{
+		Findings: []*pb.CanaryFinding{},
+		Valid:    true,
+	}
+
+	for _, finding := range append(findings, validationFindings...) {
+		if finding.Severity == flagger.SeverityError {
+			response.Valid = false
+		}
+
+		response.Findings = append(response.Findings, &pb.CanaryFinding{
+			Severity: string(finding.Severity),
+			Field:    finding.Field,
+			Message:  finding.Message,
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const watchCanariesPath = "/v1/pd/watch/canaries"
+
+func (pd *pdServer) WatchCanaries(msg *pb.WatchCanariesRequest, stream pb.ProgressiveDeliveryService_WatchCanariesServer) error {
+	ctx := stream.Context()
+	principal := auth.Principal(ctx)
+
+	clusterClient, err := pd.clustersManager.GetImpersonatedClient(ctx, principal)
+	if err != nil {
+		return fmt.Errorf("error getting impersonated client: %w", err)
+	}
+
+	events, err := pd.flagger.WatchCanaries(
+		ctx,
+		clusterClient,
+		pd.clustersManager.GetClusters(),
+		principal,
+		flagger.WatchCanariesOptions{
+			ClusterName: msg.ClusterName,
+			Namespace:   msg.Namespace,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed watching canaries: %w", err)
+	}
+
+	for event := range events {
+		var canary *pb.Canary
+
+		if event.Type == watch.Deleted {
+			// The objects of a deleted Canary are gone or being deleted with
+			// it, they're not fetched.
+			canary = pd.canaryObjectsToProto(event.ClusterName, event.Canary, flagger.CanaryObjects{})
+		} else {
+			canary = pd.canaryToProto(ctx, event.ClusterName, clusterClient, event.Canary)
+		}
+
+		err := stream.Send(&pb.WatchCanariesResponse{
+			Type:   string(event.Type),
+			Canary: canary,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// handleWatchCanaries serves WatchCanaries over HTTP. The in-process gateway
+// handlers registered by Hydrate don't support streaming, so events are
+// written here as they come, either as server-sent events or as newline
+// delimited JSON in the same format the grpc-gateway uses for streams.
+func handleWatchCanaries(mux *runtime.ServeMux, pd *pdServer) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request, _ map[string]string) {
+		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+
+		// Annotated like the generated handlers, so the annotators of the mux
+		// run for streams too.
+		ctx, err := runtime.AnnotateContext(req.Context(), mux, req, "/.ProgressiveDeliveryService/WatchCanaries", runtime.WithHTTPPathPattern(watchCanariesPath))
+		if err != nil {
+			runtime.HTTPError(req.Context(), mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		req = req.WithContext(ctx)
+
+		msg := &pb.WatchCanariesRequest{}
+		if err := runtime.PopulateQueryParameters(msg, req.URL.Query(), utilities.NewDoubleArray(nil)); err != nil {
+			runtime.HTTPError(req.Context(), mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			runtime.HTTPError(req.Context(), mux, outboundMarshaler, w, req, fmt.Errorf("streaming is not supported"))
+			return
+		}
+
+		sse := strings.Contains(req.Header.Get("Accept"), "text/event-stream")
+
+		if sse {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+		} else {
+			w.Header().Set("Content-Type", outboundMarshaler.ContentType(msg))
+		}
+
+		w.Header().Set("Transfer-Encoding", "chunked")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		stream := &httpWatchCanariesStream{
+			ctx:       req.Context(),
+			marshaler: outboundMarshaler,
+			writer:    w,
+			flusher:   flusher,
+			sse:       sse,
+		}
+
+		if err := pd.WatchCanaries(msg, stream); err != nil {
+			pd.logger.Error(err, "failed streaming canaries")
+			_ = stream.writeFrame(map[string]interface{}{"error": err.Error()})
+		}
+	}
+}
+
+type httpWatchCanariesStream struct {
+	grpc.ServerStream
+
+	ctx       context.Context
+	marshaler runtime.Marshaler
+	writer    http.ResponseWriter
+	flusher   http.Flusher
+	sse       bool
+}
+
+func (s *httpWatchCanariesStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *httpWatchCanariesStream) Send(msg *pb.WatchCanariesResponse) error {
+	if s.sse {
+		return s.writeFrame(msg)
+	}
+
+	return s.writeFrame(map[string]interface{}{"result": msg})
+}
+
+func (s *httpWatchCanariesStream) writeFrame(v interface{}) error {
+	buf, err := s.marshaler.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed marshaling event: %w", err)
+	}
+
+	if s.sse {
+		buf = append(append([]byte("data: "), buf...), '\n')
+	}
+
+	if _, err := s.writer.Write(append(buf, '\n')); err != nil {
+		return err
+	}
+
+	s.flusher.Flush()
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
+	api "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestWatchCanaries(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	c := pdtesting.MakeGRPCServer(t, k8sEnv.Rest, k8sEnv)
+
+	k, err := client.New(k8sEnv.Rest, client.Options{
+		Scheme: kube.CreateScheme(),
+	})
+	require.NoError(t, err)
+
+	ns := pdtesting.NewNamespace(ctx, t, k)
+
+	canary := pdtesting.NewCanary(ctx, t, k, pdtesting.CanaryInfo{
+		Name:      "watched",
+		Namespace: ns.GetName(),
+	})
+	defer cleanup(ctx, t, k, &canary)
+
+	stream, err := c.WatchCanaries(ctx, &api.WatchCanariesRequest{Namespace: ns.GetName()})
+	require.NoError(t, err)
+
+	event, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "ADDED", event.GetType())
+	assert.Equal(t, canary.Name, event.GetCanary().GetName())
+
+	canary.Status.Phase = v1beta1.CanaryPhaseProgressing
+	canary.Status.CanaryWeight = 10
+	require.NoError(t, k.Status().Update(ctx, &canary))
+
+	event, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "MODIFIED", event.GetType())
+	assert.Equal(t, string(v1beta1.CanaryPhaseProgressing), event.GetCanary().GetStatus().GetPhase())
+	assert.Equal(t, int32(10), event.GetCanary().GetStatus().GetCanaryWeight())
+}
@@ -0,0 +1,304 @@
+package argo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultEnrichConcurrency is the number of lists made at once per cluster if
+// not set.
+const defaultEnrichConcurrency = 4
+
+// RolloutObjects are the objects a Rollout is shown with. The pod template and
+// stable ReplicaSet are empty if not found.
+type RolloutObjects struct {
+	Template          corev1.PodTemplateSpec
+	Stable            appsv1.ReplicaSet
+	AnalysisTemplates []AnalysisTemplate
+	AnalysisRuns      []AnalysisRun
+}
+
+type FetchRolloutObjectsOptions struct {
+	// Concurrency is the number of lists made at once per cluster, clusters
+	// are listed concurrently.
+	Concurrency int
+}
+
+// FetchRolloutObjects returns the objects of the Rollouts of each cluster, in
+// the same order. Referenced Deployments, ReplicaSets, AnalysisTemplates and
+// AnalysisRuns are listed once per namespace and joined to the Rollouts,
+// instead of read one by one. Failing lists and missing AnalysisTemplates are
+// returned as errors, the objects found are still returned.
+func (service *defaultFetcher) FetchRolloutObjects(
+	ctx context.Context,
+	clusterClient clustersmngr.Client,
+	rollouts map[string][]Rollout,
+	opts FetchRolloutObjectsOptions,
+) (map[string][]RolloutObjects, []RolloutObjectsError) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultEnrichConcurrency
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = map[string][]RolloutObjects{}
+		errs    = []RolloutObjectsError{}
+	)
+
+	for clusterName, list := range rollouts {
+		wg.Add(1)
+
+		go func(clusterName string, list []Rollout) {
+			defer wg.Done()
+
+			objects, clusterErrs := service.fetchClusterRolloutObjects(ctx, clusterName, clusterClient, list, opts.Concurrency)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			results[clusterName] = objects
+			errs = append(errs, clusterErrs...)
+		}(clusterName, list)
+	}
+
+	wg.Wait()
+
+	return results, errs
+}
+
+// namespaceObjects are the objects listed in a namespace of a cluster,
+// cluster scoped AnalysisTemplates are listed in the empty namespace.
+type namespaceObjects struct {
+	deployments       map[string]appsv1.Deployment
+	replicaSets       []appsv1.ReplicaSet
+	analysisTemplates map[string]AnalysisTemplate
+	analysisRuns      []AnalysisRun
+}
+
+func (service *defaultFetcher) fetchClusterRolloutObjects(
+	ctx context.Context,
+	clusterName string,
+	clusterClient clustersmngr.Client,
+	rollouts []Rollout,
+	concurrency int,
+) ([]RolloutObjects, []RolloutObjectsError) {
+	var (
+		deploymentNamespaces = sets.NewString()
+		stableNamespaces     = sets.NewString()
+		templateNamespaces   = sets.NewString()
+		runNamespaces        = sets.NewString()
+		clusterTemplates     bool
+	)
+
+	for _, rollout := range rollouts {
+		runNamespaces.Insert(rollout.GetNamespace())
+
+		if ref := rollout.Spec.WorkloadRef; ref != nil && ref.Kind == "Deployment" {
+			deploymentNamespaces.Insert(rollout.GetNamespace())
+		}
+
+		if rollout.Status.StableRS != "" {
+			stableNamespaces.Insert(rollout.GetNamespace())
+		}
+
+		for _, ref := range rollout.AnalysisTemplateRefs() {
+			if ref.ClusterScope {
+				clusterTemplates = true
+			} else {
+				templateNamespaces.Insert(rollout.GetNamespace())
+			}
+		}
+	}
+
+	var (
+		mu         sync.Mutex
+		wg         sync.WaitGroup
+		errs       = []RolloutObjectsError{}
+		namespaces = map[string]*namespaceObjects{}
+		semaphore  = make(chan struct{}, concurrency)
+		// templatesListed are the namespaces whose AnalysisTemplates were
+		// listed, the failed lists are already reported.
+		templatesListed = sets.NewString()
+	)
+
+	objectsIn := func(namespace string) *namespaceObjects {
+		if namespaces[namespace] == nil {
+			namespaces[namespace] = &namespaceObjects{
+				deployments:       map[string]appsv1.Deployment{},
+				analysisTemplates: map[string]AnalysisTemplate{},
+			}
+		}
+
+		return namespaces[namespace]
+	}
+
+	list := func(namespace string, objects client.ObjectList, join func(*namespaceObjects) error, opts ...client.ListOption) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				mu.Lock()
+				errs = append(errs, RolloutObjectsError{ClusterName: clusterName, Namespace: namespace, Err: ctx.Err()})
+				mu.Unlock()
+
+				return
+			}
+
+			err := clusterClient.List(ctx, clusterName, objects, append(opts, client.InNamespace(namespace))...)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err == nil {
+				err = join(objectsIn(namespace))
+			}
+
+			if err != nil {
+				errs = append(errs, RolloutObjectsError{ClusterName: clusterName, Namespace: namespace, Err: err})
+			}
+		}()
+	}
+
+	for _, namespace := range deploymentNamespaces.List() {
+		deployments := &appsv1.DeploymentList{}
+
+		list(namespace, deployments, func(objects *namespaceObjects) error {
+			for _, deployment := range deployments.Items {
+				objects.deployments[deployment.GetName()] = deployment
+			}
+
+			return nil
+		})
+	}
+
+	for _, namespace := range stableNamespaces.List() {
+		replicaSets := &appsv1.ReplicaSetList{}
+
+		list(namespace, replicaSets, func(objects *namespaceObjects) error {
+			objects.replicaSets = replicaSets.Items
+
+			return nil
+		}, client.HasLabels{PodTemplateHashLabel})
+	}
+
+	listTemplates := func(namespace, kind string) {
+		templates := newList(kind)
+
+		list(namespace, templates, func(objects *namespaceObjects) error {
+			templatesListed.Insert(namespace)
+
+			for _, item := range templates.Items {
+				template, err := decodeAnalysisTemplate(item)
+				if err != nil {
+					return fmt.Errorf("failed decoding %s %s: %w", kind, item.GetName(), err)
+				}
+
+				objects.analysisTemplates[template.GetName()] = template
+			}
+
+			return nil
+		})
+	}
+
+	for _, namespace := range templateNamespaces.List() {
+		listTemplates(namespace, AnalysisTemplateKind)
+	}
+
+	if clusterTemplates {
+		listTemplates("", ClusterAnalysisTemplateKind)
+	}
+
+	for _, namespace := range runNamespaces.List() {
+		runs := newList(AnalysisRunKind)
+
+		list(namespace, runs, func(objects *namespaceObjects) error {
+			for _, item := range runs.Items {
+				run, err := decodeAnalysisRun(item)
+				if err != nil {
+					return fmt.Errorf("failed decoding analysis run %s: %w", item.GetName(), err)
+				}
+
+				objects.analysisRuns = append(objects.analysisRuns, run)
+			}
+
+			return nil
+		})
+	}
+
+	wg.Wait()
+
+	results := make([]RolloutObjects, len(rollouts))
+
+	for i := range rollouts {
+		rollout := &rollouts[i]
+		objects := objectsIn(rollout.GetNamespace())
+
+		results[i] = RolloutObjects{
+			Template:          rollout.Spec.Template,
+			AnalysisTemplates: []AnalysisTemplate{},
+			AnalysisRuns:      []AnalysisRun{},
+		}
+
+		if ref := rollout.Spec.WorkloadRef; ref != nil {
+			results[i].Template = objects.deployments[ref.Name].Spec.Template
+		}
+
+		for _, rs := range objects.replicaSets {
+			if rs.GetLabels()[PodTemplateHashLabel] == rollout.Status.StableRS && isOwnedBy(rs.GetOwnerReferences(), rollout) {
+				results[i].Stable = rs
+				break
+			}
+		}
+
+		for _, ref := range rollout.AnalysisTemplateRefs() {
+			namespace := rollout.GetNamespace()
+			if ref.ClusterScope {
+				namespace = ""
+			}
+
+			if !templatesListed.Has(namespace) {
+				continue
+			}
+
+			template, ok := objectsIn(namespace).analysisTemplates[ref.TemplateName]
+			if !ok {
+				errs = append(errs, RolloutObjectsError{
+					ClusterName: clusterName,
+					Namespace:   rollout.GetNamespace(),
+					Err:         AnalysisTemplateNotFoundError{Rollout: rollout.GetName(), Name: ref.TemplateName, Namespace: namespace},
+				})
+
+				continue
+			}
+
+			results[i].AnalysisTemplates = append(results[i].AnalysisTemplates, template)
+		}
+
+		for _, run := range objects.analysisRuns {
+			if isOwnedBy(run.GetOwnerReferences(), rollout) {
+				results[i].AnalysisRuns = append(results[i].AnalysisRuns, run)
+			}
+		}
+
+		sort.SliceStable(results[i].AnalysisRuns, func(a, b int) bool {
+			runs := results[i].AnalysisRuns
+			return runs[a].CreationTimestamp.Before(&runs[b].CreationTimestamp)
+		})
+	}
+
+	return results, errs
+}
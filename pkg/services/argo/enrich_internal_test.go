@@ -0,0 +1,131 @@
+package argo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/clustersmngrfakes"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestFetchRolloutObjects(t *testing.T) {
+	scheme := kube.CreateScheme()
+	mapper := apimeta.NewDefaultRESTMapper([]schema.GroupVersion{GroupVersion, appsv1.SchemeGroupVersion})
+
+	for _, kind := range []string{AnalysisTemplateKind, AnalysisRunKind} {
+		scheme.AddKnownTypeWithName(GroupVersion.WithKind(kind), &unstructured.Unstructured{})
+		scheme.AddKnownTypeWithName(GroupVersion.WithKind(kind+"List"), &unstructured.UnstructuredList{})
+		mapper.Add(GroupVersion.WithKind(kind), apimeta.RESTScopeNamespace)
+	}
+
+	scheme.AddKnownTypeWithName(GroupVersion.WithKind(ClusterAnalysisTemplateKind), &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(GroupVersion.WithKind(ClusterAnalysisTemplateKind+"List"), &unstructured.UnstructuredList{})
+	mapper.Add(GroupVersion.WithKind(ClusterAnalysisTemplateKind), apimeta.RESTScopeRoot)
+
+	for _, kind := range []string{"Deployment", "ReplicaSet"} {
+		mapper.Add(appsv1.SchemeGroupVersion.WithKind(kind), apimeta.RESTScopeNamespace)
+	}
+
+	newObject := func(kind, namespace, name string, owner types.UID) client.Object {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(GroupVersion.WithKind(kind))
+		obj.SetName(name)
+		obj.SetNamespace(namespace)
+
+		if owner != "" {
+			obj.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: GroupVersion.String(), Kind: RolloutKind, Name: string(owner), UID: owner}})
+		}
+
+		return obj
+	}
+
+	newReplicaSet := func(name, hash string, owner types.UID) client.Object {
+		return &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "test",
+				Labels:          map[string]string{PodTemplateHashLabel: hash},
+				OwnerReferences: []metav1.OwnerReference{{Kind: RolloutKind, Name: string(owner), UID: owner}},
+			},
+			Spec: appsv1.ReplicaSetSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: name}}}},
+			},
+		}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithObjects(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "test"},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "frontend:2"}}}},
+			},
+		},
+		newReplicaSet("backend-stable", "abc", "backend"),
+		// Same hash, owned by another rollout.
+		newReplicaSet("frontend-stable", "abc", "frontend"),
+		newObject(AnalysisTemplateKind, "test", "success-rate", ""),
+		newObject(ClusterAnalysisTemplateKind, "", "latency", ""),
+		newObject(AnalysisRunKind, "test", "backend-run", "backend"),
+		newObject(AnalysisRunKind, "test", "frontend-run", "frontend"),
+	).Build()
+
+	pool := &clustersmngrfakes.FakeClientsPool{}
+	pool.ClientReturns(c, nil)
+
+	analysis := &RolloutAnalysis{Templates: []AnalysisTemplateRef{
+		{TemplateName: "success-rate"},
+		{TemplateName: "latency", ClusterScope: true},
+		{TemplateName: "missing"},
+	}}
+
+	rollouts := []Rollout{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "test", UID: "backend"},
+			Spec: RolloutSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "backend:2"}}}},
+				Strategy: RolloutStrategy{Canary: &CanaryStrategy{Analysis: analysis}},
+			},
+			Status: RolloutStatus{StableRS: "abc"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "test", UID: "frontend"},
+			Spec:       RolloutSpec{WorkloadRef: &ObjectRef{Kind: "Deployment", Name: "frontend"}},
+		},
+	}
+
+	service := &defaultFetcher{logger: logr.Discard()}
+
+	results, errs := service.FetchRolloutObjects(context.Background(), clustersmngr.NewClient(pool, nil, logr.Discard()), map[string][]Rollout{"Default": rollouts}, FetchRolloutObjectsOptions{})
+	require.Len(t, results["Default"], 2)
+
+	backend, frontend := results["Default"][0], results["Default"][1]
+
+	assert.Equal(t, "backend:2", backend.Template.Spec.Containers[0].Image)
+	assert.Equal(t, "backend-stable", backend.Stable.GetName(), "the stable replica set should be owned by the rollout")
+	require.Len(t, backend.AnalysisTemplates, 2)
+	assert.Equal(t, "success-rate", backend.AnalysisTemplates[0].GetName())
+	assert.Equal(t, "latency", backend.AnalysisTemplates[1].GetName())
+	require.Len(t, backend.AnalysisRuns, 1)
+	assert.Equal(t, "backend-run", backend.AnalysisRuns[0].GetName())
+
+	assert.Equal(t, "frontend:2", frontend.Template.Spec.Containers[0].Image, "the template should be read from the referenced deployment")
+	assert.Empty(t, frontend.Stable.GetName())
+	require.Len(t, frontend.AnalysisRuns, 1)
+	assert.Equal(t, "frontend-run", frontend.AnalysisRuns[0].GetName())
+
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "analysis template test/missing of rollout backend not found")
+}
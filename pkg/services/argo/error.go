@@ -0,0 +1,55 @@
+package argo
+
+import "fmt"
+
+type ArgoRolloutsIsNotAvailableError struct {
+	ClusterName string
+}
+
+func (e ArgoRolloutsIsNotAvailableError) Error() string {
+	return fmt.Sprintf("argo rollouts is not installed on cluster: %s", e.ClusterName)
+}
+
+type RolloutListError struct {
+	ClusterName string
+	Err         error
+}
+
+func (e RolloutListError) Error() string {
+	return fmt.Sprintf("rollout list error on cluster %s: %s", e.ClusterName, e.Err.Error())
+}
+
+type AnalysisTemplateListError struct {
+	ClusterName string
+	Err         error
+}
+
+func (e AnalysisTemplateListError) Error() string {
+	return fmt.Sprintf("analysis template list error on cluster %s: %s", e.ClusterName, e.Err.Error())
+}
+
+// RolloutObjectsError is a failure to fetch the objects of the Rollouts of a
+// namespace.
+type RolloutObjectsError struct {
+	ClusterName string
+	Namespace   string
+	Err         error
+}
+
+func (e RolloutObjectsError) Error() string {
+	return fmt.Sprintf("rollout objects error on cluster %s namespace %s: %s", e.ClusterName, e.Namespace, e.Err.Error())
+}
+
+type AnalysisTemplateNotFoundError struct {
+	Rollout   string
+	Name      string
+	Namespace string
+}
+
+func (e AnalysisTemplateNotFoundError) Error() string {
+	if e.Namespace == "" {
+		return fmt.Sprintf("cluster analysis template %s of rollout %s not found", e.Name, e.Rollout)
+	}
+
+	return fmt.Sprintf("analysis template %s/%s of rollout %s not found", e.Namespace, e.Name, e.Rollout)
+}
@@ -0,0 +1,413 @@
+package argo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/weaveworks/progressive-delivery/pkg/pagination"
+	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+	"github.com/weaveworks/progressive-delivery/pkg/services/delivery"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Fetcher interface {
+	AttributesOf(clusterName string, rollout Rollout) delivery.Attributes
+	DeploymentStrategyFor(rollout Rollout) delivery.DeploymentStrategy
+	FetchPodTemplate(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, rollout *Rollout) (corev1.PodTemplateSpec, error)
+	FetchStable(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, rollout *Rollout) (appsv1.ReplicaSet, error)
+	FetchRolloutObjects(ctx context.Context, clusterClient clustersmngr.Client, rollouts map[string][]Rollout, opts FetchRolloutObjectsOptions) (map[string][]RolloutObjects, []RolloutObjectsError)
+	GetRollout(ctx context.Context, clusterClient clustersmngr.Client, opts GetRolloutOptions) (*Rollout, error)
+	GetAnalysisTemplate(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, ref AnalysisTemplateRef, namespace string) (AnalysisTemplate, error)
+	ListRollouts(ctx context.Context, clusterClient clustersmngr.Client, opts ListRolloutsOptions) (map[string][]Rollout, string, []RolloutListError, error)
+	ListAnalysisTemplates(ctx context.Context, clusterClient clustersmngr.Client, opts ListAnalysisTemplatesOptions) (map[string][]AnalysisTemplate, string, []AnalysisTemplateListError, error)
+	ListAnalysisRuns(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, rollout *Rollout) ([]AnalysisRun, error)
+}
+
+func NewFetcher(crdService crd.Fetcher, logger logr.Logger) Fetcher {
+	return &defaultFetcher{
+		crdService: crdService,
+		logger:     logger,
+	}
+}
+
+type defaultFetcher struct {
+	crdService crd.Fetcher
+	logger     logr.Logger
+}
+
+type ListRolloutsOptions struct {
+	delivery.Filter
+	Sort      delivery.SortOptions
+	PageSize  int32
+	PageToken string
+}
+
+type ListAnalysisTemplatesOptions struct {
+	ClusterName string
+	Namespace   string
+	PageSize    int32
+	PageToken   string
+}
+
+type GetRolloutOptions struct {
+	Name        string
+	Namespace   string
+	ClusterName string
+}
+
+// AttributesOf returns the values filters and sort orders are applied to.
+// Rollouts without a traffic router match the default provider.
+func (service *defaultFetcher) AttributesOf(clusterName string, rollout Rollout) delivery.Attributes {
+	provider := rollout.TrafficRouter()
+	if provider == "" {
+		provider = delivery.DefaultProvider
+	}
+
+	lastTransition := metav1.Time{}
+	for _, condition := range rollout.Status.Conditions {
+		if lastTransition.Before(&condition.LastTransitionTime) {
+			lastTransition = condition.LastTransitionTime
+		}
+	}
+
+	return delivery.Attributes{
+		ClusterName:        clusterName,
+		Namespace:          rollout.GetNamespace(),
+		Name:               rollout.GetName(),
+		Phase:              rollout.CanaryPhase(),
+		Provider:           provider,
+		Strategy:           service.DeploymentStrategyFor(rollout),
+		LastTransitionTime: lastTransition.Time,
+	}
+}
+
+// DeploymentStrategyFor maps the strategy of a Rollout to the closest Flagger
+// deployment strategy.
+func (service *defaultFetcher) DeploymentStrategyFor(rollout Rollout) delivery.DeploymentStrategy {
+	if rollout.Spec.Strategy.BlueGreen != nil {
+		return delivery.BlueGreenDeploymentStrategy
+	}
+
+	return delivery.CanaryDeploymentStrategy
+}
+
+func (service *defaultFetcher) ListRollouts(
+	ctx context.Context,
+	clusterClient clustersmngr.Client,
+	options ListRolloutsOptions,
+) (map[string][]Rollout, string, []RolloutListError, error) {
+	var respErrors []RolloutListError
+
+	if err := options.Sort.Validate(); err != nil {
+		return nil, "", respErrors, err
+	}
+
+	selector, err := options.Selector()
+	if err != nil {
+		return nil, "", respErrors, err
+	}
+
+	lists, continueToken, listErrors, err := service.list(ctx, clusterClient, RolloutKind, options.ClusterName, pagination.ListOptions{
+		PageSize:  options.PageSize,
+		PageToken: options.PageToken,
+		Namespace: options.Namespace,
+		Keep: func(clusterName string, obj runtime.Object) bool {
+			u, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				return false
+			}
+
+			// Objects that can't be decoded are kept to report them.
+			rollout, err := decodeRollout(*u)
+
+			return err != nil || options.Matches(service.AttributesOf(clusterName, rollout))
+		},
+		ListOptions: []client.ListOption{client.MatchingLabelsSelector{Selector: selector}},
+	})
+	if err != nil {
+		return nil, "", respErrors, err
+	}
+
+	for _, e := range listErrors {
+		respErrors = append(respErrors, RolloutListError{ClusterName: e.Cluster, Err: e.Err})
+	}
+
+	results := map[string][]Rollout{}
+
+	for clusterName, items := range lists {
+		results[clusterName] = []Rollout{}
+
+		for _, item := range items {
+			rollout, err := decodeRollout(item)
+			if err != nil {
+				respErrors = append(respErrors, RolloutListError{ClusterName: clusterName, Err: err})
+				continue
+			}
+
+			results[clusterName] = append(results[clusterName], rollout)
+		}
+
+		if options.Sort.By != "" {
+			rollouts := results[clusterName]
+
+			sort.SliceStable(rollouts, func(i, j int) bool {
+				return options.Sort.Less(
+					service.AttributesOf(clusterName, rollouts[i]),
+					service.AttributesOf(clusterName, rollouts[j]),
+				)
+			})
+		}
+	}
+
+	return results, continueToken, respErrors, nil
+}
+
+func (service *defaultFetcher) GetRollout(
+	ctx context.Context,
+	clusterClient clustersmngr.Client,
+	opts GetRolloutOptions,
+) (*Rollout, error) {
+	obj := newObject(RolloutKind)
+	key := client.ObjectKey{
+		Name:      opts.Name,
+		Namespace: opts.Namespace,
+	}
+
+	if err := clusterClient.Get(ctx, opts.ClusterName, key, obj); err != nil {
+		return nil, fmt.Errorf("failed getting rollout: name=%s namespace=%s cluster=%s err=%w", opts.Name, opts.Namespace, opts.ClusterName, err)
+	}
+
+	rollout, err := decodeRollout(*obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding rollout: %w", err)
+	}
+
+	return &rollout, nil
+}
+
+// FetchPodTemplate returns the pod template of the rollout, or the one of the
+// Deployment it references with workloadRef.
+func (service *defaultFetcher) FetchPodTemplate(
+	ctx context.Context,
+	clusterName string,
+	clusterClient clustersmngr.Client,
+	rollout *Rollout,
+) (corev1.PodTemplateSpec, error) {
+	ref := rollout.Spec.WorkloadRef
+	if ref == nil {
+		return rollout.Spec.Template, nil
+	}
+
+	if ref.Kind != "Deployment" {
+		return corev1.PodTemplateSpec{}, fmt.Errorf("unsupported workload reference kind: %s", ref.Kind)
+	}
+
+	deployment := appsv1.Deployment{}
+	key := client.ObjectKey{
+		Name:      ref.Name,
+		Namespace: rollout.GetNamespace(),
+	}
+
+	if err := clusterClient.Get(ctx, clusterName, key, &deployment); err != nil {
+		return corev1.PodTemplateSpec{}, err
+	}
+
+	return deployment.Spec.Template, nil
+}
+
+// FetchStable returns the ReplicaSet running the stable version of the
+// rollout.
+func (service *defaultFetcher) FetchStable(
+	ctx context.Context,
+	clusterName string,
+	clusterClient clustersmngr.Client,
+	rollout *Rollout,
+) (appsv1.ReplicaSet, error) {
+	if rollout.Status.StableRS == "" {
+		return appsv1.ReplicaSet{}, fmt.Errorf("rollout %s/%s has no stable replica set", rollout.GetNamespace(), rollout.GetName())
+	}
+
+	list := appsv1.ReplicaSetList{}
+
+	err := clusterClient.List(
+		ctx,
+		clusterName,
+		&list,
+		client.InNamespace(rollout.GetNamespace()),
+		client.MatchingLabels{PodTemplateHashLabel: rollout.Status.StableRS},
+	)
+	if err != nil {
+		return appsv1.ReplicaSet{}, err
+	}
+
+	for _, rs := range list.Items {
+		if isOwnedBy(rs.GetOwnerReferences(), rollout) {
+			return rs, nil
+		}
+	}
+
+	return appsv1.ReplicaSet{}, fmt.Errorf("stable replica set of rollout %s/%s not found", rollout.GetNamespace(), rollout.GetName())
+}
+
+func (service *defaultFetcher) GetAnalysisTemplate(
+	ctx context.Context,
+	clusterName string,
+	clusterClient clustersmngr.Client,
+	ref AnalysisTemplateRef,
+	namespace string,
+) (AnalysisTemplate, error) {
+	obj := newObject(AnalysisTemplateKind)
+	key := client.ObjectKey{
+		Name:      ref.TemplateName,
+		Namespace: namespace,
+	}
+
+	if ref.ClusterScope {
+		obj = newObject(ClusterAnalysisTemplateKind)
+		key.Namespace = ""
+	}
+
+	if err := clusterClient.Get(ctx, clusterName, key, obj); err != nil {
+		return AnalysisTemplate{}, err
+	}
+
+	return decodeAnalysisTemplate(*obj)
+}
+
+func (service *defaultFetcher) ListAnalysisTemplates(
+	ctx context.Context,
+	clusterClient clustersmngr.Client,
+	options ListAnalysisTemplatesOptions,
+) (map[string][]AnalysisTemplate, string, []AnalysisTemplateListError, error) {
+	var respErrors []AnalysisTemplateListError
+
+	lists, continueToken, listErrors, err := service.list(ctx, clusterClient, AnalysisTemplateKind, options.ClusterName, pagination.ListOptions{
+		PageSize:  options.PageSize,
+		PageToken: options.PageToken,
+		Namespace: options.Namespace,
+	})
+	if err != nil {
+		return nil, "", respErrors, err
+	}
+
+	for _, e := range listErrors {
+		respErrors = append(respErrors, AnalysisTemplateListError{ClusterName: e.Cluster, Err: e.Err})
+	}
+
+	results := map[string][]AnalysisTemplate{}
+
+	for clusterName, items := range lists {
+		results[clusterName] = []AnalysisTemplate{}
+
+		for _, item := range items {
+			template, err := decodeAnalysisTemplate(item)
+			if err != nil {
+				respErrors = append(respErrors, AnalysisTemplateListError{ClusterName: clusterName, Err: err})
+				continue
+			}
+
+			results[clusterName] = append(results[clusterName], template)
+		}
+	}
+
+	return results, continueToken, respErrors, nil
+}
+
+// ListAnalysisRuns returns the AnalysisRuns created for the rollout, oldest
+// first.
+func (service *defaultFetcher) ListAnalysisRuns(
+	ctx context.Context,
+	clusterName string,
+	clusterClient clustersmngr.Client,
+	rollout *Rollout,
+) ([]AnalysisRun, error) {
+	list := newList(AnalysisRunKind)
+
+	if err := clusterClient.List(ctx, clusterName, list, client.InNamespace(rollout.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("failed listing analysis runs: %w", err)
+	}
+
+	runs := []AnalysisRun{}
+
+	for _, item := range list.Items {
+		if !isOwnedBy(item.GetOwnerReferences(), rollout) {
+			continue
+		}
+
+		run, err := decodeAnalysisRun(item)
+		if err != nil {
+			return nil, fmt.Errorf("failed decoding analysis run %s: %w", item.GetName(), err)
+		}
+
+		runs = append(runs, run)
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].CreationTimestamp.Before(&runs[j].CreationTimestamp)
+	})
+
+	return runs, nil
+}
+
+// list returns a page of objects of an Argo Rollouts kind across clusters
+// and namespaces. Clusters other than clusterName, if set, and clusters
+// without Argo Rollouts are skipped.
+func (service *defaultFetcher) list(
+	ctx context.Context,
+	clusterClient clustersmngr.Client,
+	kind string,
+	clusterName string,
+	opts pagination.ListOptions,
+) (map[string][]unstructured.Unstructured, string, []clustersmngr.ListError, error) {
+	opts.SkipCluster = func(name string) bool {
+		if clusterName != "" && clusterName != name {
+			return true
+		}
+
+		if !service.crdService.IsAvailable(name, crd.ArgoRolloutsCRDName) {
+			service.logger.V(1).Info("argo rollouts unavailable", "cluster", name)
+
+			return true
+		}
+
+		return false
+	}
+
+	items, nextPageToken, listErrors, err := pagination.List(
+		ctx,
+		clusterClient,
+		func() client.ObjectList { return newList(kind) },
+		opts,
+	)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	results := map[string][]unstructured.Unstructured{}
+
+	for _, item := range items {
+		if obj, ok := item.Object.(*unstructured.Unstructured); ok {
+			results[item.ClusterName] = append(results[item.ClusterName], *obj)
+		}
+	}
+
+	return results, nextPageToken, listErrors, nil
+}
+
+func isOwnedBy(refs []metav1.OwnerReference, rollout *Rollout) bool {
+	for _, ref := range refs {
+		if ref.UID == rollout.GetUID() {
+			return true
+		}
+	}
+
+	return false
+}
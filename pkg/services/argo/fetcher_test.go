@@ -0,0 +1,107 @@
+package argo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"github.com/weaveworks/progressive-delivery/pkg/services/argo"
+	"github.com/weaveworks/progressive-delivery/pkg/services/delivery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestFetcher_ListRollouts(t *testing.T) {
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	defer cancelFn()
+
+	k, err := client.New(k8sEnv.Rest, client.Options{
+		Scheme: kube.CreateScheme(),
+	})
+	assert.NoError(t, err)
+
+	// create namespace before creating service to
+	// prevent ns access issues
+	ns := pdtesting.NewNamespace(ctx, t, k)
+
+	cl, service, err := newService(ctx, k8sEnv)
+	assert.NoError(t, err)
+
+	rollout := pdtesting.NewRollout(ctx, t, k, pdtesting.RolloutInfo{
+		Name:      "example",
+		Namespace: ns.GetName(),
+		Image:     "ghcr.io/stefanprodan/podinfo:6.0.0",
+	})
+	defer pdtesting.Cleanup(ctx, t, k, rollout)
+
+	rollouts, _, cerrs, err := service.ListRollouts(ctx, cl, argo.ListRolloutsOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, cerrs)
+
+	found := false
+	for _, item := range rollouts["Default"] {
+		if item.GetName() == rollout.GetName() && item.GetNamespace() == ns.GetName() {
+			found = true
+
+			assert.Len(t, item.Spec.Strategy.Canary.Steps, 3)
+			assert.Equal(t, "ghcr.io/stefanprodan/podinfo:6.0.0", item.Spec.Template.Spec.Containers[0].Image)
+			assert.Equal(t, delivery.CanaryDeploymentStrategy, service.DeploymentStrategyFor(item))
+		}
+	}
+
+	assert.True(t, found, "rollout should be listed")
+}
+
+func TestFetcher_GetRollout(t *testing.T) {
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	defer cancelFn()
+
+	k, err := client.New(k8sEnv.Rest, client.Options{
+		Scheme: kube.CreateScheme(),
+	})
+	assert.NoError(t, err)
+
+	ns := pdtesting.NewNamespace(ctx, t, k)
+
+	cl, service, err := newService(ctx, k8sEnv)
+	assert.NoError(t, err)
+
+	template := pdtesting.NewAnalysisTemplate(ctx, t, k, pdtesting.AnalysisTemplateInfo{
+		Name:            "success-rate",
+		Namespace:       ns.GetName(),
+		MetricName:      "success-rate",
+		ProviderAddress: "http://prometheus:9090",
+		Query:           "custom query",
+	})
+	defer pdtesting.Cleanup(ctx, t, k, template)
+
+	rollout := pdtesting.NewRollout(ctx, t, k, pdtesting.RolloutInfo{
+		Name:             "example",
+		Namespace:        ns.GetName(),
+		Image:            "ghcr.io/stefanprodan/podinfo:6.0.0",
+		AnalysisTemplate: template.GetName(),
+	})
+	defer pdtesting.Cleanup(ctx, t, k, rollout)
+
+	result, err := service.GetRollout(ctx, cl, argo.GetRolloutOptions{
+		Name:        rollout.GetName(),
+		Namespace:   ns.GetName(),
+		ClusterName: "Default",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, rollout.GetUID(), result.GetUID())
+
+	refs := result.AnalysisTemplateRefs()
+	assert.Len(t, refs, 1)
+
+	analysisTemplate, err := service.GetAnalysisTemplate(ctx, "Default", cl, refs[0], ns.GetName())
+	assert.NoError(t, err)
+	assert.Equal(t, "success-rate", analysisTemplate.Spec.Metrics[0].Name)
+
+	runs, err := service.ListAnalysisRuns(ctx, "Default", cl, result)
+	assert.NoError(t, err)
+	assert.Empty(t, runs)
+}
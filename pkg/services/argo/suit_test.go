@@ -0,0 +1,41 @@
+package argo_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
+	"github.com/weaveworks/progressive-delivery/pkg/services/argo"
+	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/pkg/testutils"
+)
+
+var k8sEnv *testutils.K8sTestEnv
+
+func TestMain(m *testing.M) {
+	var err error
+
+	k8sEnv, err = pdtesting.CreateTestEnv()
+	if err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+
+	k8sEnv.Stop()
+
+	os.Exit(code)
+}
+
+func newService(ctx context.Context, k8sEnv *testutils.K8sTestEnv) (clustersmngr.Client, argo.Fetcher, error) {
+	cl, clustersManager, err := pdtesting.CreateClient(k8sEnv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	crdSrv := crd.NewNoCacheFetcher(clustersManager)
+	return cl, argo.NewFetcher(crdSrv, logr.Discard()), nil
+}
@@ -0,0 +1,287 @@
+package argo
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// The types below are the subset of the argoproj.io/v1alpha1 API read by this
+// service. Objects are fetched as unstructured and decoded into them, which
+// keeps the Argo Rollouts module and its dependencies out of the build.
+
+var GroupVersion = schema.GroupVersion{Group: "argoproj.io", Version: "v1alpha1"}
+
+const (
+	RolloutKind                 = "Rollout"
+	AnalysisTemplateKind        = "AnalysisTemplate"
+	ClusterAnalysisTemplateKind = "ClusterAnalysisTemplate"
+	AnalysisRunKind             = "AnalysisRun"
+
+	// PodTemplateHashLabel is set by Argo Rollouts on the ReplicaSets it
+	// manages.
+	PodTemplateHashLabel = "rollouts-pod-template-hash"
+)
+
+type Rollout struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RolloutSpec   `json:"spec"`
+	Status RolloutStatus `json:"status,omitempty"`
+
+	// Object is the object as it was read from the cluster.
+	Object unstructured.Unstructured `json:"-"`
+}
+
+type RolloutSpec struct {
+	Template    corev1.PodTemplateSpec `json:"template,omitempty"`
+	WorkloadRef *ObjectRef             `json:"workloadRef,omitempty"`
+	Strategy    RolloutStrategy        `json:"strategy"`
+}
+
+type ObjectRef struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Name       string `json:"name,omitempty"`
+}
+
+type RolloutStrategy struct {
+	BlueGreen *BlueGreenStrategy `json:"blueGreen,omitempty"`
+	Canary    *CanaryStrategy    `json:"canary,omitempty"`
+}
+
+type BlueGreenStrategy struct {
+	ActiveService         string           `json:"activeService"`
+	PreviewService        string           `json:"previewService,omitempty"`
+	AutoPromotionEnabled  *bool            `json:"autoPromotionEnabled,omitempty"`
+	PrePromotionAnalysis  *RolloutAnalysis `json:"prePromotionAnalysis,omitempty"`
+	PostPromotionAnalysis *RolloutAnalysis `json:"postPromotionAnalysis,omitempty"`
+}
+
+type CanaryStrategy struct {
+	CanaryService  string                 `json:"canaryService,omitempty"`
+	StableService  string                 `json:"stableService,omitempty"`
+	Steps          []CanaryStep           `json:"steps,omitempty"`
+	TrafficRouting map[string]interface{} `json:"trafficRouting,omitempty"`
+	Analysis       *RolloutAnalysis       `json:"analysis,omitempty"`
+}
+
+type CanaryStep struct {
+	SetWeight *int32           `json:"setWeight,omitempty"`
+	Pause     *RolloutPause    `json:"pause,omitempty"`
+	Analysis  *RolloutAnalysis `json:"analysis,omitempty"`
+}
+
+type RolloutPause struct {
+	Duration interface{} `json:"duration,omitempty"`
+}
+
+type RolloutAnalysis struct {
+	Templates []AnalysisTemplateRef `json:"templates,omitempty"`
+}
+
+type AnalysisTemplateRef struct {
+	TemplateName string `json:"templateName,omitempty"`
+	ClusterScope bool   `json:"clusterScope,omitempty"`
+}
+
+type RolloutStatus struct {
+	Abort            bool               `json:"abort,omitempty"`
+	Phase            string             `json:"phase,omitempty"`
+	Message          string             `json:"message,omitempty"`
+	CurrentStepIndex *int32             `json:"currentStepIndex,omitempty"`
+	CurrentPodHash   string             `json:"currentPodHash,omitempty"`
+	StableRS         string             `json:"stableRS,omitempty"`
+	Canary           CanaryStatus       `json:"canary,omitempty"`
+	Conditions       []RolloutCondition `json:"conditions,omitempty"`
+}
+
+type CanaryStatus struct {
+	Weights *TrafficWeights `json:"weights,omitempty"`
+}
+
+type TrafficWeights struct {
+	Canary WeightDestination `json:"canary"`
+	Stable WeightDestination `json:"stable"`
+}
+
+type WeightDestination struct {
+	Weight          int32  `json:"weight"`
+	ServiceName     string `json:"serviceName,omitempty"`
+	PodTemplateHash string `json:"podTemplateHash,omitempty"`
+}
+
+type RolloutCondition struct {
+	Type               string      `json:"type"`
+	Status             string      `json:"status"`
+	LastUpdateTime     metav1.Time `json:"lastUpdateTime,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	Reason             string      `json:"reason,omitempty"`
+	Message            string      `json:"message,omitempty"`
+}
+
+// AnalysisTemplate is either a namespaced AnalysisTemplate or a
+// ClusterAnalysisTemplate, they share the same spec.
+type AnalysisTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AnalysisTemplateSpec `json:"spec"`
+
+	Object unstructured.Unstructured `json:"-"`
+}
+
+type AnalysisTemplateSpec struct {
+	Metrics []Metric `json:"metrics"`
+}
+
+type Metric struct {
+	Name             string `json:"name"`
+	Interval         string `json:"interval,omitempty"`
+	SuccessCondition string `json:"successCondition,omitempty"`
+	FailureCondition string `json:"failureCondition,omitempty"`
+	// Provider has a single key, the type of the provider, holding its
+	// configuration.
+	Provider map[string]interface{} `json:"provider"`
+}
+
+type AnalysisRun struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status AnalysisRunStatus `json:"status,omitempty"`
+}
+
+type AnalysisRunStatus struct {
+	Phase         string         `json:"phase,omitempty"`
+	Message       string         `json:"message,omitempty"`
+	MetricResults []MetricResult `json:"metricResults,omitempty"`
+}
+
+type MetricResult struct {
+	Name         string `json:"name"`
+	Phase        string `json:"phase"`
+	Count        int32  `json:"count,omitempty"`
+	Successful   int32  `json:"successful,omitempty"`
+	Failed       int32  `json:"failed,omitempty"`
+	Inconclusive int32  `json:"inconclusive,omitempty"`
+	Error        int32  `json:"error,omitempty"`
+}
+
+// AnalysisTemplateRefs returns every template referenced by the background
+// analysis, the steps, or the blue-green analyses of the rollout.
+func (r Rollout) AnalysisTemplateRefs() []AnalysisTemplateRef {
+	refs := []AnalysisTemplateRef{}
+
+	if canary := r.Spec.Strategy.Canary; canary != nil {
+		if canary.Analysis != nil {
+			refs = append(refs, canary.Analysis.Templates...)
+		}
+
+		for _, step := range canary.Steps {
+			if step.Analysis != nil {
+				refs = append(refs, step.Analysis.Templates...)
+			}
+		}
+	}
+
+	if blueGreen := r.Spec.Strategy.BlueGreen; blueGreen != nil {
+		if blueGreen.PrePromotionAnalysis != nil {
+			refs = append(refs, blueGreen.PrePromotionAnalysis.Templates...)
+		}
+
+		if blueGreen.PostPromotionAnalysis != nil {
+			refs = append(refs, blueGreen.PostPromotionAnalysis.Templates...)
+		}
+	}
+
+	return refs
+}
+
+// Argo Rollouts phases are mapped to the Flagger phase with the same meaning,
+// so clients can handle both controllers the same way.
+var canaryPhases = map[string]string{
+	"":            "Initializing",
+	"Progressing": "Progressing",
+	"Paused":      "Waiting",
+	"Healthy":     "Succeeded",
+	"Degraded":    "Failed",
+}
+
+// CanaryPhase returns the Flagger phase matching the phase of the rollout.
+func (r Rollout) CanaryPhase() string {
+	if r.Status.Abort {
+		return "Failed"
+	}
+
+	if phase, ok := canaryPhases[r.Status.Phase]; ok {
+		return phase
+	}
+
+	return r.Status.Phase
+}
+
+// TrafficRouter returns the traffic router used by the rollout, if any.
+func (r Rollout) TrafficRouter() string {
+	canary := r.Spec.Strategy.Canary
+	if canary == nil || len(canary.TrafficRouting) == 0 {
+		return ""
+	}
+
+	routers := []string{}
+	for name := range canary.TrafficRouting {
+		routers = append(routers, name)
+	}
+
+	sort.Strings(routers)
+
+	return routers[0]
+}
+
+func newList(kind string) *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(GroupVersion.WithKind(kind + "List"))
+
+	return list
+}
+
+func newObject(kind string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(GroupVersion.WithKind(kind))
+
+	return obj
+}
+
+func decodeRollout(obj unstructured.Unstructured) (Rollout, error) {
+	rollout := Rollout{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &rollout); err != nil {
+		return rollout, err
+	}
+
+	rollout.Object = obj
+
+	return rollout, nil
+}
+
+func decodeAnalysisTemplate(obj unstructured.Unstructured) (AnalysisTemplate, error) {
+	template := AnalysisTemplate{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &template); err != nil {
+		return template, err
+	}
+
+	template.Object = obj
+
+	return template, nil
+}
+
+func decodeAnalysisRun(obj unstructured.Unstructured) (AnalysisRun, error) {
+	run := AnalysisRun{}
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &run)
+
+	return run, err
+}
@@ -0,0 +1,300 @@
+package cloudevents
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// ErrBufferFull is returned when an event is appended to a full buffer.
+var ErrBufferFull = errors.New("cloudevents buffer is full")
+
+// Buffer keeps events until the sink accepts them, oldest first. Once full,
+// new events are refused until delivered ones are removed. It also keeps the
+// last progress of each Canary, the changes made while the exporter was down
+// are compared to it.
+type Buffer interface {
+	// Append adds the event, or returns ErrBufferFull.
+	Append(event Event) error
+	// Oldest returns the oldest event and its key, false if there are none.
+	Oldest() (uint64, Event, bool, error)
+	Remove(key uint64) error
+	// Progress returns the last progress saved, by cluster, namespace and
+	// name of the Canary.
+	Progress() (map[string]Progress, error)
+	// SetProgress saves the last progress of a Canary, nil deletes it.
+	SetProgress(key string, progress *Progress) error
+	Close() error
+}
+
+// Progress is the phase and weight of a Canary.
+type Progress struct {
+	Phase  string `json:"phase"`
+	Weight int    `json:"weight"`
+}
+
+var (
+	eventsBucket   = []byte("events")
+	progressBucket = []byte("progress")
+)
+
+const openTimeout = 5 * time.Second
+
+// NewBoltBuffer opens, or creates, a BoltDB file at path. Events are keyed by
+// a sequence number, so they're read back in order after a restart.
+func NewBoltBuffer(path string, maxEvents int) (Buffer, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("failed opening event buffer %s: %w", path, err)
+	}
+
+	count := 0
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(progressBucket); err != nil {
+			return err
+		}
+
+		bucket, err := tx.CreateBucketIfNotExists(eventsBucket)
+		if err != nil {
+			return err
+		}
+
+		count = bucket.Stats().KeyN
+
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed creating event buffer buckets: %w", err)
+	}
+
+	return &boltBuffer{db: db, maxEvents: maxEvents, count: count}, nil
+}
+
+type boltBuffer struct {
+	db        *bolt.DB
+	maxEvents int
+
+	// mu guards count, the number of buffered events, so it isn't read from
+	// the bucket on every append.
+	mu    sync.Mutex
+	count int
+}
+
+func (b *boltBuffer) Append(event Event) error {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed encoding event: %w", err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.maxEvents > 0 && b.count >= b.maxEvents {
+		return ErrBufferFull
+	}
+
+	err = b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(eventsBucket)
+
+		seq, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put(bufferKey(seq), value)
+	})
+	if err != nil {
+		return err
+	}
+
+	b.count++
+
+	return nil
+}
+
+func (b *boltBuffer) Oldest() (uint64, Event, bool, error) {
+	var (
+		seq   uint64
+		event Event
+		found bool
+	)
+
+	err := b.db.View(func(tx *bolt.Tx) error {
+		key, value := tx.Bucket(eventsBucket).Cursor().First()
+		if key == nil {
+			return nil
+		}
+
+		seq, found = binary.BigEndian.Uint64(key), true
+
+		return json.Unmarshal(value, &event)
+	})
+
+	return seq, event, found, err
+}
+
+func (b *boltBuffer) Remove(key uint64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	removed := false
+
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(eventsBucket)
+		if bucket.Get(bufferKey(key)) == nil {
+			return nil
+		}
+
+		removed = true
+
+		return bucket.Delete(bufferKey(key))
+	})
+	if err != nil {
+		return err
+	}
+
+	if removed {
+		b.count--
+	}
+
+	return nil
+}
+
+func (b *boltBuffer) Progress() (map[string]Progress, error) {
+	progress := map[string]Progress{}
+
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(progressBucket).ForEach(func(key, value []byte) error {
+			var p Progress
+			if err := json.Unmarshal(value, &p); err != nil {
+				return fmt.Errorf("failed decoding progress of %s: %w", key, err)
+			}
+
+			progress[string(key)] = p
+
+			return nil
+		})
+	})
+
+	return progress, err
+}
+
+func (b *boltBuffer) SetProgress(key string, progress *Progress) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(progressBucket)
+
+		if progress == nil {
+			return bucket.Delete([]byte(key))
+		}
+
+		value, err := json.Marshal(progress)
+		if err != nil {
+			return fmt.Errorf("failed encoding progress: %w", err)
+		}
+
+		return bucket.Put([]byte(key), value)
+	})
+}
+
+func (b *boltBuffer) Close() error {
+	return b.db.Close()
+}
+
+func bufferKey(seq uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, seq)
+
+	return key
+}
+
+// NewMemoryBuffer returns a buffer losing its events and progress on restart.
+func NewMemoryBuffer(maxEvents int) Buffer {
+	return &memoryBuffer{maxEvents: maxEvents, progress: map[string]Progress{}}
+}
+
+type memoryBuffer struct {
+	mu        sync.Mutex
+	events    []Event
+	keys      []uint64
+	seq       uint64
+	maxEvents int
+	progress  map[string]Progress
+}
+
+func (b *memoryBuffer) Append(event Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.maxEvents > 0 && len(b.events) >= b.maxEvents {
+		return ErrBufferFull
+	}
+
+	b.seq++
+	b.events = append(b.events, event)
+	b.keys = append(b.keys, b.seq)
+
+	return nil
+}
+
+func (b *memoryBuffer) Oldest() (uint64, Event, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.events) == 0 {
+		return 0, Event{}, false, nil
+	}
+
+	return b.keys[0], b.events[0], true, nil
+}
+
+func (b *memoryBuffer) Remove(key uint64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i := range b.keys {
+		if b.keys[i] == key {
+			b.events = append(b.events[:i], b.events[i+1:]...)
+			b.keys = append(b.keys[:i], b.keys[i+1:]...)
+
+			break
+		}
+	}
+
+	return nil
+}
+
+func (b *memoryBuffer) Progress() (map[string]Progress, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	progress := make(map[string]Progress, len(b.progress))
+	for key, p := range b.progress {
+		progress[key] = p
+	}
+
+	return progress, nil
+}
+
+func (b *memoryBuffer) SetProgress(key string, progress *Progress) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if progress == nil {
+		delete(b.progress, key)
+	} else {
+		b.progress[key] = *progress
+	}
+
+	return nil
+}
+
+func (b *memoryBuffer) Close() error {
+	return nil
+}
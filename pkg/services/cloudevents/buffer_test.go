@@ -0,0 +1,123 @@
+package cloudevents_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/progressive-delivery/pkg/services/cloudevents"
+)
+
+func TestBuffer_Order(t *testing.T) {
+	buffers := map[string]cloudevents.Buffer{
+		"bolt":   newBoltBuffer(t, filepath.Join(t.TempDir(), "events.db"), 2),
+		"memory": cloudevents.NewMemoryBuffer(2),
+	}
+
+	for name, buffer := range buffers {
+		t.Run(name, func(t *testing.T) {
+			_, _, found, err := buffer.Oldest()
+			require.NoError(t, err)
+			assert.False(t, found)
+
+			for _, id := range []string{"1", "2"} {
+				require.NoError(t, buffer.Append(cloudevents.Event{ID: id}))
+			}
+
+			assert.ErrorIs(t, buffer.Append(cloudevents.Event{ID: "3"}), cloudevents.ErrBufferFull, "should refuse events once full")
+
+			key, event, found, err := buffer.Oldest()
+			require.NoError(t, err)
+			require.True(t, found)
+			assert.Equal(t, "1", event.ID, "buffered events should be kept")
+
+			require.NoError(t, buffer.Remove(key))
+			require.NoError(t, buffer.Append(cloudevents.Event{ID: "3"}))
+
+			_, event, found, err = buffer.Oldest()
+			require.NoError(t, err)
+			require.True(t, found)
+			assert.Equal(t, "2", event.ID)
+		})
+	}
+}
+
+func TestBoltBuffer_Reopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.db")
+
+	buffer, err := cloudevents.NewBoltBuffer(path, 10)
+	require.NoError(t, err)
+
+	require.NoError(t, buffer.Append(cloudevents.Event{ID: "1", Data: []byte(`{"phase":"Failed"}`)}))
+	require.NoError(t, buffer.Close())
+
+	buffer = newBoltBuffer(t, path, 10)
+
+	_, event, found, err := buffer.Oldest()
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "1", event.ID)
+	assert.JSONEq(t, `{"phase":"Failed"}`, string(event.Data))
+}
+
+func TestBoltBuffer_ReopenFull(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.db")
+
+	buffer, err := cloudevents.NewBoltBuffer(path, 2)
+	require.NoError(t, err)
+
+	for _, id := range []string{"1", "2"} {
+		require.NoError(t, buffer.Append(cloudevents.Event{ID: id}))
+	}
+
+	require.NoError(t, buffer.Close())
+
+	buffer = newBoltBuffer(t, path, 2)
+
+	assert.ErrorIs(t, buffer.Append(cloudevents.Event{ID: "3"}), cloudevents.ErrBufferFull, "should count the events buffered before reopening")
+
+	_, event, _, err := buffer.Oldest()
+	require.NoError(t, err)
+	assert.Equal(t, "1", event.ID)
+}
+
+func TestBuffer_Progress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.db")
+
+	buffers := map[string]cloudevents.Buffer{
+		"bolt":   newBoltBuffer(t, path, 2),
+		"memory": cloudevents.NewMemoryBuffer(2),
+	}
+
+	for name, buffer := range buffers {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, buffer.SetProgress("Default/test/podinfo", &cloudevents.Progress{Phase: "Progressing", Weight: 10}))
+			require.NoError(t, buffer.SetProgress("Default/test/backend", &cloudevents.Progress{Phase: "Succeeded"}))
+			require.NoError(t, buffer.SetProgress("Default/test/backend", nil))
+
+			progress, err := buffer.Progress()
+			require.NoError(t, err)
+			assert.Equal(t, map[string]cloudevents.Progress{
+				"Default/test/podinfo": {Phase: "Progressing", Weight: 10},
+			}, progress)
+		})
+	}
+
+	require.NoError(t, buffers["bolt"].Close())
+
+	progress, err := newBoltBuffer(t, path, 2).Progress()
+	require.NoError(t, err)
+	assert.Len(t, progress, 1, "progress should survive reopening")
+}
+
+func newBoltBuffer(t *testing.T, path string, maxEvents int) cloudevents.Buffer {
+	buffer, err := cloudevents.NewBoltBuffer(path, maxEvents)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		buffer.Close()
+	})
+
+	return buffer
+}
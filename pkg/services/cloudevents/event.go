@@ -0,0 +1,100 @@
+package cloudevents
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// specVersion is the version of the CloudEvents specification implemented.
+const specVersion = "1.0"
+
+// Types of the events of Canaries.
+const (
+	PhaseChangedType  = "works.weave.progressive-delivery.canary.phase-changed"
+	WeightChangedType = "works.weave.progressive-delivery.canary.weight-changed"
+	RolledBackType    = "works.weave.progressive-delivery.canary.rolled-back"
+	PromotedType      = "works.weave.progressive-delivery.canary.promoted"
+)
+
+// Mode is how events are encoded in HTTP requests.
+type Mode string
+
+const (
+	// BinaryMode sends the attributes as ce- headers and the data as body.
+	BinaryMode Mode = "binary"
+	// StructuredMode sends the whole event as a JSON body.
+	StructuredMode Mode = "structured"
+)
+
+// Event is a CloudEvent with JSON data.
+type Event struct {
+	ID      string          `json:"id"`
+	Source  string          `json:"source"`
+	Type    string          `json:"type"`
+	Subject string          `json:"subject,omitempty"`
+	Time    time.Time       `json:"time"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// CanaryData is the data of the events of Canaries.
+type CanaryData struct {
+	// Canary is the Canary converted the same way the API returns it, so the
+	// promoted image versions are included.
+	Canary         json.RawMessage `json:"canary"`
+	PreviousPhase  string          `json:"previousPhase,omitempty"`
+	Phase          string          `json:"phase"`
+	PreviousWeight int             `json:"previousWeight"`
+	Weight         int             `json:"weight"`
+}
+
+// NewRequest returns a request posting the event to the sink.
+func NewRequest(sink string, mode Mode, event Event) (*http.Request, error) {
+	var (
+		body        []byte
+		contentType string
+		err         error
+	)
+
+	switch mode {
+	case StructuredMode:
+		contentType = "application/cloudevents+json"
+
+		body, err = json.Marshal(struct {
+			SpecVersion     string `json:"specversion"`
+			DataContentType string `json:"datacontenttype"`
+			Event
+		}{specVersion, "application/json", event})
+		if err != nil {
+			return nil, fmt.Errorf("encoding event: %w", err)
+		}
+	case BinaryMode, "":
+		contentType = "application/json"
+		body = event.Data
+	default:
+		return nil, fmt.Errorf("unknown mode %q", mode)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, sink, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	if mode != StructuredMode {
+		req.Header.Set("ce-specversion", specVersion)
+		req.Header.Set("ce-id", event.ID)
+		req.Header.Set("ce-source", event.Source)
+		req.Header.Set("ce-type", event.Type)
+		req.Header.Set("ce-time", event.Time.UTC().Format(time.RFC3339Nano))
+
+		if event.Subject != "" {
+			req.Header.Set("ce-subject", event.Subject)
+		}
+	}
+
+	return req, nil
+}
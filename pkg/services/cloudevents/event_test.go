@@ -0,0 +1,65 @@
+package cloudevents_test
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/progressive-delivery/pkg/services/cloudevents"
+)
+
+func testEvent() cloudevents.Event {
+	return cloudevents.Event{
+		ID:      "Default/payments/backend/42/works.weave.progressive-delivery.canary.promoted",
+		Source:  "/progressive-delivery/clusters/Default",
+		Type:    cloudevents.PromotedType,
+		Subject: "canaries/payments/backend",
+		Time:    time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC),
+		Data:    json.RawMessage(`{"phase":"Succeeded"}`),
+	}
+}
+
+func TestNewRequest_Binary(t *testing.T) {
+	req, err := cloudevents.NewRequest("http://sink.example", cloudevents.BinaryMode, testEvent())
+	require.NoError(t, err)
+
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	assert.Equal(t, "1.0", req.Header.Get("ce-specversion"))
+	assert.Equal(t, cloudevents.PromotedType, req.Header.Get("ce-type"))
+	assert.Equal(t, "/progressive-delivery/clusters/Default", req.Header.Get("ce-source"))
+	assert.Equal(t, "canaries/payments/backend", req.Header.Get("ce-subject"))
+	assert.Equal(t, "2023-05-01T12:00:00Z", req.Header.Get("ce-time"))
+
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"phase":"Succeeded"}`, string(body))
+}
+
+func TestNewRequest_Structured(t *testing.T) {
+	req, err := cloudevents.NewRequest("http://sink.example", cloudevents.StructuredMode, testEvent())
+	require.NoError(t, err)
+
+	assert.Equal(t, "application/cloudevents+json", req.Header.Get("Content-Type"))
+	assert.Empty(t, req.Header.Get("ce-type"))
+
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"specversion": "1.0",
+		"datacontenttype": "application/json",
+		"id": "Default/payments/backend/42/works.weave.progressive-delivery.canary.promoted",
+		"source": "/progressive-delivery/clusters/Default",
+		"type": "works.weave.progressive-delivery.canary.promoted",
+		"subject": "canaries/payments/backend",
+		"time": "2023-05-01T12:00:00Z",
+		"data": {"phase": "Succeeded"}
+	}`, string(body))
+}
+
+func TestNewRequest_UnknownMode(t *testing.T) {
+	_, err := cloudevents.NewRequest("http://sink.example", cloudevents.Mode("batched"), testEvent())
+	assert.Error(t, err)
+}
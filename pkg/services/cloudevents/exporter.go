@@ -0,0 +1,377 @@
+package cloudevents
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/convert"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"google.golang.org/protobuf/encoding/protojson"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// Delivery settings, retries of an event back off up to maxBackoff.
+const (
+	deliveryTimeout = 10 * time.Second
+	minBackoff      = time.Second
+	maxBackoff      = time.Minute
+)
+
+// Overflow is what the exporter does with new events once the buffer is full.
+type Overflow string
+
+const (
+	// BlockOverflow waits for delivered events to make room, Canary events
+	// are then observed as fast as the sink accepts them.
+	BlockOverflow Overflow = "block"
+	// DropOverflow drops the new events, they're counted in the dropped
+	// events metric.
+	DropOverflow Overflow = "drop"
+)
+
+type Options struct {
+	// Sink is the URL events are posted to.
+	Sink string
+	Mode Mode
+	// Overflow defaults to BlockOverflow.
+	Overflow Overflow
+}
+
+// Exporter publishes the lifecycle changes of Canaries as CloudEvents. Events are buffered until the sink accepts them, so
+// they're delivered at least once, they may be delivered more than once. The
+// ID of an event is the same each time it's delivered.
+type Exporter struct {
+	// progress is the last progress seen, by cluster, namespace and name. It's
+	// saved in the buffer to be compared to after a restart.
+	progress map[string]Progress
+	// wake is signalled when an event is buffered.
+	wake chan struct{}
+	// removed is signalled when an event is removed from the buffer, making
+	// room for blocked events.
+	removed chan struct{}
+	// dropped counts the events dropped because the buffer was full.
+	dropped prometheus.Counter
+
+	opts            Options
+	buffer          Buffer
+	httpClient      *http.Client
+	minBackoff      time.Duration
+	maxBackoff      time.Duration
+	flagger         flagger.Fetcher
+	clustersManager clustersmngr.ClustersManager
+	logger          logr.Logger
+
+	// toProto converts a Canary for the data of its events.
+	toProto func(ctx context.Context, clusterName string, canary flaggerv1.Canary) (*pb.Canary, error)
+}
+
+func NewExporter(opts Options, buffer Buffer, flaggerService flagger.Fetcher, clustersManager clustersmngr.ClustersManager, logger logr.Logger) *Exporter {
+	if opts.Overflow == "" {
+		opts.Overflow = BlockOverflow
+	}
+
+	exporter := &Exporter{
+		progress: map[string]Progress{},
+		wake:     make(chan struct{}, 1),
+		removed:  make(chan struct{}, 1),
+		dropped: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "progressive_delivery_cloudevents_dropped_total",
+			Help: "Number of CloudEvents dropped because the buffer was full.",
+		}),
+		opts:            opts,
+		buffer:          buffer,
+		httpClient:      &http.Client{Timeout: deliveryTimeout},
+		minBackoff:      minBackoff,
+		maxBackoff:      maxBackoff,
+		flagger:         flaggerService,
+		clustersManager: clustersManager,
+		logger:          logger,
+	}
+
+	exporter.toProto = exporter.canaryToProto
+
+	return exporter
+}
+
+// Collector returns the metrics of the exporter, they're registered by the
+// caller.
+func (e *Exporter) Collector() prometheus.Collector {
+	return e.dropped
+}
+
+// Start publishes the events of the changes of Canaries until the channel
+// is closed, and delivers them until ctx is done. Canaries are compared to the
+// progress saved in the buffer, so the changes made while the exporter was
+// down are published once it's back. The progress a Canary has when it's
+// first seen is not published, there is nothing to compare it to. Events
+// buffered before a restart are delivered.
+func (e *Exporter) Start(ctx context.Context, events <-chan flagger.CanaryEvent) {
+	progress, err := e.buffer.Progress()
+	if err != nil {
+		e.logger.Error(err, "failed reading saved canary progress")
+	} else {
+		e.progress = progress
+	}
+
+	go e.deliver(ctx)
+
+	for event := range events {
+		if err := e.observe(ctx, event); err != nil {
+			e.logger.Error(err, "failed buffering cloudevents", "cluster", event.ClusterName, "canary", event.Canary.GetName(), "namespace", event.Canary.GetNamespace())
+		}
+	}
+}
+
+// observe buffers the events of the changes of the Canary since it was last
+// seen, and saves its progress once they're buffered.
+func (e *Exporter) observe(ctx context.Context, event flagger.CanaryEvent) error {
+	canary := event.Canary
+	key := fmt.Sprintf("%s/%s/%s", event.ClusterName, canary.GetNamespace(), canary.GetName())
+
+	current := Progress{Phase: string(canary.Status.Phase), Weight: canary.Status.CanaryWeight}
+
+	previous, seen := e.progress[key]
+
+	if event.Type == watch.Deleted {
+		if !seen {
+			return nil
+		}
+
+		delete(e.progress, key)
+
+		return e.buffer.SetProgress(key, nil)
+	}
+
+	if current.Phase == "" || (seen && previous == current) {
+		return nil
+	}
+
+	if seen {
+		if err := e.append(ctx, event.ClusterName, key, canary, previous, current); err != nil {
+			return err
+		}
+	}
+
+	e.progress[key] = current
+
+	return e.buffer.SetProgress(key, &current)
+}
+
+// append buffers the events of the change of the Canary from previous to
+// current.
+func (e *Exporter) append(ctx context.Context, clusterName, key string, canary flaggerv1.Canary, previous, current Progress) error {
+	types := []string{}
+
+	if current.Phase != previous.Phase {
+		types = append(types, PhaseChangedType)
+
+		switch flaggerv1.CanaryPhase(current.Phase) {
+		case flaggerv1.CanaryPhaseFailed:
+			types = append(types, RolledBackType)
+		case flaggerv1.CanaryPhaseSucceeded:
+			types = append(types, PromotedType)
+		}
+	}
+
+	if current.Weight != previous.Weight {
+		types = append(types, WeightChangedType)
+	}
+
+	pbCanary, err := e.toProto(ctx, clusterName, canary)
+	if err != nil {
+		return err
+	}
+
+	canaryJSON, err := protojson.Marshal(pbCanary)
+	if err != nil {
+		return fmt.Errorf("encoding canary: %w", err)
+	}
+
+	data, err := json.Marshal(CanaryData{
+		Canary:         canaryJSON,
+		PreviousPhase:  previous.Phase,
+		Phase:          current.Phase,
+		PreviousWeight: previous.Weight,
+		Weight:         current.Weight,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding event data: %w", err)
+	}
+
+	timestamp := canary.Status.LastTransitionTime.Time
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	for _, eventType := range types {
+		err := e.enqueue(ctx, Event{
+			ID:      fmt.Sprintf("%s/%s/%s", key, canary.GetResourceVersion(), eventType),
+			Source:  fmt.Sprintf("/progressive-delivery/clusters/%s", clusterName),
+			Type:    eventType,
+			Subject: fmt.Sprintf("canaries/%s/%s", canary.GetNamespace(), canary.GetName()),
+			Time:    timestamp,
+			Data:    data,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// enqueue buffers the event. Once the buffer is full, it waits for delivered
+// events to make room, or drops the event with DropOverflow. Events still
+// waiting when ctx is done are dropped.
+func (e *Exporter) enqueue(ctx context.Context, event Event) error {
+	for {
+		err := e.buffer.Append(event)
+		if !errors.Is(err, ErrBufferFull) {
+			if err == nil {
+				e.signal(e.wake)
+			}
+
+			return err
+		}
+
+		if e.opts.Overflow == DropOverflow {
+			e.dropped.Inc()
+			e.logger.Error(err, "dropped cloudevent", "id", event.ID, "type", event.Type)
+
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			e.dropped.Inc()
+
+			return fmt.Errorf("dropped cloudevent %s: %w", event.ID, ctx.Err())
+		case <-e.removed:
+		}
+	}
+}
+
+// signal wakes up the goroutine waiting on ch, if any.
+func (e *Exporter) signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// deliver sends the buffered events in order until ctx is done. Events are
+// removed once the sink accepts them, or rejects them as invalid.
+func (e *Exporter) deliver(ctx context.Context) {
+	backoff := e.minBackoff
+
+	for {
+		key, event, found, err := e.buffer.Oldest()
+
+		switch {
+		case err != nil:
+			e.logger.Error(err, "failed reading cloudevents buffer")
+		case !found:
+			select {
+			case <-ctx.Done():
+				return
+			case <-e.wake:
+			}
+
+			continue
+		default:
+			retry, err := e.send(ctx, event)
+			if err == nil || !retry {
+				if err != nil {
+					e.logger.Error(err, "sink rejected cloudevent, dropping it", "id", event.ID, "type", event.Type)
+				}
+
+				if err := e.buffer.Remove(key); err != nil {
+					e.logger.Error(err, "failed removing cloudevent from buffer", "id", event.ID)
+				}
+
+				e.signal(e.removed)
+
+				backoff = e.minBackoff
+
+				continue
+			}
+
+			e.logger.V(1).Info("failed sending cloudevent, retrying", "id", event.ID, "error", err.Error(), "backoff", backoff)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		if backoff *= 2; backoff > e.maxBackoff {
+			backoff = e.maxBackoff
+		}
+	}
+}
+
+// send posts the event once, it returns whether a failure is worth retrying:
+// network errors, throttling and server errors.
+func (e *Exporter) send(ctx context.Context, event Event) (bool, error) {
+	req, err := NewRequest(e.opts.Sink, e.opts.Mode, event)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := e.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+
+	return retry, fmt.Errorf("sink responded with %d", resp.StatusCode)
+}
+
+// canaryToProto converts the Canary with its target, primary and
+// MetricTemplates, read with the permissions of the server. Only the objects
+// of the Canary are read, missing ones are left empty.
+func (e *Exporter) canaryToProto(ctx context.Context, clusterName string, canary flaggerv1.Canary) (*pb.Canary, error) {
+	clusterClient, err := e.clustersManager.GetServerClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	target, _ := e.flagger.FetchTargetRef(ctx, clusterName, clusterClient, &canary)
+
+	promoted, _ := e.flagger.FetchPromoted(ctx, clusterName, clusterClient, &canary)
+
+	templates := []flaggerv1.MetricTemplate{}
+
+	for _, ref := range flagger.MetricTemplateRefs(canary) {
+		template, err := e.flagger.GetMetricTemplate(ctx, clusterName, clusterClient, ref.Name, ref.Namespace)
+		if err != nil {
+			continue
+		}
+
+		templates = append(templates, template)
+	}
+
+	pbCanary := convert.FlaggerCanaryToProto(canary, clusterName, target, promoted, templates)
+	pbCanary.DeploymentStrategy = string(e.flagger.DeploymentStrategyFor(canary))
+
+	return pbCanary, nil
+}
@@ -0,0 +1,263 @@
+package cloudevents
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// sink records the types of the events it receives, failing the first
+// failures requests with status.
+type sink struct {
+	mu       sync.Mutex
+	types    []string
+	requests int
+	failures int
+	status   int
+}
+
+func (s *sink) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+
+	if s.requests <= s.failures {
+		w.WriteHeader(s.status)
+		return
+	}
+
+	s.types = append(s.types, r.Header.Get("ce-type"))
+}
+
+func (s *sink) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string{}, s.types...)
+}
+
+func newTestExporter(t *testing.T, s *sink) *Exporter {
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+
+	exporter := NewExporter(Options{Sink: server.URL, Mode: BinaryMode}, NewMemoryBuffer(10), nil, nil, logr.Discard())
+	exporter.minBackoff = time.Millisecond
+	exporter.maxBackoff = time.Millisecond
+	exporter.toProto = func(_ context.Context, clusterName string, canary flaggerv1.Canary) (*pb.Canary, error) {
+		return &pb.Canary{Name: canary.GetName(), Namespace: canary.GetNamespace(), ClusterName: clusterName}, nil
+	}
+
+	return exporter
+}
+
+func canaryEvent(eventType watch.EventType, phase flaggerv1.CanaryPhase, weight int) flagger.CanaryEvent {
+	canary := flaggerv1.Canary{ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "payments"}}
+	canary.Status.Phase = phase
+	canary.Status.CanaryWeight = weight
+
+	return flagger.CanaryEvent{Type: eventType, ClusterName: "Default", Canary: canary}
+}
+
+func bufferedTypes(t *testing.T, buffer Buffer) []string {
+	types := []string{}
+
+	for {
+		key, event, found, err := buffer.Oldest()
+		require.NoError(t, err)
+
+		if !found {
+			return types
+		}
+
+		types = append(types, event.Type)
+
+		require.NoError(t, buffer.Remove(key))
+	}
+}
+
+func TestExporter_Observe(t *testing.T) {
+	exporter := newTestExporter(t, &sink{})
+	ctx := context.Background()
+
+	events := []flagger.CanaryEvent{
+		// The first progress seen is not a change.
+		canaryEvent(watch.Added, flaggerv1.CanaryPhaseProgressing, 0),
+		canaryEvent(watch.Modified, flaggerv1.CanaryPhaseProgressing, 0),
+		canaryEvent(watch.Modified, flaggerv1.CanaryPhaseProgressing, 10),
+		canaryEvent(watch.Modified, flaggerv1.CanaryPhaseFailed, 0),
+		canaryEvent(watch.Modified, flaggerv1.CanaryPhaseSucceeded, 0),
+		// Deleted Canaries are seen again from scratch.
+		canaryEvent(watch.Deleted, flaggerv1.CanaryPhaseSucceeded, 0),
+		canaryEvent(watch.Added, flaggerv1.CanaryPhaseFailed, 0),
+	}
+
+	for _, event := range events {
+		require.NoError(t, exporter.observe(ctx, event))
+	}
+
+	assert.Equal(t, []string{
+		WeightChangedType,
+		PhaseChangedType, RolledBackType, WeightChangedType,
+		PhaseChangedType, PromotedType,
+	}, bufferedTypes(t, exporter.buffer))
+}
+
+func TestExporter_Start_SavedProgress(t *testing.T) {
+	// The sink is down, so the events stay buffered.
+	exporter := newTestExporter(t, &sink{failures: 1 << 30, status: http.StatusServiceUnavailable})
+	require.NoError(t, exporter.buffer.SetProgress("Default/payments/backend", &Progress{Phase: "Progressing", Weight: 10}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The Canary failed while the exporter was down.
+	events := make(chan flagger.CanaryEvent, 1)
+	events <- canaryEvent(watch.Added, flaggerv1.CanaryPhaseFailed, 0)
+	close(events)
+
+	exporter.Start(ctx, events)
+
+	assert.Contains(t, bufferedTypes(t, exporter.buffer), RolledBackType)
+
+	progress, err := exporter.buffer.Progress()
+	require.NoError(t, err)
+	assert.Equal(t, Progress{Phase: "Failed"}, progress["Default/payments/backend"])
+}
+
+func TestExporter_ObserveData(t *testing.T) {
+	exporter := newTestExporter(t, &sink{})
+	ctx := context.Background()
+
+	require.NoError(t, exporter.observe(ctx, canaryEvent(watch.Added, flaggerv1.CanaryPhaseFinalising, 0)))
+	require.NoError(t, exporter.observe(ctx, canaryEvent(watch.Modified, flaggerv1.CanaryPhaseSucceeded, 0)))
+
+	_, event, found, err := exporter.buffer.Oldest()
+	require.NoError(t, err)
+	require.True(t, found)
+
+	assert.Equal(t, "/progressive-delivery/clusters/Default", event.Source)
+	assert.Equal(t, "canaries/payments/backend", event.Subject)
+
+	data := CanaryData{}
+	require.NoError(t, json.Unmarshal(event.Data, &data))
+	assert.Equal(t, "Finalising", data.PreviousPhase)
+	assert.Equal(t, "Succeeded", data.Phase)
+	assert.JSONEq(t, `{"name": "backend", "namespace": "payments", "clusterName": "Default"}`, string(data.Canary))
+}
+
+func TestExporter_Deliver(t *testing.T) {
+	s := &sink{failures: 2, status: http.StatusServiceUnavailable}
+	exporter := newTestExporter(t, s)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go exporter.deliver(ctx)
+
+	require.NoError(t, exporter.observe(ctx, canaryEvent(watch.Added, flaggerv1.CanaryPhaseProgressing, 0)))
+	require.NoError(t, exporter.observe(ctx, canaryEvent(watch.Modified, flaggerv1.CanaryPhaseFailed, 0)))
+
+	assert.Eventually(t, func() bool {
+		return len(s.received()) == 2
+	}, 5*time.Second, 10*time.Millisecond, "should retry server errors")
+	assert.Equal(t, []string{PhaseChangedType, RolledBackType}, s.received())
+
+	_, _, found, err := exporter.buffer.Oldest()
+	require.NoError(t, err)
+	assert.False(t, found, "delivered events should be removed")
+}
+
+func TestExporter_Deliver_ClientError(t *testing.T) {
+	s := &sink{failures: 1, status: http.StatusBadRequest}
+	exporter := newTestExporter(t, s)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go exporter.deliver(ctx)
+
+	require.NoError(t, exporter.observe(ctx, canaryEvent(watch.Added, flaggerv1.CanaryPhaseProgressing, 0)))
+	require.NoError(t, exporter.observe(ctx, canaryEvent(watch.Modified, flaggerv1.CanaryPhaseFailed, 0)))
+
+	assert.Eventually(t, func() bool {
+		return len(s.received()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{RolledBackType}, s.received(), "should drop events rejected as invalid")
+}
+
+func TestExporter_Overflow(t *testing.T) {
+	// The sink is down, so the events stay buffered.
+	newFullExporter := func(t *testing.T, overflow Overflow) *Exporter {
+		exporter := newTestExporter(t, &sink{failures: 1 << 30, status: http.StatusServiceUnavailable})
+		exporter.opts.Overflow = overflow
+		exporter.buffer = NewMemoryBuffer(1)
+
+		require.NoError(t, exporter.observe(context.Background(), canaryEvent(watch.Added, flaggerv1.CanaryPhaseProgressing, 0)))
+		require.NoError(t, exporter.observe(context.Background(), canaryEvent(watch.Modified, flaggerv1.CanaryPhaseProgressing, 10)))
+
+		return exporter
+	}
+
+	t.Run("drop", func(t *testing.T) {
+		exporter := newFullExporter(t, DropOverflow)
+
+		require.NoError(t, exporter.observe(context.Background(), canaryEvent(watch.Modified, flaggerv1.CanaryPhaseProgressing, 20)))
+
+		assert.Equal(t, 1.0, testutil.ToFloat64(exporter.dropped))
+		assert.Equal(t, []string{WeightChangedType}, bufferedTypes(t, exporter.buffer), "buffered events should be kept")
+	})
+
+	t.Run("block", func(t *testing.T) {
+		exporter := newFullExporter(t, BlockOverflow)
+
+		done := make(chan error)
+		go func() {
+			done <- exporter.observe(context.Background(), canaryEvent(watch.Modified, flaggerv1.CanaryPhaseProgressing, 20))
+		}()
+
+		select {
+		case <-done:
+			t.Fatal("observe should block until there's room in the buffer")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		key, _, _, err := exporter.buffer.Oldest()
+		require.NoError(t, err)
+		require.NoError(t, exporter.buffer.Remove(key))
+		exporter.signal(exporter.removed)
+
+		require.NoError(t, <-done)
+		assert.Equal(t, 0.0, testutil.ToFloat64(exporter.dropped))
+		assert.Equal(t, []string{WeightChangedType}, bufferedTypes(t, exporter.buffer))
+	})
+
+	t.Run("block until done", func(t *testing.T) {
+		exporter := newFullExporter(t, BlockOverflow)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := exporter.observe(ctx, canaryEvent(watch.Modified, flaggerv1.CanaryPhaseProgressing, 20))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 1.0, testutil.ToFloat64(exporter.dropped))
+
+		progress, err := exporter.buffer.Progress()
+		require.NoError(t, err)
+		assert.Equal(t, 10, progress["Default/payments/backend"].Weight, "the progress of dropped events shouldn't be saved")
+	})
+}
@@ -1,3 +1,7 @@
 package crd
 
 const FlaggerCRDName = "canaries.flagger.app"
+
+const ArgoRolloutsCRDName = "rollouts.argoproj.io"
+
+const GatewayAPICRDName = "httproutes.gateway.networking.k8s.io"
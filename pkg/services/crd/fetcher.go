@@ -2,110 +2,373 @@ package crd
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
 	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster"
 	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-const watchCRDsFrequency = 30 * time.Second
+// watchRetryInterval is how long to wait before listing the CRDs of a cluster
+// again after a failure.
+const watchRetryInterval = 30 * time.Second
+
+// listTimeout is how long NewFetcher and UpdateCRDList wait for the CRDs to be
+// listed, unreachable clusters don't block them any longer.
+const listTimeout = 30 * time.Second
 
 type Fetcher interface {
 	IsAvailable(clusterName, name string) bool
 	IsAvailableOnClusters(name string) map[string]bool
+	// Get returns the CRD of the given name, false if it's not installed on
+	// the cluster.
+	Get(clusterName, name string) (v1.CustomResourceDefinition, bool)
+	// UpdateCRDList lists the CRDs again right away, instead of waiting for
+	// changes to be seen.
 	UpdateCRDList()
 }
 
+// NewFetcher returns a Fetcher tracking the CRDs of every cluster of the
+// clusters manager with a watch, until ctx is done. Clusters are tracked as
+// they're added and forgotten as they're removed. It returns once the CRDs of
+// the current clusters are listed, or after listTimeout.
 func NewFetcher(ctx context.Context, logger logr.Logger, clustersManager clustersmngr.ClustersManager) Fetcher {
 	fetcher := &defaultFetcher{
 		logger:          logger,
 		clustersManager: clustersManager,
-		crds:            map[string][]v1.CustomResourceDefinition{},
+		clusters:        map[string]*clusterCRDs{},
+	}
+
+	watcher := clustersManager.Subscribe()
+
+	for _, cl := range clustersManager.GetClusters() {
+		fetcher.addCluster(ctx, cl)
 	}
 
-	go fetcher.watchCRDs(ctx)
+	go fetcher.trackClusters(ctx, watcher)
+
+	fetcher.waitListed(ctx)
 
 	return fetcher
 }
 
 type defaultFetcher struct {
-	sync.RWMutex
+	mu              sync.RWMutex
 	logger          logr.Logger
 	clustersManager clustersmngr.ClustersManager
-	crds            map[string][]v1.CustomResourceDefinition
+	clusters        map[string]*clusterCRDs
+}
+
+// clusterCRDs are the CRDs of a cluster by name, cancel stops their watch.
+// They're only written by the watch.
+type clusterCRDs struct {
+	crds   map[string]v1.CustomResourceDefinition
+	cancel context.CancelFunc
+	// listed is closed once the CRDs are listed the first time.
+	listed chan struct{}
+	// relist receives requests to list the CRDs again, the channel of a
+	// request is closed once they are.
+	relist chan chan struct{}
+}
+
+func (s *defaultFetcher) trackClusters(ctx context.Context, watcher *clustersmngr.ClustersWatcher) {
+	defer watcher.Unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.mu.Lock()
+			for name, cc := range s.clusters {
+				cc.cancel()
+				delete(s.clusters, name)
+			}
+			s.mu.Unlock()
+
+			return
+		case update := <-watcher.Updates:
+			for _, cl := range update.Removed {
+				s.removeCluster(cl.GetName())
+			}
+
+			for _, cl := range update.Added {
+				s.addCluster(ctx, cl)
+			}
+		}
+	}
 }
 
-func (s *defaultFetcher) watchCRDs(ctx context.Context) {
-	_ = wait.PollImmediateInfinite(watchCRDsFrequency, func() (bool, error) {
-		s.UpdateCRDList()
+func (s *defaultFetcher) addCluster(ctx context.Context, cl cluster.Cluster) {
+	ctx, cancel := context.WithCancel(ctx)
+	cc := &clusterCRDs{
+		crds:   map[string]v1.CustomResourceDefinition{},
+		cancel: cancel,
+		listed: make(chan struct{}),
+		relist: make(chan chan struct{}, 1),
+	}
+
+	s.mu.Lock()
+	if previous, ok := s.clusters[cl.GetName()]; ok {
+		previous.cancel()
+	}
+	s.clusters[cl.GetName()] = cc
+	s.mu.Unlock()
 
-		return false, nil
-	})
+	go s.watchCluster(ctx, cl, cc)
 }
 
-func (s *defaultFetcher) UpdateCRDList() {
-	s.Lock()
-	defer s.Unlock()
+func (s *defaultFetcher) removeCluster(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if cc, ok := s.clusters[name]; ok {
+		cc.cancel()
+		delete(s.clusters, name)
+	}
+}
 
-	ctx := context.Background()
+// watchCluster lists the CRDs of the cluster, then applies the changes of a
+// watch started from the version of the list, until ctx is done. They're
+// listed again if the watch can't be resumed.
+func (s *defaultFetcher) watchCluster(ctx context.Context, cl cluster.Cluster, cc *clusterCRDs) {
+	for {
+		err := s.listAndWatch(ctx, cl, cc)
 
-	client, err := s.clustersManager.GetServerClient(ctx)
+		switch {
+		case err == nil:
+		case k8serrors.IsResourceExpired(err), k8serrors.IsGone(err):
+			// The watch is too old to resume, the CRDs are listed again.
+			continue
+		default:
+			s.logger.Error(err, "failed watching crds", "cluster", cl.GetName())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRetryInterval):
+		}
+	}
+}
+
+// listAndWatch lists the CRDs of the cluster and watches them from the version
+// of the list, until ctx is done. They're listed again when it's requested.
+func (s *defaultFetcher) listAndWatch(ctx context.Context, cl cluster.Cluster, cc *clusterCRDs) error {
+	cfg, err := cl.GetServerConfig()
 	if err != nil {
-		s.logger.Error(err, "unable to get client pool")
+		return err
+	}
 
-		return
+	watchClient, err := client.NewWithWatch(cfg, client.Options{Scheme: kube.CreateScheme()})
+	if err != nil {
+		return fmt.Errorf("failed creating watch client: %w", err)
 	}
 
-	for clusterName, client := range client.ClientsPool().Clients() {
+	// requested is the relist request to close once the CRDs are listed.
+	var requested chan struct{}
+
+	for {
 		crdList := &v1.CustomResourceDefinitionList{}
+		if err := watchClient.List(ctx, crdList); err != nil {
+			return fmt.Errorf("unable to list crds: %w", err)
+		}
+
+		crds := map[string]v1.CustomResourceDefinition{}
+		for _, crd := range crdList.Items {
+			crds[crd.Name] = crd
+		}
 
-		s.crds[clusterName] = []v1.CustomResourceDefinition{}
+		s.mu.Lock()
+		cc.crds = crds
+		s.mu.Unlock()
 
-		err := client.List(ctx, crdList)
-		if err != nil {
-			s.logger.Error(err, "unable to list crds", "cluster", clusterName)
+		select {
+		case <-cc.listed:
+		default:
+			close(cc.listed)
+		}
 
-			continue
+		if requested != nil {
+			close(requested)
 		}
 
-		s.crds[clusterName] = crdList.Items
+		resourceVersion := crdList.ResourceVersion
+
+		for {
+			w, err := watchClient.Watch(ctx, &v1.CustomResourceDefinitionList{}, &client.ListOptions{
+				Raw: &metav1.ListOptions{ResourceVersion: resourceVersion, AllowWatchBookmarks: true},
+			})
+			if err != nil {
+				return fmt.Errorf("unable to watch crds: %w", err)
+			}
+
+			resourceVersion, requested, err = s.applyEvents(ctx, w, cc, resourceVersion)
+			if err != nil {
+				return err
+			}
+
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			if requested != nil {
+				break
+			}
+		}
 	}
 }
 
-func (s *defaultFetcher) IsAvailable(clusterName, name string) bool {
-	s.Lock()
-	defer s.Unlock()
+// applyEvents applies the events of the watch to the CRDs of the cluster until
+// it's closed or a relist is requested. It returns the last resource version
+// seen to resume from, and the relist request if there was one.
+func (s *defaultFetcher) applyEvents(ctx context.Context, w watch.Interface, cc *clusterCRDs, resourceVersion string) (string, chan struct{}, error) {
+	defer w.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion, nil, nil
+		case done := <-cc.relist:
+			return resourceVersion, done, nil
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return resourceVersion, nil, nil
+			}
+
+			if event.Type == watch.Error {
+				return resourceVersion, nil, k8serrors.FromObject(event.Object)
+			}
 
-	for _, crd := range s.crds[clusterName] {
-		if crd.Name == name {
-			return true
+			crd, ok := event.Object.(*v1.CustomResourceDefinition)
+			if !ok {
+				continue
+			}
+
+			resourceVersion = crd.ResourceVersion
+
+			s.mu.Lock()
+			switch event.Type {
+			case watch.Added, watch.Modified:
+				cc.crds[crd.Name] = *crd
+			case watch.Deleted:
+				delete(cc.crds, crd.Name)
+			}
+			s.mu.Unlock()
 		}
 	}
+}
+
+// waitListed waits until the CRDs of every cluster are listed, or until
+// listTimeout.
+func (s *defaultFetcher) waitListed(ctx context.Context) {
+	s.mu.RLock()
+	listed := map[string]chan struct{}{}
+	for name, cc := range s.clusters {
+		listed[name] = cc.listed
+	}
+	s.mu.RUnlock()
+
+	timeout := time.NewTimer(listTimeout)
+	defer timeout.Stop()
 
-	return false
+	for name, done := range listed {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return
+		case <-timeout.C:
+			s.logger.Info("timed out waiting for the crds to be listed", "cluster", name)
+			return
+		}
+	}
+}
+
+// UpdateCRDList asks the watch of every cluster to list the CRDs again right
+// away, and waits until they are, or until listTimeout.
+func (s *defaultFetcher) UpdateCRDList() {
+	s.mu.RLock()
+	requested := map[string]chan struct{}{}
+	for name, cc := range s.clusters {
+		done := make(chan struct{})
+
+		select {
+		case cc.relist <- done:
+			requested[name] = done
+		default:
+			// A relist is already requested.
+		}
+	}
+	s.mu.RUnlock()
+
+	timeout := time.NewTimer(listTimeout)
+	defer timeout.Stop()
+
+	for name, done := range requested {
+		select {
+		case <-done:
+		case <-timeout.C:
+			s.logger.Info("timed out waiting for the crds to be listed", "cluster", name)
+			return
+		}
+	}
+}
+
+func (s *defaultFetcher) IsAvailable(clusterName, name string) bool {
+	_, found := s.Get(clusterName, name)
+
+	return found
 }
 
 func (s *defaultFetcher) IsAvailableOnClusters(name string) map[string]bool {
 	result := map[string]bool{}
 
-	s.Lock()
-	defer s.Unlock()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	for clusterName, crds := range s.crds {
-		// Set this to be sure the key is there with false value if the following
-		// look does not say it's there.
-		result[clusterName] = false
+	for clusterName, cc := range s.clusters {
+		_, result[clusterName] = cc.crds[name]
+	}
 
-		for _, crd := range crds {
-			if crd.Name == name {
-				result[clusterName] = true
-				break
-			}
+	return result
+}
+
+func (s *defaultFetcher) Get(clusterName, name string) (v1.CustomResourceDefinition, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cc, ok := s.clusters[clusterName]
+	if !ok {
+		return v1.CustomResourceDefinition{}, false
+	}
+
+	crd, found := cc.crds[name]
+
+	return crd, found
+}
+
+// ServedVersions returns the versions of the CRD served by the API server,
+// the storage version first.
+func ServedVersions(crd v1.CustomResourceDefinition) []string {
+	versions := []string{}
+
+	for _, version := range crd.Spec.Versions {
+		if !version.Served {
+			continue
+		}
+
+		if version.Storage {
+			versions = append([]string{version.Name}, versions...)
+		} else {
+			versions = append(versions, version.Name)
 		}
 	}
 
-	return result
+	return versions
 }
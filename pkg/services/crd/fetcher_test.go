@@ -3,10 +3,13 @@ package crd_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
 	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -86,3 +89,49 @@ func TestFetcher_IsAvailableOnClusters(t *testing.T) {
 	assert.Len(t, response, 1, "cluster list should contain one entry")
 	assert.False(t, response["Default"], "%s shouldn't be available on Default cluster", crdName)
 }
+
+func TestFetcher_Watch(t *testing.T) {
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	defer cancelFn()
+
+	service, err := newService(ctx, k8sEnv)
+	assert.NoError(t, err)
+
+	k, err := client.New(k8sEnv.Rest, client.Options{
+		Scheme: kube.CreateScheme(),
+	})
+	assert.NoError(t, err)
+
+	assert.True(t, service.IsAvailable("Default", crd.FlaggerCRDName), "existing crds should be listed once the fetcher is returned")
+
+	pdtesting.NewCRD(ctx, t, k,
+		pdtesting.CRDInfo{
+			Singular: "watchedobject",
+			Group:    "example.com",
+			Plural:   "watchedobjects",
+			Kind:     "WatchedObject",
+		})
+
+	assert.Eventually(t, func() bool {
+		return service.IsAvailable("Default", "watchedobjects.example.com")
+	}, 10*time.Second, 100*time.Millisecond, "created crds should be seen without an update")
+
+	definition, found := service.Get("Default", "watchedobjects.example.com")
+	assert.True(t, found)
+	assert.Equal(t, "WatchedObject", definition.Spec.Names.Kind)
+}
+
+func TestServedVersions(t *testing.T) {
+	definition := v1.CustomResourceDefinition{
+		Spec: v1.CustomResourceDefinitionSpec{
+			Versions: []v1.CustomResourceDefinitionVersion{
+				{Name: "v1alpha1", Served: false},
+				{Name: "v1beta1", Served: true},
+				{Name: "v1", Served: true, Storage: true},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"v1", "v1beta1"}, crd.ServedVersions(definition))
+}
@@ -2,90 +2,61 @@ package crd
 
 import (
 	"context"
-	"sync"
 
-	"github.com/go-logr/logr"
 	"github.com/weaveworks/weave-gitops/core/clustersmngr"
 	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// NewNoCacheFetcher returns a Fetcher looking CRDs up on every call, by name
+// and only on the clusters asked about.
 func NewNoCacheFetcher(clustersManager clustersmngr.ClustersManager) Fetcher {
-	fetcher := &noCacheFetcher{
+	return &noCacheFetcher{
 		clustersManager: clustersManager,
-		crds:            map[string][]v1.CustomResourceDefinition{},
 	}
-
-	return fetcher
 }
 
 type noCacheFetcher struct {
-	sync.RWMutex
-	logger          logr.Logger
 	clustersManager clustersmngr.ClustersManager
-	crds            map[string][]v1.CustomResourceDefinition
 }
 
-func (s *noCacheFetcher) UpdateCRDList() {
-	s.Lock()
-	defer s.Unlock()
-
-	ctx := context.Background()
-
-	client, err := s.clustersManager.GetServerClient(ctx)
-	if err != nil {
-		s.logger.Error(err, "unable to get client pool")
-
-		return
-	}
+// UpdateCRDList does nothing, nothing is cached.
+func (s *noCacheFetcher) UpdateCRDList() {}
 
-	for clusterName, client := range client.ClientsPool().Clients() {
-		crdList := &v1.CustomResourceDefinitionList{}
-
-		s.crds[clusterName] = []v1.CustomResourceDefinition{}
-
-		err := client.List(ctx, crdList)
-		if err != nil {
-			s.logger.Error(err, "unable to list crds")
-			continue
-		}
+func (s *noCacheFetcher) IsAvailable(clusterName, name string) bool {
+	_, found := s.Get(clusterName, name)
 
-		s.crds[clusterName] = crdList.Items
-	}
+	return found
 }
 
-func (s *noCacheFetcher) IsAvailable(clusterName, name string) bool {
-	s.UpdateCRDList()
+func (s *noCacheFetcher) IsAvailableOnClusters(name string) map[string]bool {
+	ctx := context.Background()
+	result := map[string]bool{}
 
-	s.Lock()
-	defer s.Unlock()
+	clusterClient, err := s.clustersManager.GetServerClient(ctx)
+	if err != nil {
+		return result
+	}
 
-	for _, crd := range s.crds[clusterName] {
-		if crd.Name == name {
-			return true
-		}
+	for clusterName, c := range clusterClient.ClientsPool().Clients() {
+		result[clusterName] = c.Get(ctx, client.ObjectKey{Name: name}, &v1.CustomResourceDefinition{}) == nil
 	}
 
-	return false
+	return result
 }
 
-func (s *noCacheFetcher) IsAvailableOnClusters(name string) map[string]bool {
-	s.UpdateCRDList()
-
-	s.Lock()
-	defer s.Unlock()
-
-	result := map[string]bool{}
+func (s *noCacheFetcher) Get(clusterName, name string) (v1.CustomResourceDefinition, bool) {
+	ctx := context.Background()
+	crd := v1.CustomResourceDefinition{}
 
-	for clusterName, crds := range s.crds {
-		result[clusterName] = false
+	clusterClient, err := s.clustersManager.GetServerClient(ctx)
+	if err != nil {
+		return crd, false
+	}
 
-		for _, crd := range crds {
-			if crd.Name == name {
-				result[clusterName] = true
-				break
-			}
-		}
+	if err := clusterClient.Get(ctx, clusterName, client.ObjectKey{Name: name}, &crd); err != nil {
+		return crd, false
 	}
 
-	return result
+	return crd, true
 }
@@ -0,0 +1,143 @@
+package delivery
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Filter selects rollouts by their attributes. Empty fields match
+// everything, lists match any of their values.
+type Filter struct {
+	ClusterName   string
+	Namespace     string
+	Phases        []string
+	Providers     []string
+	Strategies    []string
+	LabelSelector string
+	NameContains  string
+}
+
+// DefaultProvider is the provider of rollouts without one, they use the
+// default of their controller.
+const DefaultProvider = "default"
+
+// Attributes are the values filters and sort orders apply to. They are
+// shared by every controller, so results can be merged and sorted together.
+type Attributes struct {
+	ClusterName string
+	Namespace   string
+	Name        string
+	Phase       string
+	// Provider is the name of the provider without its version, or
+	// DefaultProvider.
+	Provider           string
+	Strategy           DeploymentStrategy
+	LastTransitionTime time.Time
+}
+
+type SortField string
+
+const (
+	SortByName               SortField = "name"
+	SortByNamespace          SortField = "namespace"
+	SortByClusterName        SortField = "cluster_name"
+	SortByPhase              SortField = "phase"
+	SortByLastTransitionTime SortField = "last_transition_time"
+)
+
+// SortOptions orders rollouts by a field. Ties are broken by cluster,
+// namespace and name. An empty field leaves the order unspecified.
+type SortOptions struct {
+	By         SortField
+	Descending bool
+}
+
+// Selector parses the label selector of the filter, it's applied by the API
+// server when listing and ignored by Matches.
+func (f Filter) Selector() (labels.Selector, error) {
+	selector, err := labels.Parse(f.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %w", f.LabelSelector, err)
+	}
+
+	return selector, nil
+}
+
+func (f Filter) Matches(attrs Attributes) bool {
+	if f.ClusterName != "" && f.ClusterName != attrs.ClusterName {
+		return false
+	}
+
+	if f.Namespace != "" && f.Namespace != attrs.Namespace {
+		return false
+	}
+
+	if f.NameContains != "" && !strings.Contains(strings.ToLower(attrs.Name), strings.ToLower(f.NameContains)) {
+		return false
+	}
+
+	return matchesAny(f.Phases, attrs.Phase) &&
+		matchesAny(f.Providers, attrs.Provider) &&
+		matchesAny(f.Strategies, string(attrs.Strategy))
+}
+
+func matchesAny(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (o SortOptions) Validate() error {
+	switch o.By {
+	case "", SortByName, SortByNamespace, SortByClusterName, SortByPhase, SortByLastTransitionTime:
+		return nil
+	}
+
+	return fmt.Errorf("unsupported sort field: %s", o.By)
+}
+
+func (o SortOptions) Less(a, b Attributes) bool {
+	result := 0
+
+	switch o.By {
+	case SortByNamespace:
+		result = strings.Compare(a.Namespace, b.Namespace)
+	case SortByClusterName:
+		result = strings.Compare(a.ClusterName, b.ClusterName)
+	case SortByPhase:
+		result = strings.Compare(a.Phase, b.Phase)
+	case SortByLastTransitionTime:
+		result = a.LastTransitionTime.Compare(b.LastTransitionTime)
+	case SortByName:
+		result = strings.Compare(a.Name, b.Name)
+	}
+
+	for _, tieBreaker := range [][2]string{
+		{a.ClusterName, b.ClusterName},
+		{a.Namespace, b.Namespace},
+		{a.Name, b.Name},
+	} {
+		if result != 0 {
+			break
+		}
+
+		result = strings.Compare(tieBreaker[0], tieBreaker[1])
+	}
+
+	if o.Descending {
+		return result > 0
+	}
+
+	return result < 0
+}
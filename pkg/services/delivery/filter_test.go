@@ -0,0 +1,65 @@
+package delivery_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/pkg/services/delivery"
+)
+
+func TestFilter_Matches(t *testing.T) {
+	attrs := delivery.Attributes{
+		ClusterName: "prod",
+		Namespace:   "apps",
+		Name:        "Podinfo",
+		Phase:       "Failed",
+		Provider:    "istio",
+		Strategy:    delivery.CanaryDeploymentStrategy,
+	}
+
+	tests := []struct {
+		name    string
+		filter  delivery.Filter
+		matches bool
+	}{
+		{name: "empty", filter: delivery.Filter{}, matches: true},
+		{name: "cluster", filter: delivery.Filter{ClusterName: "prod"}, matches: true},
+		{name: "other cluster", filter: delivery.Filter{ClusterName: "dev"}, matches: false},
+		{name: "other namespace", filter: delivery.Filter{Namespace: "default"}, matches: false},
+		{name: "phases", filter: delivery.Filter{Phases: []string{"Succeeded", "failed"}}, matches: true},
+		{name: "other phases", filter: delivery.Filter{Phases: []string{"Succeeded"}}, matches: false},
+		{name: "providers", filter: delivery.Filter{Providers: []string{"istio"}}, matches: true},
+		{name: "strategies", filter: delivery.Filter{Strategies: []string{"blue-green"}}, matches: false},
+		{name: "name", filter: delivery.Filter{NameContains: "dinf"}, matches: true},
+		{name: "other name", filter: delivery.Filter{NameContains: "backend"}, matches: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.matches, tt.filter.Matches(attrs))
+		})
+	}
+}
+
+func TestSortOptions_Less(t *testing.T) {
+	older := delivery.Attributes{ClusterName: "a", Namespace: "ns", Name: "b", LastTransitionTime: time.Unix(100, 0)}
+	newer := delivery.Attributes{ClusterName: "a", Namespace: "ns", Name: "a", LastTransitionTime: time.Unix(200, 0)}
+
+	byTime := delivery.SortOptions{By: delivery.SortByLastTransitionTime}
+	assert.True(t, byTime.Less(older, newer))
+	assert.False(t, byTime.Less(newer, older))
+
+	byTime.Descending = true
+	assert.True(t, byTime.Less(newer, older))
+
+	byName := delivery.SortOptions{By: delivery.SortByName}
+	assert.True(t, byName.Less(newer, older))
+
+	// Ties are broken by name.
+	byCluster := delivery.SortOptions{By: delivery.SortByClusterName}
+	assert.True(t, byCluster.Less(newer, older))
+
+	assert.Error(t, delivery.SortOptions{By: "size"}.Validate())
+	assert.NoError(t, delivery.SortOptions{}.Validate())
+}
@@ -0,0 +1,30 @@
+package delivery
+
+import (
+	"context"
+
+	pb "github.com/weaveworks/progressive-delivery/pkg/api/prog"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+)
+
+// Source lists the rollouts of a progressive delivery controller as Canaries,
+// so the rollouts of every controller can be listed and paginated together.
+type Source interface {
+	// Controller names the controller of the rollouts, page tokens point into
+	// the rollouts of a controller by its name.
+	Controller() string
+	// IsInstalled reports whether the controller is available on any cluster,
+	// its rollouts aren't listed otherwise.
+	IsInstalled() bool
+	// List returns a page of the rollouts matching the options, the token of
+	// the next page, empty on the last one, and the clusters and namespaces
+	// that failed to be listed.
+	List(ctx context.Context, clusterClient clustersmngr.Client, opts ListOptions) ([]*pb.Canary, string, []*pb.ListError, error)
+}
+
+type ListOptions struct {
+	Filter
+	Sort      SortOptions
+	PageSize  int32
+	PageToken string
+}
@@ -0,0 +1,14 @@
+package delivery
+
+// DeploymentStrategy is how a rollout shifts traffic to the new version.
+// Controllers map their strategies to the closest one, so rollouts can be
+// filtered and counted together.
+type DeploymentStrategy string
+
+const (
+	CanaryDeploymentStrategy          DeploymentStrategy = "canary"
+	BlueGreenDeploymentStrategy       DeploymentStrategy = "blue-green"
+	BlueGreenMirrorDeploymentStrategy DeploymentStrategy = "blue-green-mirror"
+	ABTestingDeploymentStrategy       DeploymentStrategy = "ab-testing"
+	NoAnalysisDeploymentStrategy      DeploymentStrategy = "no-analysis"
+)
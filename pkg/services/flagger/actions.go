@@ -0,0 +1,329 @@
+package flagger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PromotedAnnotation is set on Canaries whose analysis is skipped by
+// PromoteCanary, their analysis is enabled again by the PromotionResetter once
+// the rollout ends.
+const PromotedAnnotation = "progressive-delivery.weave.works/promoted"
+
+// promotablePhases are the phases of a rollout in progress, Canaries in other
+// phases have nothing to promote.
+var promotablePhases = map[flaggerv1.CanaryPhase]bool{
+	flaggerv1.CanaryPhaseProgressing:      true,
+	flaggerv1.CanaryPhaseWaiting:          true,
+	flaggerv1.CanaryPhaseWaitingPromotion: true,
+}
+
+// Flagger loadtester gate endpoints. Canaries are halted by a gate webhook
+// pointing to the check endpoint, the state of the gate is changed by calling
+// the open and close endpoints next to it.
+const (
+	gateCheckPath      = "/gate/check"
+	gateOpenPath       = "/gate/open"
+	gateClosePath      = "/gate/close"
+	rollbackCheckPath  = "/rollback/check"
+	rollbackOpenPath   = "/rollback/open"
+	confirmGateName    = "confirm"
+	rollbackGateName   = "rollback"
+	gateRequestTimeout = 10 * time.Second
+)
+
+// gateHTTPClient doesn't follow redirects, they could point the request to a
+// host that isn't allowed.
+var gateHTTPClient = &http.Client{
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+type CanaryActionOptions struct {
+	Name        string
+	Namespace   string
+	ClusterName string
+	// GateHosts are the hosts of the loadtesters whose gates can be called,
+	// as host or host:port. Gates of other hosts are refused.
+	GateHosts []string
+}
+
+func (opts CanaryActionOptions) getCanaryOptions() GetCanaryOptions {
+	return GetCanaryOptions{
+		Name:        opts.Name,
+		Namespace:   opts.Namespace,
+		ClusterName: opts.ClusterName,
+	}
+}
+
+// PromoteCanary skips the analysis of the rollout in progress, so Flagger
+// promotes it. The Canary is annotated so its analysis is enabled again for
+// the next rollouts, Canaries skipping their analysis already are left as is.
+func (service *defaultFetcher) PromoteCanary(ctx context.Context, clusterClient clustersmngr.Client, opts CanaryActionOptions) (*flaggerv1.Canary, error) {
+	canary, err := service.GetCanary(ctx, clusterClient, opts.getCanaryOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	if !promotablePhases[canary.Status.Phase] {
+		return nil, CanaryNotProgressingError{Name: opts.Name, Namespace: opts.Namespace, Phase: string(canary.Status.Phase)}
+	}
+
+	if canary.Spec.SkipAnalysis {
+		return canary, nil
+	}
+
+	patch := client.MergeFrom(canary.DeepCopy())
+	canary.Spec.SkipAnalysis = true
+
+	annotations := canary.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[PromotedAnnotation] = "true"
+	canary.SetAnnotations(annotations)
+
+	if err := clusterClient.Patch(ctx, opts.ClusterName, canary, patch); err != nil {
+		return nil, fmt.Errorf("failed patching canary: name=%s namespace=%s cluster=%s err=%w", opts.Name, opts.Namespace, opts.ClusterName, err)
+	}
+
+	return canary, nil
+}
+
+func (service *defaultFetcher) RollbackCanary(ctx context.Context, clusterClient clustersmngr.Client, opts CanaryActionOptions) (*flaggerv1.Canary, error) {
+	return service.callGate(ctx, clusterClient, opts, rollbackGateName, rollbackCheckPath, rollbackOpenPath, flaggerv1.RollbackHook)
+}
+
+// PauseCanary closes the confirmation gate of the Canary, or suspends it if
+// it has none.
+func (service *defaultFetcher) PauseCanary(ctx context.Context, clusterClient clustersmngr.Client, opts CanaryActionOptions) (*flaggerv1.Canary, error) {
+	canary, err := service.callGate(ctx, clusterClient, opts, confirmGateName, gateCheckPath, gateClosePath, confirmHookTypes...)
+	if errors.As(err, &CanaryGateNotFoundError{}) {
+		return service.suspendCanary(ctx, clusterClient, opts, true)
+	}
+
+	return canary, err
+}
+
+// ResumeCanary opens the confirmation gate of the Canary, or resumes it if it
+// has none.
+func (service *defaultFetcher) ResumeCanary(ctx context.Context, clusterClient clustersmngr.Client, opts CanaryActionOptions) (*flaggerv1.Canary, error) {
+	canary, err := service.callGate(ctx, clusterClient, opts, confirmGateName, gateCheckPath, gateOpenPath, confirmHookTypes...)
+	if errors.As(err, &CanaryGateNotFoundError{}) {
+		return service.suspendCanary(ctx, clusterClient, opts, false)
+	}
+
+	return canary, err
+}
+
+// suspendCanary sets spec.suspend of the Canary. The field is newer than the
+// version of the Flagger API the server is built with, so it's patched as is
+// once the CRD of the cluster is known to have it.
+func (service *defaultFetcher) suspendCanary(ctx context.Context, clusterClient clustersmngr.Client, opts CanaryActionOptions, suspend bool) (*flaggerv1.Canary, error) {
+	if !service.supportsSuspend(opts.ClusterName) {
+		return nil, CanarySuspendNotSupportedError{Name: opts.Name, Namespace: opts.Namespace, ClusterName: opts.ClusterName}
+	}
+
+	canary, err := service.GetCanary(ctx, clusterClient, opts.getCanaryOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	patch := client.RawPatch(types.MergePatchType, []byte(fmt.Sprintf(`{"spec":{"suspend":%t}}`, suspend)))
+
+	if err := clusterClient.Patch(ctx, opts.ClusterName, canary, patch); err != nil {
+		return nil, fmt.Errorf("failed patching canary: name=%s namespace=%s cluster=%s err=%w", opts.Name, opts.Namespace, opts.ClusterName, err)
+	}
+
+	return canary, nil
+}
+
+// supportsSuspend reports whether the Canary CRD of the cluster has
+// spec.suspend.
+func (service *defaultFetcher) supportsSuspend(clusterName string) bool {
+	definition, found := service.crdService.Get(clusterName, crd.FlaggerCRDName)
+	if !found {
+		return false
+	}
+
+	for _, version := range definition.Spec.Versions {
+		if version.Name != flaggerv1.SchemeGroupVersion.Version || version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+			continue
+		}
+
+		_, found := version.Schema.OpenAPIV3Schema.Properties["spec"].Properties["suspend"]
+
+		return found
+	}
+
+	return false
+}
+
+var confirmHookTypes = []flaggerv1.HookType{
+	flaggerv1.ConfirmRolloutHook,
+	flaggerv1.ConfirmTrafficIncreaseHook,
+	flaggerv1.ConfirmPromotionHook,
+}
+
+// callGate looks up the first webhook of the given types pointing to a
+// loadtester check endpoint, and calls the action endpoint next to it with the
+// same payload Flagger sends to the webhook. The request is sent by the
+// server, so the user needs to be allowed to update the Canary and the
+// loadtester needs to be one of the configured gate hosts.
+func (service *defaultFetcher) callGate(
+	ctx context.Context,
+	clusterClient clustersmngr.Client,
+	opts CanaryActionOptions,
+	gate, checkPath, actionPath string,
+	hookTypes ...flaggerv1.HookType,
+) (*flaggerv1.Canary, error) {
+	canary, err := service.GetCanary(ctx, clusterClient, opts.getCanaryOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	gateURL, err := findGateURL(canary, checkPath, actionPath, hookTypes...)
+	if err != nil {
+		return nil, err
+	}
+
+	if gateURL == nil {
+		return nil, CanaryGateNotFoundError{Name: opts.Name, Namespace: opts.Namespace, Gate: gate}
+	}
+
+	if !containsHost(opts.GateHosts, gateURL) {
+		return nil, CanaryGateHostNotAllowedError{Name: opts.Name, Namespace: opts.Namespace, Host: gateURL.Host}
+	}
+
+	if err := canUpdateCanary(ctx, clusterClient, opts); err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(flaggerv1.CanaryWebhookPayload{
+		Name:      canary.GetName(),
+		Namespace: canary.GetNamespace(),
+		Phase:     canary.Status.Phase,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed encoding gate payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, gateRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, gateURL.String(), bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed creating gate request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := service.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed calling %s gate: %w", gate, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("failed calling %s gate: %s returned %s", gate, gateURL, res.Status)
+	}
+
+	return canary, nil
+}
+
+// canUpdateCanary returns a Forbidden error unless the user of the client is
+// allowed to update the Canary, gates act on it without the client.
+func canUpdateCanary(ctx context.Context, clusterClient clustersmngr.Client, opts CanaryActionOptions) error {
+	ssar := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: opts.Namespace,
+				Name:      opts.Name,
+				Verb:      "update",
+				Group:     flaggerv1.SchemeGroupVersion.Group,
+				Resource:  "canaries",
+			},
+		},
+	}
+
+	if err := clusterClient.Create(ctx, opts.ClusterName, ssar); err != nil {
+		return fmt.Errorf("failed reviewing access to canary: %w", err)
+	}
+
+	if !ssar.Status.Allowed {
+		return k8serrors.NewForbidden(
+			flaggerv1.SchemeGroupVersion.WithResource("canaries").GroupResource(),
+			opts.Name,
+			fmt.Errorf("user can't update canaries in namespace %s", opts.Namespace),
+		)
+	}
+
+	return nil
+}
+
+func findGateURL(canary *flaggerv1.Canary, checkPath, actionPath string, hookTypes ...flaggerv1.HookType) (*url.URL, error) {
+	analysis := canary.GetAnalysis()
+	if analysis == nil {
+		return nil, nil
+	}
+
+	for _, hook := range analysis.Webhooks {
+		if !containsHookType(hookTypes, hook.Type) {
+			continue
+		}
+
+		hookURL, err := url.Parse(hook.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook url %q: %w", hook.URL, err)
+		}
+
+		if !strings.HasSuffix(hookURL.Path, checkPath) {
+			continue
+		}
+
+		hookURL.Path = strings.TrimSuffix(hookURL.Path, checkPath) + actionPath
+
+		return hookURL, nil
+	}
+
+	return nil, nil
+}
+
+// containsHost returns true if the host of the URL, with or without its port,
+// is one of the hosts.
+func containsHost(hosts []string, u *url.URL) bool {
+	for _, host := range hosts {
+		if host == u.Host || host == u.Hostname() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsHookType(types []flaggerv1.HookType, t flaggerv1.HookType) bool {
+	for _, item := range types {
+		if item == t {
+			return true
+		}
+	}
+
+	return false
+}
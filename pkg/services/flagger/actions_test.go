@@ -0,0 +1,151 @@
+package flagger_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestFetcher_PromoteCanary(t *testing.T) {
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	defer cancelFn()
+
+	k, err := client.New(k8sEnv.Rest, client.Options{
+		Scheme: kube.CreateScheme(),
+	})
+	require.NoError(t, err)
+
+	ns := pdtesting.NewNamespace(ctx, t, k)
+
+	cl, service, err := newService(ctx, k8sEnv)
+	require.NoError(t, err)
+
+	canary := pdtesting.NewCanary(ctx, t, k, pdtesting.CanaryInfo{
+		Name:      "promote",
+		Namespace: ns.Name,
+	})
+	defer pdtesting.Cleanup(ctx, t, k, &canary)
+
+	opts := flagger.CanaryActionOptions{
+		Name:        canary.Name,
+		Namespace:   canary.Namespace,
+		ClusterName: "Default",
+	}
+
+	_, err = service.PromoteCanary(ctx, cl, opts)
+	assert.ErrorAs(t, err, &flagger.CanaryNotProgressingError{}, "canaries without a rollout in progress can't be promoted")
+
+	canary.Status.Phase = v1beta1.CanaryPhaseProgressing
+	require.NoError(t, k.Status().Update(ctx, &canary))
+
+	updated, err := service.PromoteCanary(ctx, cl, opts)
+	require.NoError(t, err)
+	assert.True(t, updated.Spec.SkipAnalysis)
+
+	stored := &v1beta1.Canary{}
+	require.NoError(t, k.Get(ctx, client.ObjectKeyFromObject(&canary), stored))
+	assert.True(t, stored.Spec.SkipAnalysis)
+	assert.Equal(t, "true", stored.GetAnnotations()[flagger.PromotedAnnotation])
+}
+
+func TestFetcher_CanaryGates(t *testing.T) {
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	defer cancelFn()
+
+	k, err := client.New(k8sEnv.Rest, client.Options{
+		Scheme: kube.CreateScheme(),
+	})
+	require.NoError(t, err)
+
+	ns := pdtesting.NewNamespace(ctx, t, k)
+
+	cl, service, err := newService(ctx, k8sEnv)
+	require.NoError(t, err)
+
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+
+	loadtester := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		calls = append(calls, r.URL.Path)
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer loadtester.Close()
+
+	gated := pdtesting.NewCanary(ctx, t, k, pdtesting.CanaryInfo{
+		Name:      "gated",
+		Namespace: ns.Name,
+		Webhooks: []v1beta1.CanaryWebhook{
+			{Type: v1beta1.ConfirmRolloutHook, Name: "gate", URL: loadtester.URL + "/gate/check"},
+			{Type: v1beta1.RollbackHook, Name: "rollback", URL: loadtester.URL + "/rollback/check"},
+		},
+	})
+	defer pdtesting.Cleanup(ctx, t, k, &gated)
+
+	ungated := pdtesting.NewCanary(ctx, t, k, pdtesting.CanaryInfo{
+		Name:      "ungated",
+		Namespace: ns.Name,
+	})
+	defer pdtesting.Cleanup(ctx, t, k, &ungated)
+
+	loadtesterURL, err := url.Parse(loadtester.URL)
+	require.NoError(t, err)
+
+	opts := flagger.CanaryActionOptions{
+		Name:        gated.Name,
+		Namespace:   gated.Namespace,
+		ClusterName: "Default",
+	}
+
+	_, err = service.PauseCanary(ctx, cl, opts)
+	assert.ErrorAs(t, err, &flagger.CanaryGateHostNotAllowedError{})
+	assert.Empty(t, calls)
+
+	opts.GateHosts = []string{loadtesterURL.Host}
+
+	_, err = service.PauseCanary(ctx, cl, opts)
+	require.NoError(t, err)
+
+	_, err = service.ResumeCanary(ctx, cl, opts)
+	require.NoError(t, err)
+
+	_, err = service.RollbackCanary(ctx, cl, opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"/gate/close", "/gate/open", "/rollback/open"}, calls)
+
+	_, err = service.RollbackCanary(ctx, cl, flagger.CanaryActionOptions{
+		Name:        ungated.Name,
+		Namespace:   ungated.Namespace,
+		ClusterName: "Default",
+		GateHosts:   opts.GateHosts,
+	})
+	assert.ErrorAs(t, err, &flagger.CanaryGateNotFoundError{})
+
+	// The Canary CRD of the test environment has no spec.suspend.
+	_, err = service.PauseCanary(ctx, cl, flagger.CanaryActionOptions{
+		Name:        ungated.Name,
+		Namespace:   ungated.Namespace,
+		ClusterName: "Default",
+		GateHosts:   opts.GateHosts,
+	})
+	assert.ErrorAs(t, err, &flagger.CanarySuspendNotSupportedError{})
+}
@@ -0,0 +1,279 @@
+package flagger
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/fluxcd/flagger/pkg/metrics/observers"
+	"github.com/fluxcd/flagger/pkg/metrics/providers"
+	"github.com/weaveworks/progressive-delivery/pkg/pagination"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultMetricInterval is rendered in queries when neither the request nor
+// a metric of the Canary sets an interval, it's the Flagger default.
+const defaultMetricInterval = "1m"
+
+type RunMetricTemplateOptions struct {
+	Name            string
+	Namespace       string
+	ClusterName     string
+	CanaryName      string
+	CanaryNamespace string
+	Interval        string
+	// ProviderHosts are the hosts of the metric providers templates can be
+	// run against, as host or host:port. Templates of other hosts are refused.
+	ProviderHosts []string
+}
+
+// MetricTemplateRun is the result of a MetricTemplate query.
+type MetricTemplateRun struct {
+	Query  string
+	Value  float64
+	Checks []MetricCheck
+}
+
+// MetricCheck tells whether a query result is accepted by a metric of a
+// Canary referencing the template.
+type MetricCheck struct {
+	CanaryName      string
+	CanaryNamespace string
+	MetricName      string
+	ThresholdRange  flaggerv1.CanaryThresholdRange
+	WithinRange     bool
+}
+
+func (service *defaultFetcher) RunMetricTemplate(ctx context.Context, clusterClient clustersmngr.Client, opts RunMetricTemplateOptions) (*MetricTemplateRun, error) {
+	template, err := service.GetMetricTemplate(ctx, opts.ClusterName, clusterClient, opts.Name, opts.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting metric template: name=%s namespace=%s cluster=%s err=%w", opts.Name, opts.Namespace, opts.ClusterName, err)
+	}
+
+	// The server sends the query itself, to any address the template sets.
+	if address := template.Spec.Provider.Address; address != "" {
+		providerURL, err := url.Parse(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metric template provider address %q: %w", address, err)
+		}
+
+		if !containsHost(opts.ProviderHosts, providerURL) {
+			return nil, MetricProviderHostNotAllowedError{Name: template.GetName(), Namespace: template.GetNamespace(), Host: providerURL.Host}
+		}
+	}
+
+	canaryNamespace := opts.CanaryNamespace
+	if canaryNamespace == "" {
+		canaryNamespace = template.GetNamespace()
+	}
+
+	canary, err := service.GetCanary(ctx, clusterClient, GetCanaryOptions{
+		Name:        opts.CanaryName,
+		Namespace:   canaryNamespace,
+		ClusterName: opts.ClusterName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	interval := defaultMetricInterval
+	variables := map[string]string{}
+
+	if metric, ok := referencingMetric(*canary, template); ok {
+		if metric.Interval != "" {
+			interval = metric.Interval
+		}
+
+		variables = metric.TemplateVariables
+	}
+
+	if opts.Interval != "" {
+		interval = opts.Interval
+	}
+
+	var credentials map[string][]byte
+
+	if template.Spec.Provider.SecretRef != nil {
+		secret := corev1.Secret{}
+		key := client.ObjectKey{Name: template.Spec.Provider.SecretRef.Name, Namespace: template.GetNamespace()}
+
+		if err := clusterClient.Get(ctx, opts.ClusterName, key, &secret); err != nil {
+			return nil, fmt.Errorf("failed getting metric template secret: name=%s namespace=%s cluster=%s err=%w", key.Name, key.Namespace, opts.ClusterName, err)
+		}
+
+		credentials = secret.Data
+	}
+
+	provider, err := newMetricProvider(interval, template.Spec.Provider, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("metric template provider %s error: %w", template.Spec.Provider.Type, err)
+	}
+
+	query, err := observers.RenderQuery(template.Spec.Query, metricTemplateModel(*canary, interval, variables))
+	if err != nil {
+		return nil, fmt.Errorf("metric template query render error: %w", err)
+	}
+
+	value, err := provider.RunQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("metric template query failed: %w", err)
+	}
+
+	checks, err := service.checkMetric(ctx, clusterClient, opts.ClusterName, template, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricTemplateRun{
+		Query:  query,
+		Value:  value,
+		Checks: checks,
+	}, nil
+}
+
+// metricProvider runs the queries of metric templates.
+type metricProvider interface {
+	RunQuery(query string) (float64, error)
+}
+
+// newMetricProvider returns the Flagger provider of the template. Prometheus
+// queries are sent without following redirects, the clients of the other
+// providers follow them, so their hosts need to be trusted.
+func newMetricProvider(interval string, provider flaggerv1.MetricTemplateProvider, credentials map[string][]byte) (metricProvider, error) {
+	switch provider.Type {
+	case "datadog", "cloudwatch", "newrelic", "graphite", "stackdriver", "influxdb", "dynatrace":
+		return providers.Factory{}.Provider(interval, provider, credentials)
+	default:
+		// Unknown types are Prometheus for Flagger too.
+		return newPrometheusProvider(provider, credentials)
+	}
+}
+
+// checkMetric checks the value against every metric referencing the template
+// in the Canaries of the cluster.
+func (service *defaultFetcher) checkMetric(ctx context.Context, clusterClient clustersmngr.Client, clusterName string, template flaggerv1.MetricTemplate, value float64) ([]MetricCheck, error) {
+	items, _, _, err := pagination.List(
+		ctx,
+		clusterClient,
+		func() client.ObjectList { return &flaggerv1.CanaryList{} },
+		pagination.ListOptions{
+			SkipCluster: func(name string) bool { return name != clusterName },
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing canaries: %w", err)
+	}
+
+	checks := []MetricCheck{}
+
+	for _, item := range items {
+		canary, ok := item.Object.(*flaggerv1.Canary)
+		if !ok || canary.GetAnalysis() == nil {
+			continue
+		}
+
+		for _, metric := range canary.GetAnalysis().Metrics {
+			if !references(*canary, metric, template) {
+				continue
+			}
+
+			thresholdRange := effectiveThresholdRange(metric)
+
+			checks = append(checks, MetricCheck{
+				CanaryName:      canary.GetName(),
+				CanaryNamespace: canary.GetNamespace(),
+				MetricName:      metric.Name,
+				ThresholdRange:  thresholdRange,
+				WithinRange:     withinRange(thresholdRange, value),
+			})
+		}
+	}
+
+	return checks, nil
+}
+
+func referencingMetric(canary flaggerv1.Canary, template flaggerv1.MetricTemplate) (flaggerv1.CanaryMetric, bool) {
+	if canary.GetAnalysis() == nil {
+		return flaggerv1.CanaryMetric{}, false
+	}
+
+	for _, metric := range canary.GetAnalysis().Metrics {
+		if references(canary, metric, template) {
+			return metric, true
+		}
+	}
+
+	return flaggerv1.CanaryMetric{}, false
+}
+
+// references reports whether the metric uses the template, template
+// references default to the namespace of the Canary.
+func references(canary flaggerv1.Canary, metric flaggerv1.CanaryMetric, template flaggerv1.MetricTemplate) bool {
+	if metric.TemplateRef == nil || metric.TemplateRef.Name != template.GetName() {
+		return false
+	}
+
+	namespace := metric.TemplateRef.Namespace
+	if namespace == "" {
+		namespace = canary.GetNamespace()
+	}
+
+	return namespace == template.GetNamespace()
+}
+
+// effectiveThresholdRange returns the range Flagger checks the metric
+// against, the deprecated threshold is a maximum.
+func effectiveThresholdRange(metric flaggerv1.CanaryMetric) flaggerv1.CanaryThresholdRange {
+	if metric.ThresholdRange != nil {
+		return *metric.ThresholdRange
+	}
+
+	threshold := metric.Threshold
+
+	return flaggerv1.CanaryThresholdRange{Max: &threshold}
+}
+
+func withinRange(thresholdRange flaggerv1.CanaryThresholdRange, value float64) bool {
+	if thresholdRange.Min != nil && value < *thresholdRange.Min {
+		return false
+	}
+
+	if thresholdRange.Max != nil && value > *thresholdRange.Max {
+		return false
+	}
+
+	return true
+}
+
+// metricTemplateModel returns the values of the query template variables,
+// the same way Flagger renders them.
+func metricTemplateModel(canary flaggerv1.Canary, interval string, variables map[string]string) flaggerv1.MetricTemplateModel {
+	service := canary.Spec.TargetRef.Name
+	if canary.Spec.Service.Name != "" {
+		service = canary.Spec.Service.Name
+	}
+
+	ingress := canary.Spec.TargetRef.Name
+	if canary.Spec.IngressRef != nil {
+		ingress = canary.Spec.IngressRef.Name
+	}
+
+	route := canary.Spec.TargetRef.Name
+	if canary.Spec.RouteRef != nil {
+		route = canary.Spec.RouteRef.Name
+	}
+
+	return flaggerv1.MetricTemplateModel{
+		Name:      canary.GetName(),
+		Namespace: canary.GetNamespace(),
+		Target:    canary.Spec.TargetRef.Name,
+		Service:   service,
+		Ingress:   ingress,
+		Route:     route,
+		Interval:  interval,
+		Variables: variables,
+	}
+}
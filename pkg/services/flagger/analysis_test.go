@@ -0,0 +1,134 @@
+package flagger_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestFetcher_RunMetricTemplate(t *testing.T) {
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	defer cancelFn()
+
+	var mu sync.Mutex
+
+	queries := []string{}
+
+	prometheus := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.Query().Get("query"))
+		mu.Unlock()
+
+		fmt.Fprint(w, `{"data":{"result":[{"metric":{},"value":[1680000000,"0.95"]}]}}`)
+	}))
+	defer prometheus.Close()
+
+	k, err := client.New(k8sEnv.Rest, client.Options{
+		Scheme: kube.CreateScheme(),
+	})
+	assert.NoError(t, err)
+
+	ns := pdtesting.NewNamespace(ctx, t, k)
+
+	cl, service, err := newService(ctx, k8sEnv)
+	assert.NoError(t, err)
+
+	template := pdtesting.NewMetricTemplate(ctx, t, k, pdtesting.MetricTemplateInfo{
+		Name:            "success-rate",
+		Namespace:       ns.GetName(),
+		ProviderType:    "prometheus",
+		ProviderAddress: prometheus.URL,
+		Query:           `rate(requests{namespace="{{ namespace }}",deployment="{{ target }}",code="{{ variables.code }}"}[{{ interval }}])`,
+	})
+	defer pdtesting.Cleanup(ctx, t, k, template)
+
+	min, max := 0.99, 1.0
+
+	canary := pdtesting.NewCanary(ctx, t, k, pdtesting.CanaryInfo{
+		Name:      "podinfo",
+		Namespace: ns.GetName(),
+		Metrics: []v1beta1.CanaryMetric{
+			{
+				Name:              "strict",
+				Interval:          "2m",
+				TemplateRef:       &v1beta1.CrossNamespaceObjectReference{Name: template.GetName()},
+				TemplateVariables: map[string]string{"code": "200"},
+				ThresholdRange:    &v1beta1.CanaryThresholdRange{Min: &min, Max: &max},
+			},
+			{
+				Name:        "lenient",
+				TemplateRef: &v1beta1.CrossNamespaceObjectReference{Name: template.GetName(), Namespace: ns.GetName()},
+				Threshold:   1,
+			},
+			{
+				Name: "request-duration",
+			},
+		},
+	})
+	defer pdtesting.Cleanup(ctx, t, k, &canary)
+
+	promURL, err := url.Parse(prometheus.URL)
+	assert.NoError(t, err)
+
+	_, err = service.RunMetricTemplate(ctx, cl, flagger.RunMetricTemplateOptions{
+		Name:        template.GetName(),
+		Namespace:   ns.GetName(),
+		ClusterName: "Default",
+		CanaryName:  canary.GetName(),
+	})
+	assert.ErrorAs(t, err, &flagger.MetricProviderHostNotAllowedError{})
+	assert.Empty(t, queries, "providers that aren't allowed shouldn't be queried")
+
+	run, err := service.RunMetricTemplate(ctx, cl, flagger.RunMetricTemplateOptions{
+		Name:          template.GetName(),
+		Namespace:     ns.GetName(),
+		ClusterName:   "Default",
+		CanaryName:    canary.GetName(),
+		ProviderHosts: []string{promURL.Host},
+	})
+	assert.NoError(t, err)
+
+	expectedQuery := fmt.Sprintf(`rate(requests{namespace="%s",deployment="podinfo",code="200"}[2m])`, ns.GetName())
+
+	assert.Equal(t, expectedQuery, run.Query)
+	assert.Equal(t, []string{expectedQuery}, queries)
+	assert.Equal(t, 0.95, run.Value)
+
+	assert.Len(t, run.Checks, 2)
+	assert.Equal(t, "strict", run.Checks[0].MetricName)
+	assert.False(t, run.Checks[0].WithinRange)
+	assert.Equal(t, "lenient", run.Checks[1].MetricName)
+	assert.True(t, run.Checks[1].WithinRange)
+
+	run, err = service.RunMetricTemplate(ctx, cl, flagger.RunMetricTemplateOptions{
+		Name:          template.GetName(),
+		Namespace:     ns.GetName(),
+		ClusterName:   "Default",
+		CanaryName:    canary.GetName(),
+		Interval:      "30s",
+		ProviderHosts: []string{promURL.Host},
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, run.Query, "[30s]")
+
+	_, err = service.RunMetricTemplate(ctx, cl, flagger.RunMetricTemplateOptions{
+		Name:          template.GetName(),
+		Namespace:     ns.GetName(),
+		ClusterName:   "Default",
+		CanaryName:    "missing",
+		ProviderHosts: []string{promURL.Host},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,184 @@
+package flagger
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster"
+)
+
+// broadcastRetryInterval is how long to wait before watching a cluster again
+// when its watch stops, Flagger may not be installed on it yet.
+const broadcastRetryInterval = time.Minute
+
+// subscriberBuffer is the number of events buffered per subscriber, the
+// broadcast waits for slower subscribers beyond it.
+const subscriberBuffer = 100
+
+// Broadcaster watches the Canaries of every cluster of the clusters manager
+// once, with the permissions of the server, and sends their events to all its
+// subscribers. Each cluster has a single watch at a time, so the events of a
+// Canary are received in order. It must not be used to serve users.
+type Broadcaster struct {
+	fetcher         Fetcher
+	clustersManager clustersmngr.ClustersManager
+	logger          logr.Logger
+
+	mu          sync.Mutex
+	subscribers []chan CanaryEvent
+	clusters    map[string]*clusterWatch
+}
+
+// clusterWatch is the watch of a cluster, done is closed once it stopped
+// sending events after cancel.
+type clusterWatch struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewBroadcaster(fetcher Fetcher, clustersManager clustersmngr.ClustersManager, logger logr.Logger) *Broadcaster {
+	return &Broadcaster{
+		fetcher:         fetcher,
+		clustersManager: clustersManager,
+		logger:          logger,
+		clusters:        map[string]*clusterWatch{},
+	}
+}
+
+// Subscribe returns a channel receiving the events of every Canary, closed
+// when the broadcaster stops. Subscribers subscribe before the broadcaster is
+// started, they receive the existing Canaries as added first.
+func (b *Broadcaster) Subscribe() <-chan CanaryEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	events := make(chan CanaryEvent, subscriberBuffer)
+	b.subscribers = append(b.subscribers, events)
+
+	return events
+}
+
+// Start watches the clusters until ctx is done. Clusters are watched as
+// they're added and stop being watched as they're removed. Nothing is watched
+// if there are no subscribers.
+func (b *Broadcaster) Start(ctx context.Context) {
+	b.mu.Lock()
+	subscribed := len(b.subscribers) > 0
+	b.mu.Unlock()
+
+	if !subscribed {
+		return
+	}
+
+	watcher := b.clustersManager.Subscribe()
+	defer watcher.Unsubscribe()
+
+	for _, cl := range b.clustersManager.GetClusters() {
+		b.addCluster(ctx, cl)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			b.stop()
+
+			return
+		case update := <-watcher.Updates:
+			for _, cl := range update.Removed {
+				b.removeCluster(cl.GetName())
+			}
+
+			for _, cl := range update.Added {
+				b.addCluster(ctx, cl)
+			}
+		}
+	}
+}
+
+// stop waits for the watches to stop and closes the subscribers.
+func (b *Broadcaster) stop() {
+	b.mu.Lock()
+	names := make([]string, 0, len(b.clusters))
+	for name := range b.clusters {
+		names = append(names, name)
+	}
+	b.mu.Unlock()
+
+	for _, name := range names {
+		b.removeCluster(name)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, events := range b.subscribers {
+		close(events)
+	}
+
+	b.subscribers = nil
+}
+
+// addCluster watches the cluster, once its previous watch stopped if it had
+// one, so their events can't interleave.
+func (b *Broadcaster) addCluster(ctx context.Context, cl cluster.Cluster) {
+	b.removeCluster(cl.GetName())
+
+	ctx, cancel := context.WithCancel(ctx)
+	cw := &clusterWatch{cancel: cancel, done: make(chan struct{})}
+
+	b.mu.Lock()
+	b.clusters[cl.GetName()] = cw
+	b.mu.Unlock()
+
+	go b.watchCluster(ctx, cl, cw.done)
+}
+
+func (b *Broadcaster) removeCluster(name string) {
+	b.mu.Lock()
+	cw, ok := b.clusters[name]
+	delete(b.clusters, name)
+	b.mu.Unlock()
+
+	if ok {
+		cw.cancel()
+		<-cw.done
+	}
+}
+
+func (b *Broadcaster) watchCluster(ctx context.Context, cl cluster.Cluster, done chan<- struct{}) {
+	defer close(done)
+
+	for {
+		events, err := b.fetcher.WatchAllCanaries(ctx, []cluster.Cluster{cl}, WatchCanariesOptions{})
+		if err != nil {
+			b.logger.Error(err, "failed watching canaries", "cluster", cl.GetName())
+		} else {
+			for event := range events {
+				b.broadcast(ctx, event)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(broadcastRetryInterval):
+		}
+	}
+}
+
+func (b *Broadcaster) broadcast(ctx context.Context, event CanaryEvent) {
+	b.mu.Lock()
+	subscribers := append([]chan CanaryEvent{}, b.subscribers...)
+	b.mu.Unlock()
+
+	for _, events := range subscribers {
+		select {
+		case events <- event:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
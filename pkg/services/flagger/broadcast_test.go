@@ -0,0 +1,82 @@
+package flagger
+
+import (
+	"context"
+	"testing"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster/clusterfakes"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/clustersmngrfakes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// watchFetcher sends a single added Canary per watched cluster.
+type watchFetcher struct {
+	Fetcher
+}
+
+func (watchFetcher) WatchAllCanaries(ctx context.Context, clusters []cluster.Cluster, opts WatchCanariesOptions) (<-chan CanaryEvent, error) {
+	events := make(chan CanaryEvent)
+
+	go func() {
+		defer close(events)
+
+		for _, cl := range clusters {
+			select {
+			case events <- CanaryEvent{
+				Type:        watch.Added,
+				ClusterName: cl.GetName(),
+				Canary:      flaggerv1.Canary{ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "test"}},
+			}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		<-ctx.Done()
+	}()
+
+	return events, nil
+}
+
+func TestBroadcaster(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cl := &clusterfakes.FakeCluster{}
+	cl.GetNameReturns("Default")
+
+	clustersFetcher := &clustersmngrfakes.FakeClusterFetcher{}
+	clustersFetcher.FetchReturns([]cluster.Cluster{cl}, nil)
+
+	clustersManager := clustersmngr.NewClustersManager([]clustersmngr.ClusterFetcher{clustersFetcher}, nil, logr.Discard())
+	require.NoError(t, clustersManager.UpdateClusters(ctx))
+
+	broadcaster := NewBroadcaster(watchFetcher{}, clustersManager, logr.Discard())
+	first, second := broadcaster.Subscribe(), broadcaster.Subscribe()
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		broadcaster.Start(ctx)
+	}()
+
+	for _, events := range []<-chan CanaryEvent{first, second} {
+		event := <-events
+		assert.Equal(t, watch.Added, event.Type)
+		assert.Equal(t, "Default", event.ClusterName)
+		assert.Equal(t, "podinfo", event.Canary.GetName())
+	}
+
+	cancel()
+	<-done
+
+	_, open := <-first
+	assert.False(t, open, "subscribers are closed once stopped")
+}
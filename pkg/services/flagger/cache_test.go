@@ -0,0 +1,80 @@
+package flagger_test
+
+import (
+	"context"
+	"testing"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
+	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// canaryCache serves Canaries only.
+type canaryCache struct {
+	canaries map[client.ObjectKey]flaggerv1.Canary
+	gets     int
+}
+
+func (c *canaryCache) Get(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, key client.ObjectKey, obj client.Object) (bool, error) {
+	canary, ok := obj.(*flaggerv1.Canary)
+	if !ok {
+		return false, nil
+	}
+
+	cached := c.canaries[key]
+
+	c.gets++
+	cached.DeepCopyInto(canary)
+
+	return true, nil
+}
+
+func (c *canaryCache) List(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, list client.ObjectList, opts ...client.ListOption) (bool, error) {
+	return false, nil
+}
+
+func TestCachedFetcher_GetCanary(t *testing.T) {
+	key := client.ObjectKey{Name: "podinfo", Namespace: "test"}
+	objectCache := &canaryCache{canaries: map[client.ObjectKey]flaggerv1.Canary{
+		key: {Spec: flaggerv1.CanarySpec{Provider: "traefik"}},
+	}}
+
+	service := flagger.NewCachedFetcher(crd.NewNoCacheFetcher(nil), objectCache, logr.Discard())
+
+	canary, err := service.GetCanary(context.Background(), nil, flagger.GetCanaryOptions{
+		Name:        key.Name,
+		Namespace:   key.Namespace,
+		ClusterName: "Default",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "traefik", canary.Spec.Provider)
+	assert.Equal(t, 1, objectCache.gets)
+}
+
+func TestCachedFetcher_FetchTargetRef_NotCached(t *testing.T) {
+	ctx := context.Background()
+
+	clusterClient, _, err := newService(ctx, k8sEnv)
+	assert.NoError(t, err)
+
+	objectCache := &canaryCache{canaries: map[client.ObjectKey]flaggerv1.Canary{}}
+	service := flagger.NewCachedFetcher(crd.NewNoCacheFetcher(nil), objectCache, logr.Discard())
+
+	ns := pdtesting.NewNamespace(ctx, t, k8sEnv.Client)
+	deployment := pdtesting.NewDeployment(ctx, t, k8sEnv.Client, "podinfo", ns.Name)
+
+	canary := &flaggerv1.Canary{}
+	canary.SetNamespace(ns.Name)
+	canary.Spec.TargetRef = flaggerv1.LocalObjectReference{Kind: "Deployment", Name: "podinfo"}
+
+	target, err := service.FetchTargetRef(ctx, "Default", clusterClient, canary)
+	assert.NoError(t, err)
+	assert.Equal(t, deployment.GetUID(), target.UID)
+	assert.Equal(t, 0, objectCache.gets)
+
+}
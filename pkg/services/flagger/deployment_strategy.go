@@ -1,36 +1,29 @@
 package flagger
 
-import "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
-
-type DeploymentStrategy string
-
-const (
-	CanaryDeploymentStrategy          DeploymentStrategy = "canary"
-	BlueGreenDeploymentStrategy       DeploymentStrategy = "blue-green"
-	BlueGreenMirrorDeploymentStrategy DeploymentStrategy = "blue-green-mirror"
-	ABTestingDeploymentStrategy       DeploymentStrategy = "ab-testing"
-	NoAnalysisDeploymentStrategy      DeploymentStrategy = "no-analysis"
+import (
+	"github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/weaveworks/progressive-delivery/pkg/services/delivery"
 )
 
-func (service *defaultFetcher) DeploymentStrategyFor(canary v1beta1.Canary) DeploymentStrategy {
+func (service *defaultFetcher) DeploymentStrategyFor(canary v1beta1.Canary) delivery.DeploymentStrategy {
 	if canary.Spec.SkipAnalysis {
-		return NoAnalysisDeploymentStrategy
+		return delivery.NoAnalysisDeploymentStrategy
 	}
 
 	hasIterations := canary.Spec.Analysis.Iterations > 0
 	hasMatch := len(canary.Spec.Analysis.Match) > 0
 
 	if canary.Spec.Analysis.Mirror && hasIterations {
-		return BlueGreenMirrorDeploymentStrategy
+		return delivery.BlueGreenMirrorDeploymentStrategy
 	}
 
 	if hasIterations && !hasMatch {
-		return BlueGreenDeploymentStrategy
+		return delivery.BlueGreenDeploymentStrategy
 	}
 
 	if hasIterations {
-		return ABTestingDeploymentStrategy
+		return delivery.ABTestingDeploymentStrategy
 	}
 
-	return CanaryDeploymentStrategy
+	return delivery.CanaryDeploymentStrategy
 }
@@ -8,7 +8,7 @@ import (
 	"github.com/fluxcd/flagger/pkg/apis/istio/common/v1alpha1"
 	"github.com/fluxcd/flagger/pkg/apis/istio/v1alpha3"
 	"github.com/stretchr/testify/assert"
-	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"github.com/weaveworks/progressive-delivery/pkg/services/delivery"
 )
 
 func TestFlagger_DeploymentStrategyFor_Canary(t *testing.T) {
@@ -34,7 +34,7 @@ func TestFlagger_DeploymentStrategyFor_Canary(t *testing.T) {
 		},
 	}
 
-	assert.Equal(t, flagger.CanaryDeploymentStrategy, service.DeploymentStrategyFor(canary))
+	assert.Equal(t, delivery.CanaryDeploymentStrategy, service.DeploymentStrategyFor(canary))
 }
 
 func TestFlagger_DeploymentStrategyFor_BlueGreen(t *testing.T) {
@@ -60,7 +60,7 @@ func TestFlagger_DeploymentStrategyFor_BlueGreen(t *testing.T) {
 		},
 	}
 
-	assert.Equal(t, flagger.BlueGreenDeploymentStrategy, service.DeploymentStrategyFor(canary))
+	assert.Equal(t, delivery.BlueGreenDeploymentStrategy, service.DeploymentStrategyFor(canary))
 }
 
 func TestFlagger_DeploymentStrategyFor_BlueGreenMirror(t *testing.T) {
@@ -86,7 +86,7 @@ func TestFlagger_DeploymentStrategyFor_BlueGreenMirror(t *testing.T) {
 		},
 	}
 
-	assert.Equal(t, flagger.BlueGreenMirrorDeploymentStrategy, service.DeploymentStrategyFor(canary))
+	assert.Equal(t, delivery.BlueGreenMirrorDeploymentStrategy, service.DeploymentStrategyFor(canary))
 }
 
 func TestFlagger_DeploymentStrategyFor_ABTesting(t *testing.T) {
@@ -118,7 +118,7 @@ func TestFlagger_DeploymentStrategyFor_ABTesting(t *testing.T) {
 		},
 	}
 
-	assert.Equal(t, flagger.ABTestingDeploymentStrategy, service.DeploymentStrategyFor(canary))
+	assert.Equal(t, delivery.ABTestingDeploymentStrategy, service.DeploymentStrategyFor(canary))
 }
 
 func TestFlagger_DeploymentStrategyFor_NoAnalysis(t *testing.T) {
@@ -133,5 +133,5 @@ func TestFlagger_DeploymentStrategyFor_NoAnalysis(t *testing.T) {
 		},
 	}
 
-	assert.Equal(t, flagger.NoAnalysisDeploymentStrategy, service.DeploymentStrategyFor(canary))
+	assert.Equal(t, delivery.NoAnalysisDeploymentStrategy, service.DeploymentStrategyFor(canary))
 }
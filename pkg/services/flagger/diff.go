@@ -0,0 +1,261 @@
+package flagger
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+)
+
+// Change is how a container or an environment variable of the target differs
+// from the primary.
+type Change string
+
+const (
+	Added   Change = "added"
+	Removed Change = "removed"
+	Changed Change = "changed"
+)
+
+// defaultRegistry is the registry of images without one, its official images
+// are in defaultNamespace.
+const (
+	defaultRegistry  = "docker.io"
+	defaultNamespace = "library"
+)
+
+// ImageReference is a container image split into its parts. The repository is
+// normalized the way the container runtime resolves it, so nginx and
+// docker.io/library/nginx are the same. The tag defaults to latest if neither
+// a tag nor a digest is set.
+type ImageReference struct {
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseImageReference splits an image into repository, tag and digest.
+func ParseImageReference(image string) ImageReference {
+	ref := ImageReference{}
+
+	if i := strings.Index(image, "@"); i >= 0 {
+		ref.Digest = image[i+1:]
+		image = image[:i]
+	}
+
+	// A colon before the last slash is the port of the registry.
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		ref.Tag = image[i+1:]
+		image = image[:i]
+	}
+
+	ref.Repository = normalizeRepository(image)
+
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+
+	return ref
+}
+
+// normalizeRepository prefixes the repository with the default registry if
+// its first component isn't a registry, and official images with the library
+// namespace.
+func normalizeRepository(repository string) string {
+	registry, path := "", repository
+
+	// The first component is a registry if it's a host name, a host with a
+	// port or localhost, like the container runtime parses it.
+	if i := strings.Index(repository, "/"); i >= 0 {
+		if first := repository[:i]; strings.ContainsAny(first, ".:") || first == "localhost" {
+			registry, path = first, repository[i+1:]
+		}
+	}
+
+	if registry == "" || registry == "index.docker.io" {
+		registry = defaultRegistry
+	}
+
+	if registry == defaultRegistry && !strings.Contains(path, "/") {
+		path = defaultNamespace + "/" + path
+	}
+
+	return registry + "/" + path
+}
+
+// ContainerDiff is the difference of a container of the target from the one
+// of the primary with the same name.
+type ContainerDiff struct {
+	Name          string
+	Change        Change
+	TargetImage   ImageReference
+	PromotedImage ImageReference
+
+	RepositoryChanged bool
+	TagChanged        bool
+	DigestChanged     bool
+	Env               []EnvVarDiff
+	ResourcesChanged  bool
+	// ChangedProbes are liveness, readiness or startup.
+	ChangedProbes []string
+}
+
+// EnvVarDiff is the difference of an environment variable. Values from
+// references are shown as the reference, not resolved.
+type EnvVarDiff struct {
+	Name          string
+	Change        Change
+	TargetValue   string
+	PromotedValue string
+}
+
+// DiffContainers returns the containers of the target differing from the
+// primary, in the order of the target, removed ones last.
+func DiffContainers(target, promoted Workload) []ContainerDiff {
+	diffs := []ContainerDiff{}
+
+	promotedContainers := map[string]corev1.Container{}
+	for _, container := range promoted.Containers {
+		promotedContainers[container.Name] = container
+	}
+
+	targetNames := map[string]bool{}
+
+	for _, container := range target.Containers {
+		targetNames[container.Name] = true
+
+		previous, ok := promotedContainers[container.Name]
+		if !ok {
+			diffs = append(diffs, ContainerDiff{
+				Name:        container.Name,
+				Change:      Added,
+				TargetImage: ParseImageReference(container.Image),
+			})
+
+			continue
+		}
+
+		if diff, changed := diffContainer(container, previous); changed {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	for _, container := range promoted.Containers {
+		if targetNames[container.Name] {
+			continue
+		}
+
+		diffs = append(diffs, ContainerDiff{
+			Name:          container.Name,
+			Change:        Removed,
+			PromotedImage: ParseImageReference(container.Image),
+		})
+	}
+
+	return diffs
+}
+
+func diffContainer(target, promoted corev1.Container) (ContainerDiff, bool) {
+	diff := ContainerDiff{
+		Name:          target.Name,
+		Change:        Changed,
+		TargetImage:   ParseImageReference(target.Image),
+		PromotedImage: ParseImageReference(promoted.Image),
+		Env:           diffEnv(target.Env, promoted.Env),
+	}
+
+	diff.RepositoryChanged = diff.TargetImage.Repository != diff.PromotedImage.Repository
+	diff.TagChanged = diff.TargetImage.Tag != diff.PromotedImage.Tag
+	diff.DigestChanged = diff.TargetImage.Digest != diff.PromotedImage.Digest
+	diff.ResourcesChanged = !apiequality.Semantic.DeepEqual(target.Resources, promoted.Resources)
+
+	probes := []struct {
+		name             string
+		target, promoted *corev1.Probe
+	}{
+		{"liveness", target.LivenessProbe, promoted.LivenessProbe},
+		{"readiness", target.ReadinessProbe, promoted.ReadinessProbe},
+		{"startup", target.StartupProbe, promoted.StartupProbe},
+	}
+
+	for _, probe := range probes {
+		if !apiequality.Semantic.DeepEqual(probe.target, probe.promoted) {
+			diff.ChangedProbes = append(diff.ChangedProbes, probe.name)
+		}
+	}
+
+	changed := diff.RepositoryChanged || diff.TagChanged || diff.DigestChanged ||
+		len(diff.Env) > 0 || diff.ResourcesChanged || len(diff.ChangedProbes) > 0
+
+	return diff, changed
+}
+
+func diffEnv(target, promoted []corev1.EnvVar) []EnvVarDiff {
+	diffs := []EnvVarDiff{}
+
+	targetValues := envValues(target, false)
+	promotedValues := envValues(promoted, true)
+
+	for name, value := range targetValues {
+		previous, ok := promotedValues[name]
+
+		switch {
+		case !ok:
+			diffs = append(diffs, EnvVarDiff{Name: name, Change: Added, TargetValue: value})
+		case value != previous:
+			diffs = append(diffs, EnvVarDiff{Name: name, Change: Changed, TargetValue: value, PromotedValue: previous})
+		}
+	}
+
+	for name, previous := range promotedValues {
+		if _, ok := targetValues[name]; !ok {
+			diffs = append(diffs, EnvVarDiff{Name: name, Change: Removed, PromotedValue: previous})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Name < diffs[j].Name })
+
+	return diffs
+}
+
+// envValues returns the value of each environment variable by name. Flagger
+// copies the ConfigMaps and Secrets of the target to the primary with the
+// -primary suffix, references of the primary are shown without it.
+func envValues(env []corev1.EnvVar, primary bool) map[string]string {
+	values := map[string]string{}
+
+	for _, v := range env {
+		values[v.Name] = envValue(v, primary)
+	}
+
+	return values
+}
+
+func envValue(v corev1.EnvVar, primary bool) string {
+	if v.ValueFrom == nil {
+		return v.Value
+	}
+
+	refName := func(name string) string {
+		if primary {
+			return strings.TrimSuffix(name, "-primary")
+		}
+
+		return name
+	}
+
+	switch from := v.ValueFrom; {
+	case from.ConfigMapKeyRef != nil:
+		return fmt.Sprintf("configMapKeyRef:%s/%s", refName(from.ConfigMapKeyRef.Name), from.ConfigMapKeyRef.Key)
+	case from.SecretKeyRef != nil:
+		return fmt.Sprintf("secretKeyRef:%s/%s", refName(from.SecretKeyRef.Name), from.SecretKeyRef.Key)
+	case from.FieldRef != nil:
+		return fmt.Sprintf("fieldRef:%s", from.FieldRef.FieldPath)
+	case from.ResourceFieldRef != nil:
+		return fmt.Sprintf("resourceFieldRef:%s/%s", from.ResourceFieldRef.ContainerName, from.ResourceFieldRef.Resource)
+	}
+
+	return ""
+}
@@ -0,0 +1,124 @@
+package flagger_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestParseImageReference(t *testing.T) {
+	tests := []struct {
+		image    string
+		expected flagger.ImageReference
+	}{
+		{image: "nginx", expected: flagger.ImageReference{Repository: "docker.io/library/nginx", Tag: "latest"}},
+		{image: "docker.io/library/nginx", expected: flagger.ImageReference{Repository: "docker.io/library/nginx", Tag: "latest"}},
+		{image: "index.docker.io/nginx:1.25", expected: flagger.ImageReference{Repository: "docker.io/library/nginx", Tag: "1.25"}},
+		{image: "stefanprodan/podinfo:6.3.5", expected: flagger.ImageReference{Repository: "docker.io/stefanprodan/podinfo", Tag: "6.3.5"}},
+		{image: "ghcr.io/stefanprodan/podinfo:6.3.5", expected: flagger.ImageReference{Repository: "ghcr.io/stefanprodan/podinfo", Tag: "6.3.5"}},
+		{image: "localhost:5000/podinfo", expected: flagger.ImageReference{Repository: "localhost:5000/podinfo", Tag: "latest"}},
+		{image: "localhost/podinfo", expected: flagger.ImageReference{Repository: "localhost/podinfo", Tag: "latest"}},
+		{
+			image:    "localhost:5000/podinfo:6.3.5@sha256:abc",
+			expected: flagger.ImageReference{Repository: "localhost:5000/podinfo", Tag: "6.3.5", Digest: "sha256:abc"},
+		},
+		{image: "podinfo@sha256:abc", expected: flagger.ImageReference{Repository: "docker.io/library/podinfo", Digest: "sha256:abc"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			assert.Equal(t, tt.expected, flagger.ParseImageReference(tt.image))
+		})
+	}
+}
+
+func TestDiffContainers(t *testing.T) {
+	promoted := flagger.Workload{
+		Name: "podinfo-primary",
+		Containers: []corev1.Container{
+			{
+				Name:  "podinfo",
+				Image: "ghcr.io/stefanprodan/podinfo:6.3.4",
+				Env: []corev1.EnvVar{
+					{Name: "LEVEL", Value: "info"},
+					{Name: "REMOVED", Value: "true"},
+					{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "podinfo-primary"},
+						Key:                  "token",
+					}}},
+					{Name: "CONFIG", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "podinfo-primary"},
+						Key:                  "config",
+					}}},
+				},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				},
+			},
+			{Name: "sidecar", Image: "envoy:v1.25"},
+			{Name: "legacy", Image: "legacy:1.0"},
+		},
+	}
+
+	target := flagger.Workload{
+		Name: "podinfo",
+		Containers: []corev1.Container{
+			{
+				Name:  "podinfo",
+				Image: "ghcr.io/stefanprodan/podinfo:6.3.5",
+				Env: []corev1.EnvVar{
+					{Name: "LEVEL", Value: "debug"},
+					{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "podinfo"},
+						Key:                  "token",
+					}}},
+					{Name: "CONFIG", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "podinfo"},
+						Key:                  "config",
+					}}},
+					{Name: "ADDED", Value: "true"},
+				},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("0.1")},
+				},
+				ReadinessProbe: &corev1.Probe{PeriodSeconds: 5},
+			},
+			{Name: "sidecar", Image: "docker.io/library/envoy:v1.25"},
+			{Name: "metrics", Image: "exporter:0.1"},
+		},
+	}
+
+	// The images of the sidecars are the same once normalized.
+	diffs := flagger.DiffContainers(target, promoted)
+	assert.Len(t, diffs, 3)
+
+	podinfo := diffs[0]
+	assert.Equal(t, "podinfo", podinfo.Name)
+	assert.Equal(t, flagger.Changed, podinfo.Change)
+	assert.Equal(t, "6.3.5", podinfo.TargetImage.Tag)
+	assert.Equal(t, "6.3.4", podinfo.PromotedImage.Tag)
+	assert.True(t, podinfo.TagChanged)
+	assert.False(t, podinfo.RepositoryChanged)
+	assert.False(t, podinfo.DigestChanged)
+	assert.False(t, podinfo.ResourcesChanged, "100m and 0.1 are the same quantity")
+	assert.Equal(t, []string{"readiness"}, podinfo.ChangedProbes)
+	// TOKEN and CONFIG of the primary reference the copies Flagger makes.
+	assert.Equal(t, []flagger.EnvVarDiff{
+		{Name: "ADDED", Change: flagger.Added, TargetValue: "true"},
+		{Name: "LEVEL", Change: flagger.Changed, TargetValue: "debug", PromotedValue: "info"},
+		{Name: "REMOVED", Change: flagger.Removed, PromotedValue: "true"},
+	}, podinfo.Env)
+
+	assert.Equal(t, "metrics", diffs[1].Name)
+	assert.Equal(t, flagger.Added, diffs[1].Change)
+	assert.Equal(t, "docker.io/library/exporter", diffs[1].TargetImage.Repository)
+
+	assert.Equal(t, "legacy", diffs[2].Name)
+	assert.Equal(t, flagger.Removed, diffs[2].Change)
+	assert.Equal(t, "docker.io/library/legacy", diffs[2].PromotedImage.Repository)
+
+	assert.Empty(t, flagger.DiffContainers(target, target))
+}
@@ -0,0 +1,253 @@
+package flagger
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// defaultEnrichConcurrency is the number of lists made at once per cluster if
+// not set.
+const defaultEnrichConcurrency = 4
+
+// CanaryObjects are the objects a Canary is shown with. Workloads are empty if
+// not found, Flagger creates the primary once the Canary is initialized.
+type CanaryObjects struct {
+	Target          Workload
+	Promoted        Workload
+	MetricTemplates []flaggerv1.MetricTemplate
+}
+
+type FetchCanaryObjectsOptions struct {
+	// Concurrency is the number of lists made at once per cluster, clusters
+	// are listed concurrently.
+	Concurrency int
+}
+
+// FetchCanaryObjects returns the objects of the Canaries of each cluster, in
+// the same order. Targets and MetricTemplates are listed once per kind and
+// namespace and joined to the Canaries, instead of read one by one. Failing
+// lists and missing MetricTemplates are returned as errors, the objects found
+// are still returned.
+func (service *defaultFetcher) FetchCanaryObjects(
+	ctx context.Context,
+	clusterClient clustersmngr.Client,
+	canaries map[string][]flaggerv1.Canary,
+	opts FetchCanaryObjectsOptions,
+) (map[string][]CanaryObjects, []CanaryObjectsError) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultEnrichConcurrency
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = map[string][]CanaryObjects{}
+		errs    = []CanaryObjectsError{}
+	)
+
+	for clusterName, list := range canaries {
+		wg.Add(1)
+
+		go func(clusterName string, list []flaggerv1.Canary) {
+			defer wg.Done()
+
+			objects, clusterErrs := service.fetchClusterCanaryObjects(ctx, clusterName, clusterClient, list, opts.Concurrency)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			results[clusterName] = objects
+			errs = append(errs, clusterErrs...)
+		}(clusterName, list)
+	}
+
+	wg.Wait()
+
+	return results, errs
+}
+
+// namespaceObjects are the objects listed in a namespace of a cluster.
+type namespaceObjects struct {
+	// workloads are keyed by group, kind and name.
+	workloads       map[string]Workload
+	metricTemplates map[string]flaggerv1.MetricTemplate
+}
+
+func (service *defaultFetcher) fetchClusterCanaryObjects(
+	ctx context.Context,
+	clusterName string,
+	clusterClient clustersmngr.Client,
+	canaries []flaggerv1.Canary,
+	concurrency int,
+) ([]CanaryObjects, []CanaryObjectsError) {
+	workloadNamespaces := map[schema.GroupVersionKind]sets.String{}
+	templateNamespaces := sets.NewString()
+
+	for _, canary := range canaries {
+		kind := TargetGroupVersionKind(canary)
+		if workloadNamespaces[kind] == nil {
+			workloadNamespaces[kind] = sets.NewString()
+		}
+
+		workloadNamespaces[kind].Insert(canary.GetNamespace())
+
+		for _, ref := range MetricTemplateRefs(canary) {
+			templateNamespaces.Insert(ref.Namespace)
+		}
+	}
+
+	var (
+		mu         sync.Mutex
+		wg         sync.WaitGroup
+		errs       = []CanaryObjectsError{}
+		namespaces = map[string]*namespaceObjects{}
+		semaphore  = make(chan struct{}, concurrency)
+		// templatesListed are the namespaces whose MetricTemplates were
+		// listed, the failed lists are already reported.
+		templatesListed = sets.NewString()
+	)
+
+	objectsIn := func(namespace string) *namespaceObjects {
+		if namespaces[namespace] == nil {
+			namespaces[namespace] = &namespaceObjects{
+				workloads:       map[string]Workload{},
+				metricTemplates: map[string]flaggerv1.MetricTemplate{},
+			}
+		}
+
+		return namespaces[namespace]
+	}
+
+	list := func(namespace string, newList func() client.ObjectList, join func(*namespaceObjects, client.ObjectList)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				mu.Lock()
+				errs = append(errs, CanaryObjectsError{ClusterName: clusterName, Namespace: namespace, Err: ctx.Err()})
+				mu.Unlock()
+
+				return
+			}
+
+			objects := newList()
+			err := service.list(ctx, clusterName, clusterClient, objects, client.InNamespace(namespace))
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				errs = append(errs, CanaryObjectsError{ClusterName: clusterName, Namespace: namespace, Err: err})
+				return
+			}
+
+			join(objectsIn(namespace), objects)
+		}()
+	}
+
+	for kind, kindNamespaces := range workloadNamespaces {
+		kind := kind
+
+		for _, namespace := range kindNamespaces.List() {
+			list(namespace, func() client.ObjectList { return newWorkloadList(kind) }, func(objects *namespaceObjects, l client.ObjectList) {
+				for _, workload := range workloadsFromList(l) {
+					objects.workloads[workloadKey(kind.GroupKind(), workload.Name)] = workload
+				}
+			})
+		}
+	}
+
+	for _, namespace := range templateNamespaces.List() {
+		namespace := namespace
+
+		list(namespace, func() client.ObjectList { return &flaggerv1.MetricTemplateList{} }, func(objects *namespaceObjects, l client.ObjectList) {
+			templatesListed.Insert(namespace)
+
+			for _, template := range l.(*flaggerv1.MetricTemplateList).Items {
+				objects.metricTemplates[template.GetName()] = template
+			}
+		})
+	}
+
+	wg.Wait()
+
+	results := make([]CanaryObjects, len(canaries))
+
+	for i, canary := range canaries {
+		objects := objectsIn(canary.GetNamespace())
+		kind := TargetGroupVersionKind(canary).GroupKind()
+
+		results[i] = CanaryObjects{
+			Target:          objects.workloads[workloadKey(kind, canary.Spec.TargetRef.Name)],
+			MetricTemplates: []flaggerv1.MetricTemplate{},
+		}
+
+		if HasPrimary(canary) {
+			results[i].Promoted = objects.workloads[workloadKey(kind, PrimaryName(canary))]
+		}
+
+		for _, ref := range MetricTemplateRefs(canary) {
+			if !templatesListed.Has(ref.Namespace) {
+				continue
+			}
+
+			template, ok := objectsIn(ref.Namespace).metricTemplates[ref.Name]
+			if !ok {
+				errs = append(errs, CanaryObjectsError{
+					ClusterName: clusterName,
+					Namespace:   canary.GetNamespace(),
+					Err:         MetricTemplateNotFoundError{Canary: canary.GetName(), Name: ref.Name, Namespace: ref.Namespace},
+				})
+
+				continue
+			}
+
+			results[i].MetricTemplates = append(results[i].MetricTemplates, template)
+		}
+	}
+
+	return results, errs
+}
+
+func workloadKey(kind schema.GroupKind, name string) string {
+	return fmt.Sprintf("%s/%s", kind, name)
+}
+
+// MetricTemplateRefs returns the MetricTemplates the metrics of the Canary
+// reference, they default to the namespace of the Canary.
+func MetricTemplateRefs(canary flaggerv1.Canary) []types.NamespacedName {
+	refs := []types.NamespacedName{}
+
+	if canary.GetAnalysis() == nil {
+		return refs
+	}
+
+	for _, metric := range canary.GetAnalysis().Metrics {
+		if metric.TemplateRef == nil {
+			continue
+		}
+
+		namespace := metric.TemplateRef.Namespace
+		if namespace == "" {
+			namespace = canary.GetNamespace()
+		}
+
+		refs = append(refs, types.NamespacedName{Name: metric.TemplateRef.Name, Namespace: namespace})
+	}
+
+	return refs
+}
@@ -0,0 +1,146 @@
+package flagger_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
+	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestFetcher_FetchCanaryObjects(t *testing.T) {
+	ctx := context.Background()
+
+	clusterClient, service, err := newService(ctx, k8sEnv)
+	assert.NoError(t, err)
+
+	ns := pdtesting.NewNamespace(ctx, t, k8sEnv.Client)
+	templatesNs := pdtesting.NewNamespace(ctx, t, k8sEnv.Client)
+
+	target := pdtesting.NewDeployment(ctx, t, k8sEnv.Client, "backend", ns.Name)
+	promoted := pdtesting.NewDeployment(ctx, t, k8sEnv.Client, "backend-primary", ns.Name)
+	local := pdtesting.NewMetricTemplate(ctx, t, k8sEnv.Client, pdtesting.MetricTemplateInfo{
+		Name:         "latency",
+		Namespace:    ns.Name,
+		ProviderType: "prometheus",
+	})
+	shared := pdtesting.NewMetricTemplate(ctx, t, k8sEnv.Client, pdtesting.MetricTemplateInfo{
+		Name:         "error-rate",
+		Namespace:    templatesNs.Name,
+		ProviderType: "prometheus",
+	})
+
+	newCanary := func(name, targetName string, refs ...flaggerv1.CrossNamespaceObjectReference) flaggerv1.Canary {
+		canary := flaggerv1.Canary{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns.Name},
+			Spec: flaggerv1.CanarySpec{
+				TargetRef: flaggerv1.LocalObjectReference{Kind: "Deployment", Name: targetName},
+				Analysis:  &flaggerv1.CanaryAnalysis{},
+			},
+		}
+
+		for i := range refs {
+			canary.Spec.Analysis.Metrics = append(canary.Spec.Analysis.Metrics, flaggerv1.CanaryMetric{Name: refs[i].Name, TemplateRef: &refs[i]})
+		}
+
+		return canary
+	}
+
+	canaries := map[string][]flaggerv1.Canary{
+		"Default": {
+			newCanary("backend", "backend",
+				flaggerv1.CrossNamespaceObjectReference{Name: "latency"},
+				flaggerv1.CrossNamespaceObjectReference{Name: "error-rate", Namespace: templatesNs.Name},
+			),
+			newCanary("frontend", "frontend",
+				flaggerv1.CrossNamespaceObjectReference{Name: "missing"},
+			),
+		},
+	}
+
+	objects, errs := service.FetchCanaryObjects(ctx, clusterClient, canaries, flagger.FetchCanaryObjectsOptions{Concurrency: 1})
+
+	assert.Len(t, objects["Default"], 2)
+
+	backend := objects["Default"][0]
+	assert.Equal(t, target.GetUID(), backend.Target.UID)
+	assert.Equal(t, promoted.GetUID(), backend.Promoted.UID)
+	assert.Len(t, backend.MetricTemplates, 2)
+	assert.Equal(t, local.GetUID(), backend.MetricTemplates[0].GetUID())
+	assert.Equal(t, shared.GetUID(), backend.MetricTemplates[1].GetUID())
+
+	// Missing objects are empty, missing templates are reported.
+	frontend := objects["Default"][1]
+	assert.Empty(t, frontend.Target.Name)
+	assert.Empty(t, frontend.MetricTemplates)
+
+	assert.Len(t, errs, 1)
+	assert.Equal(t, ns.Name, errs[0].Namespace)
+
+	var notFound flagger.MetricTemplateNotFoundError
+	assert.True(t, errors.As(errs[0].Err, &notFound))
+	assert.Equal(t, "missing", notFound.Name)
+}
+
+func TestFetcher_FetchCanaryObjects_Deadline(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	clusterClient, service, err := newService(context.Background(), k8sEnv)
+	assert.NoError(t, err)
+
+	canary := flaggerv1.Canary{ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "default"}}
+
+	objects, errs := service.FetchCanaryObjects(ctx, clusterClient, map[string][]flaggerv1.Canary{"Default": {canary}}, flagger.FetchCanaryObjectsOptions{})
+
+	assert.Len(t, objects["Default"], 1)
+	assert.NotEmpty(t, errs)
+	assert.Equal(t, "Default", errs[0].ClusterName)
+}
+
+// failingTemplatesCache serves empty lists of workloads and fails lists of
+// MetricTemplates.
+type failingTemplatesCache struct{}
+
+func (failingTemplatesCache) Get(context.Context, string, clustersmngr.Client, client.ObjectKey, client.Object) (bool, error) {
+	return false, nil
+}
+
+func (failingTemplatesCache) List(_ context.Context, _ string, _ clustersmngr.Client, list client.ObjectList, _ ...client.ListOption) (bool, error) {
+	if _, ok := list.(*flaggerv1.MetricTemplateList); ok {
+		return true, errors.New("list failed")
+	}
+
+	return true, nil
+}
+
+func TestFetcher_FetchCanaryObjects_FailedTemplateList(t *testing.T) {
+	service := flagger.NewCachedFetcher(crd.NewNoCacheFetcher(nil), failingTemplatesCache{}, logr.Discard())
+
+	canary := flaggerv1.Canary{
+		ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "default"},
+		Spec: flaggerv1.CanarySpec{
+			TargetRef: flaggerv1.LocalObjectReference{Kind: "Deployment", Name: "backend"},
+			Analysis: &flaggerv1.CanaryAnalysis{
+				Metrics: []flaggerv1.CanaryMetric{{Name: "latency", TemplateRef: &flaggerv1.CrossNamespaceObjectReference{Name: "latency"}}},
+			},
+		},
+	}
+
+	objects, errs := service.FetchCanaryObjects(context.Background(), nil, map[string][]flaggerv1.Canary{"Default": {canary}}, flagger.FetchCanaryObjectsOptions{})
+
+	assert.Len(t, objects["Default"], 1)
+	assert.Empty(t, objects["Default"][0].MetricTemplates)
+
+	// Only the failed list is reported, not the templates it would have found.
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0].Err, "list failed")
+}
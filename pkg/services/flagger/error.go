@@ -27,3 +27,84 @@ type MetricTemplateListError struct {
 func (e MetricTemplateListError) Error() string {
 	return fmt.Sprintf("metric template list error on cluster %s: %s", e.ClusterName, e.Err.Error())
 }
+
+type CanaryGateNotFoundError struct {
+	Name      string
+	Namespace string
+	Gate      string
+}
+
+func (e CanaryGateNotFoundError) Error() string {
+	return fmt.Sprintf("canary %s/%s has no %s gate webhook", e.Namespace, e.Name, e.Gate)
+}
+
+// CanaryGateHostNotAllowedError is returned for gates of loadtesters that
+// aren't configured, the server would send requests to any URL otherwise.
+type CanaryGateHostNotAllowedError struct {
+	Name      string
+	Namespace string
+	Host      string
+}
+
+func (e CanaryGateHostNotAllowedError) Error() string {
+	return fmt.Sprintf("gate host %s of canary %s/%s is not an allowed loadtester host", e.Host, e.Namespace, e.Name)
+}
+
+// MetricProviderHostNotAllowedError is returned for metric templates whose
+// provider isn't configured, the server would send queries to any URL
+// otherwise.
+type MetricProviderHostNotAllowedError struct {
+	Name      string
+	Namespace string
+	Host      string
+}
+
+func (e MetricProviderHostNotAllowedError) Error() string {
+	return fmt.Sprintf("provider host %s of metric template %s/%s is not an allowed metric provider host", e.Host, e.Namespace, e.Name)
+}
+
+// CanaryNotProgressingError is returned when promoting a Canary without a
+// rollout in progress.
+type CanaryNotProgressingError struct {
+	Name      string
+	Namespace string
+	Phase     string
+}
+
+func (e CanaryNotProgressingError) Error() string {
+	return fmt.Sprintf("canary %s/%s has no rollout in progress to promote, its phase is %q", e.Namespace, e.Name, e.Phase)
+}
+
+// CanarySuspendNotSupportedError is returned when pausing or resuming a Canary
+// without a confirmation gate, on a cluster whose Flagger has no spec.suspend.
+type CanarySuspendNotSupportedError struct {
+	Name        string
+	Namespace   string
+	ClusterName string
+}
+
+func (e CanarySuspendNotSupportedError) Error() string {
+	return fmt.Sprintf("canary %s/%s has no confirm gate webhook and flagger on cluster %s doesn't support spec.suspend", e.Namespace, e.Name, e.ClusterName)
+}
+
+// CanaryObjectsError is a failure to fetch the objects of the Canaries of a
+// namespace.
+type CanaryObjectsError struct {
+	ClusterName string
+	Namespace   string
+	Err         error
+}
+
+func (e CanaryObjectsError) Error() string {
+	return fmt.Sprintf("canary objects error on cluster %s namespace %s: %s", e.ClusterName, e.Namespace, e.Err.Error())
+}
+
+type MetricTemplateNotFoundError struct {
+	Canary    string
+	Name      string
+	Namespace string
+}
+
+func (e MetricTemplateNotFoundError) Error() string {
+	return fmt.Sprintf("metric template %s/%s of canary %s not found", e.Namespace, e.Name, e.Canary)
+}
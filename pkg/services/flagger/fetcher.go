@@ -2,55 +2,126 @@ package flagger
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"net/http"
+	"sort"
 
 	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/go-multierror"
+	"github.com/weaveworks/progressive-delivery/pkg/pagination"
 	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+	"github.com/weaveworks/progressive-delivery/pkg/services/delivery"
 	"github.com/weaveworks/weave-gitops/core/clustersmngr"
-	v1 "k8s.io/api/apps/v1"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type Fetcher interface {
-	DeploymentStrategyFor(canary flaggerv1.Canary) DeploymentStrategy
-	FetchTargetRef(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, canary *flaggerv1.Canary) (v1.Deployment, error)
-	FetchPromoted(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, canary *flaggerv1.Canary) (v1.Deployment, error)
+	AttributesOf(clusterName string, canary flaggerv1.Canary) delivery.Attributes
+	DeploymentStrategyFor(canary flaggerv1.Canary) delivery.DeploymentStrategy
+	FetchTargetRef(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, canary *flaggerv1.Canary) (Workload, error)
+	FetchPromoted(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, canary *flaggerv1.Canary) (Workload, error)
+	FlaggerVersion(ctx context.Context, clusterName string, clusterClient clustersmngr.Client) (string, error)
+	FetchCanaryObjects(ctx context.Context, clusterClient clustersmngr.Client, canaries map[string][]flaggerv1.Canary, opts FetchCanaryObjectsOptions) (map[string][]CanaryObjects, []CanaryObjectsError)
 	GetCanary(ctx context.Context, client clustersmngr.Client, opts GetCanaryOptions) (*flaggerv1.Canary, error)
 	GetMetricTemplate(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, name, namespace string) (flaggerv1.MetricTemplate, error)
 	ListCanaryDeployments(ctx context.Context, client clustersmngr.Client, opts ListCanaryDeploymentsOptions) (map[string][]flaggerv1.Canary, string, []CanaryListError, error)
 	ListMetricTemplates(ctx context.Context, clusterClient clustersmngr.Client, options ListMetricTemplatesOptions) (map[string][]flaggerv1.MetricTemplate, string, []MetricTemplateListError, error)
 	ListCanaryObjects(ctx context.Context, clusterClient clustersmngr.Client, opts ListCanaryObjectsOptions) ([]unstructured.Unstructured, error)
+	ListCanaryObjectTree(ctx context.Context, clusterClient clustersmngr.Client, opts ListCanaryObjectsOptions) (*ObjectNode, error)
+	RunMetricTemplate(ctx context.Context, clusterClient clustersmngr.Client, opts RunMetricTemplateOptions) (*MetricTemplateRun, error)
+	ValidateCanary(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, canary *flaggerv1.Canary) ([]Finding, error)
+	PromoteCanary(ctx context.Context, clusterClient clustersmngr.Client, opts CanaryActionOptions) (*flaggerv1.Canary, error)
+	RollbackCanary(ctx context.Context, clusterClient clustersmngr.Client, opts CanaryActionOptions) (*flaggerv1.Canary, error)
+	PauseCanary(ctx context.Context, clusterClient clustersmngr.Client, opts CanaryActionOptions) (*flaggerv1.Canary, error)
+	ResumeCanary(ctx context.Context, clusterClient clustersmngr.Client, opts CanaryActionOptions) (*flaggerv1.Canary, error)
+	ListCanaryEvents(ctx context.Context, clusterClient clustersmngr.Client, opts ListCanaryEventsOptions) ([]TimelineEvent, error)
+	WatchCanaries(ctx context.Context, clusterClient clustersmngr.Client, clusters []cluster.Cluster, user *auth.UserPrincipal, opts WatchCanariesOptions) (<-chan CanaryEvent, error)
+	WatchAllCanaries(ctx context.Context, clusters []cluster.Cluster, opts WatchCanariesOptions) (<-chan CanaryEvent, error)
+}
+
+// ObjectCache serves reads of objects without a request to the API server.
+// Get and List return false if they can't serve the objects, they're read
+// with the client then.
+type ObjectCache interface {
+	Get(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, key client.ObjectKey, obj client.Object) (bool, error)
+	List(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, list client.ObjectList, opts ...client.ListOption) (bool, error)
 }
 
 func NewFetcher(crdService crd.Fetcher, logger logr.Logger) Fetcher {
-	fetcher := &defaultFetcher{crdService: crdService, logger: logger}
+	fetcher := &defaultFetcher{
+		crdService: crdService,
+		logger:     logger,
+		httpClient: gateHTTPClient,
+	}
+
+	return fetcher
+}
+
+// NewCachedFetcher returns a fetcher reading Canaries, MetricTemplates and
+// Deployments from the cache when it can, including the lists of the objects
+// of listed Canaries. Lists of Canaries still go to the API server, page
+// tokens need them.
+func NewCachedFetcher(crdService crd.Fetcher, objectCache ObjectCache, logger logr.Logger) Fetcher {
+	fetcher := &defaultFetcher{
+		crdService: crdService,
+		cache:      objectCache,
+		logger:     logger,
+		httpClient: gateHTTPClient,
+	}
 
 	return fetcher
 }
 
 type defaultFetcher struct {
 	crdService crd.Fetcher
+	cache      ObjectCache
 	logger     logr.Logger
+	httpClient *http.Client
+}
+
+// get reads the object from the cache if there's one, or with the client.
+func (service *defaultFetcher) get(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, key client.ObjectKey, obj client.Object) error {
+	if service.cache != nil {
+		if served, err := service.cache.Get(ctx, clusterName, clusterClient, key, obj); served {
+			return err
+		}
+	}
+
+	return clusterClient.Get(ctx, clusterName, key, obj)
+}
+
+// list lists the objects from the cache if there's one, or with the client.
+func (service *defaultFetcher) list(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, list client.ObjectList, opts ...client.ListOption) error {
+	if service.cache != nil {
+		if served, err := service.cache.List(ctx, clusterName, clusterClient, list, opts...); served {
+			return err
+		}
+	}
+
+	return clusterClient.List(ctx, clusterName, list, opts...)
 }
 
 type ListCanaryDeploymentsOptions struct {
-	Namespace string
+	delivery.Filter
+	Sort      delivery.SortOptions
 	PageSize  int32
 	PageToken string
 }
 
 type ListMetricTemplatesOptions struct {
-	Namespace string
-	PageSize  int32
-	PageToken string
+	ClusterName string
+	Namespace   string
+	PageSize    int32
+	PageToken   string
 }
 
 type GetCanaryOptions struct {
@@ -72,60 +143,80 @@ func (service *defaultFetcher) ListCanaryDeployments(
 ) (map[string][]flaggerv1.Canary, string, []CanaryListError, error) {
 	var respErrors []CanaryListError
 
-	clist := clustersmngr.NewClusteredList(func() client.ObjectList {
-		return &flaggerv1.CanaryList{}
-	})
+	if err := options.Sort.Validate(); err != nil {
+		return nil, "", respErrors, err
+	}
 
-	opts := []client.ListOption{}
-	if options.PageSize != 0 {
-		opts = append(opts, client.Limit(options.PageSize))
+	selector, err := options.Selector()
+	if err != nil {
+		return nil, "", respErrors, err
 	}
 
-	if options.PageToken != "" {
-		opts = append(opts, client.Continue(options.PageToken))
+	items, nextPageToken, listErrors, err := pagination.List(
+		ctx,
+		clusterClient,
+		func() client.ObjectList { return &flaggerv1.CanaryList{} },
+		pagination.ListOptions{
+			PageSize:    options.PageSize,
+			PageToken:   options.PageToken,
+			Namespace:   options.Namespace,
+			SkipCluster: service.skipCluster(options.ClusterName),
+			Keep: func(clusterName string, obj runtime.Object) bool {
+				canary, ok := obj.(*flaggerv1.Canary)
+
+				return ok && options.Matches(service.AttributesOf(clusterName, *canary))
+			},
+			ListOptions: []client.ListOption{client.MatchingLabelsSelector{Selector: selector}},
+		},
+	)
+	if err != nil {
+		return nil, "", respErrors, err
 	}
 
-	if err := clusterClient.ClusteredList(ctx, clist, true, opts...); err != nil {
-		var errs clustersmngr.ClusteredListError
-		if !errors.As(err, &errs) {
-			return nil, "", respErrors, err
-		}
+	for _, e := range listErrors {
+		respErrors = append(respErrors, CanaryListError{ClusterName: e.Cluster, Err: e.Err})
+	}
 
-		for _, e := range errs.Errors {
-			// If Flagger is not installed, skip all errors reported from that
-			// cluster, will log an error later if Flagger is not available.
-			if service.crdService.IsAvailable(e.Cluster, crd.FlaggerCRDName) {
-				respErrors = append(respErrors, CanaryListError{ClusterName: e.Cluster, Err: e.Err})
-			}
+	results := map[string][]flaggerv1.Canary{}
+
+	for _, item := range items {
+		if canary, ok := item.Object.(*flaggerv1.Canary); ok {
+			results[item.ClusterName] = append(results[item.ClusterName], *canary)
 		}
 	}
 
-	results := map[string][]flaggerv1.Canary{}
+	for clusterName := range results {
+		if options.Sort.By != "" {
+			canaries := results[clusterName]
 
-	for clusterName, lists := range clist.Lists() {
-		// log an error if Flagger is not available on a cluster.
-		if !service.crdService.IsAvailable(clusterName, crd.FlaggerCRDName) {
-			e := CanaryListError{
-				ClusterName: clusterName,
-				Err:         FlaggerIsNotAvailableError{ClusterName: clusterName},
-			}
-			service.logger.Error(e, "flagger unavailable")
-			results[clusterName] = []flaggerv1.Canary{}
+			sort.SliceStable(canaries, func(i, j int) bool {
+				return options.Sort.Less(
+					service.AttributesOf(clusterName, canaries[i]),
+					service.AttributesOf(clusterName, canaries[j]),
+				)
+			})
+		}
+	}
 
-			continue
+	return results, nextPageToken, respErrors, nil
+}
+
+// skipCluster returns a function excluding clusters other than clusterName,
+// if set, and clusters where Flagger is not available.
+func (service *defaultFetcher) skipCluster(clusterName string) func(string) bool {
+	return func(name string) bool {
+		if clusterName != "" && clusterName != name {
+			return true
 		}
 
-		for _, l := range lists {
-			list, ok := l.(*flaggerv1.CanaryList)
-			if !ok {
-				continue
-			}
+		if !service.crdService.IsAvailable(name, crd.FlaggerCRDName) {
+			service.logger.Error(FlaggerIsNotAvailableError{ClusterName: name}, "flagger unavailable")
 
-			results[clusterName] = append(results[clusterName], list.Items...)
+			return true
 		}
-	}
 
-	return results, clist.GetContinue(), respErrors, nil
+		return false
+	}
 }
 
 func (service *defaultFetcher) GetCanary(
@@ -139,7 +230,7 @@ func (service *defaultFetcher) GetCanary(
 		Namespace: opts.Namespace,
 	}
 
-	if err := clustersClient.Get(ctx, opts.ClusterName, key, k); err != nil {
+	if err := service.get(ctx, opts.ClusterName, clustersClient, key, k); err != nil {
 		return nil, fmt.Errorf("failed getting canary: name=%s namespace=%s cluster=%s err=%w", opts.Name, opts.Namespace, opts.ClusterName, err)
 	}
 
@@ -159,7 +250,7 @@ func (service *defaultFetcher) GetMetricTemplate(
 		Namespace: namespace,
 	}
 
-	err := clusterClient.Get(ctx, clusterName, key, &object)
+	err := service.get(ctx, clusterName, clusterClient, key, &object)
 
 	return object, err
 }
@@ -169,18 +260,23 @@ func (service *defaultFetcher) FetchTargetRef(
 	clusterName string,
 	clusterClient clustersmngr.Client,
 	canary *flaggerv1.Canary,
-) (v1.Deployment, error) {
-	return getDeployment(ctx, clusterName, clusterClient, canary.Spec.TargetRef.Name, canary.GetNamespace())
+) (Workload, error) {
+	return service.getWorkload(ctx, clusterName, clusterClient, TargetGroupVersionKind(*canary), canary.Spec.TargetRef.Name, canary.GetNamespace())
 }
 
+// FetchPromoted returns the primary of the target of the Canary, or an empty
+// Workload for targets without one.
 func (service *defaultFetcher) FetchPromoted(
 	ctx context.Context,
 	clusterName string,
 	clusterClient clustersmngr.Client,
 	canary *flaggerv1.Canary,
-) (v1.Deployment, error) {
-	name := fmt.Sprintf("%s-primary", canary.Spec.TargetRef.Name)
-	return getDeployment(ctx, clusterName, clusterClient, name, canary.GetNamespace())
+) (Workload, error) {
+	if !HasPrimary(*canary) {
+		return Workload{}, nil
+	}
+
+	return service.getWorkload(ctx, clusterName, clusterClient, TargetGroupVersionKind(*canary), PrimaryName(*canary), canary.GetNamespace())
 }
 
 func (service *defaultFetcher) ListMetricTemplates(
@@ -190,73 +286,50 @@ func (service *defaultFetcher) ListMetricTemplates(
 ) (map[string][]flaggerv1.MetricTemplate, string, []MetricTemplateListError, error) {
 	var respErrors []MetricTemplateListError
 
-	clist := clustersmngr.NewClusteredList(func() client.ObjectList {
-		return &flaggerv1.MetricTemplateList{}
-	})
-
-	opts := []client.ListOption{}
-	if options.PageSize != 0 {
-		opts = append(opts, client.Limit(options.PageSize))
-	}
-
-	if options.PageToken != "" {
-		opts = append(opts, client.Continue(options.PageToken))
+	items, nextPageToken, listErrors, err := pagination.List(
+		ctx,
+		clusterClient,
+		func() client.ObjectList { return &flaggerv1.MetricTemplateList{} },
+		pagination.ListOptions{
+			PageSize:    options.PageSize,
+			PageToken:   options.PageToken,
+			Namespace:   options.Namespace,
+			SkipCluster: service.skipCluster(options.ClusterName),
+		},
+	)
+	if err != nil {
+		return nil, "", respErrors, err
 	}
 
-	if err := clusterClient.ClusteredList(ctx, clist, true, opts...); err != nil {
-		var errs clustersmngr.ClusteredListError
-		if !errors.As(err, &errs) {
-			return nil, "", respErrors, err
-		}
-
-		for _, e := range errs.Errors {
-			// If Flagger is not installed, skip all errors reported from that
-			// cluster, will log an error later if Flagger is not available.
-			if service.crdService.IsAvailable(e.Cluster, crd.FlaggerCRDName) {
-				respErrors = append(respErrors, MetricTemplateListError{ClusterName: e.Cluster, Err: e.Err})
-			}
-		}
+	for _, e := range listErrors {
+		respErrors = append(respErrors, MetricTemplateListError{ClusterName: e.Cluster, Err: e.Err})
 	}
 
 	results := map[string][]flaggerv1.MetricTemplate{}
 
-	for clusterName, lists := range clist.Lists() {
-		// log an error if Flagger is not available on a cluster.
-		if !service.crdService.IsAvailable(clusterName, crd.FlaggerCRDName) {
-			e := MetricTemplateListError{
-				ClusterName: clusterName,
-				Err:         FlaggerIsNotAvailableError{ClusterName: clusterName},
-			}
-			service.logger.Error(e, "flagger unavailable")
-			results[clusterName] = []flaggerv1.MetricTemplate{}
-
-			continue
-		}
-
-		for _, l := range lists {
-
-			list, ok := l.(*flaggerv1.MetricTemplateList)
-			if !ok {
-				continue
-			}
-
-			results[clusterName] = append(results[clusterName], list.Items...)
+	for _, item := range items {
+		if template, ok := item.Object.(*flaggerv1.MetricTemplate); ok {
+			results[item.ClusterName] = append(results[item.ClusterName], *template)
 		}
 	}
 
-	return results, clist.GetContinue(), respErrors, nil
+	return results, nextPageToken, respErrors, nil
 }
 
 func (service *defaultFetcher) ListCanaryObjects(ctx context.Context, clusterClient clustersmngr.Client, opts ListCanaryObjectsOptions) ([]unstructured.Unstructured, error) {
-	result := []unstructured.Unstructured{}
-	checkDup := map[types.UID]bool{}
-
 	// Get canary object
 	canary, err := service.GetCanary(ctx, clusterClient, GetCanaryOptions(opts))
 	if err != nil {
 		return nil, fmt.Errorf("unable to find canary object: %w", err)
 	}
 
+	return service.listCanaryObjects(ctx, clusterClient, canary, opts)
+}
+
+func (service *defaultFetcher) listCanaryObjects(ctx context.Context, clusterClient clustersmngr.Client, canary *flaggerv1.Canary, opts ListCanaryObjectsOptions) ([]unstructured.Unstructured, error) {
+	result := []unstructured.Unstructured{}
+	checkDup := map[types.UID]bool{}
+
 	targetDeployment, err := getRef(
 		ctx,
 		clusterClient,
@@ -300,25 +373,38 @@ func (service *defaultFetcher) ListCanaryObjects(ctx context.Context, clusterCli
 		}
 	}
 
-	// List of kinds all canaries generate independently of mesh provider
-	coreObjectsKinds := []schema.GroupVersionKind{
-		{Group: "", Version: "v1", Kind: "Service"},
-		{Group: "apps", Version: "v1", Kind: "Deployment"},
-		{Group: "autoscaling", Version: "v2beta1", Kind: "HorizontalPodAutoscaler"},
-	}
-
-	objectsKinds := append(coreObjectsKinds, meshProviderObjectKinds(canary.Spec.Provider)...)
+	objectsKinds := append(append([]objectKind{}, coreObjectKinds...), meshProviderObjectKinds(canary.Spec.Provider)...)
 
 	var merr *multierror.Error
 
-	for _, gvk := range objectsKinds {
+	for _, kind := range objectsKinds {
+		gvk, installed, err := service.resolveKind(opts.ClusterName, clusterClient, kind)
+		if err != nil {
+			return nil, fmt.Errorf("failed resolving version of %s: %w", kind, err)
+		}
+
+		if !installed {
+			service.logger.V(1).Info("kind is not installed", "kind", kind.String(), "cluster", opts.ClusterName)
+			continue
+		}
+
+		// Objects generated by the controller of the target are owned by it.
+		owner := canary.GetUID()
+		if kind.OwnedByTarget {
+			owner = targetDeployment.GetUID()
+		}
+
+		listOpts := []client.ListOption{client.InNamespace(canary.GetNamespace())}
+		if kind.TargetLabel != "" {
+			listOpts = append(listOpts, client.MatchingLabels{kind.TargetLabel: canary.Spec.TargetRef.Name})
+		}
+
 		listResult := unstructured.UnstructuredList{}
 
 		listResult.SetGroupVersionKind(gvk)
 
-		if err := clusterClient.List(ctx, opts.ClusterName, &listResult); err != nil {
-			// Given Flux supports multiple version of the same CRD we need to avoid
-			// breaking when we query a version that's not present on the cluster.
+		if err := clusterClient.List(ctx, opts.ClusterName, &listResult, listOpts...); err != nil {
+			// The kind may have been removed since it was resolved.
 			if apimeta.IsNoMatchError(err) {
 				service.logger.Error(err, "failed listing mesh provider resource", "cluster", opts.ClusterName)
 				continue
@@ -334,19 +420,11 @@ func (service *defaultFetcher) ListCanaryObjects(ctx context.Context, clusterCli
 			return nil, fmt.Errorf("error listing unstructured object: %w", err)
 		}
 
-	ItemsLoop:
 		for _, obj := range listResult.Items {
-			refs := obj.GetOwnerReferences()
-			if len(refs) == 0 {
+			if kind.TargetLabel == "" && !ownedOnlyBy(obj, owner) {
 				continue
 			}
 
-			for _, ref := range refs {
-				if ref.UID != canary.GetUID() {
-					continue ItemsLoop
-				}
-			}
-
 			uid := obj.GetUID()
 
 			if !checkDup[uid] {
@@ -359,17 +437,20 @@ func (service *defaultFetcher) ListCanaryObjects(ctx context.Context, clusterCli
 	return result, merr.ErrorOrNil()
 }
 
-func getDeployment(ctx context.Context, clusterName string, c clustersmngr.Client, name string, namespace string) (v1.Deployment, error) {
-	deployment := v1.Deployment{}
-
-	key := client.ObjectKey{
-		Name:      name,
-		Namespace: namespace,
+// ownedOnlyBy reports whether the object has owners, all of them the owner.
+func ownedOnlyBy(obj unstructured.Unstructured, owner types.UID) bool {
+	refs := obj.GetOwnerReferences()
+	if len(refs) == 0 {
+		return false
 	}
 
-	err := c.Get(ctx, clusterName, key, &deployment)
+	for _, ref := range refs {
+		if ref.UID != owner {
+			return false
+		}
+	}
 
-	return deployment, err
+	return true
 }
 
 func getRef(ctx context.Context, clusterClient clustersmngr.Client, ref *flaggerv1.LocalObjectReference, ns string, clusterName string) (unstructured.Unstructured, error) {
@@ -388,68 +469,3 @@ func getRef(ctx context.Context, clusterClient clustersmngr.Client, ref *flagger
 
 	return object, err
 }
-
-func meshProviderObjectKinds(provider string) []schema.GroupVersionKind {
-	kinds := []schema.GroupVersionKind{}
-
-	if provider == "" {
-		return kinds
-	}
-
-	switch provider {
-	case flaggerv1.AppMeshProvider:
-		kinds = []schema.GroupVersionKind{
-			{Group: "appmesh.k8s.aws", Version: "v1beta2", Kind: "virtualnode"},
-			{Group: "appmesh.k8s.aws", Version: "v1beta2", Kind: "virtualrouter"},
-			{Group: "appmesh.k8s.aws", Version: "v1beta2", Kind: "virtualservice"},
-		}
-	case flaggerv1.LinkerdProvider:
-		kinds = []schema.GroupVersionKind{
-			{Group: "split.smi-spec.io", Version: "v1alpha1", Kind: "trafficsplit"},
-			{Group: "split.smi-spec.io", Version: "v1alpha2", Kind: "trafficsplit"},
-			{Group: "split.smi-spec.io", Version: "v1alpha3", Kind: "trafficsplit"},
-		}
-	case flaggerv1.IstioProvider:
-		kinds = []schema.GroupVersionKind{
-			{Group: "networking.istio.io", Version: "v1alpha3", Kind: "destinationrule"},
-			{Group: "networking.istio.io", Version: "v1alpha3", Kind: "virtualservice"},
-		}
-	case flaggerv1.ContourProvider:
-		kinds = []schema.GroupVersionKind{
-			{Group: "projectcontour.io", Version: "v1", Kind: "httpproxy"},
-		}
-	case flaggerv1.GlooProvider:
-		kinds = []schema.GroupVersionKind{
-			{Group: "gateway.solo.io", Version: "v1", Kind: "routetable"},
-			{Group: "gloo.solo.io", Version: "v1", Kind: "upstream"},
-		}
-	case flaggerv1.NGINXProvider:
-		kinds = []schema.GroupVersionKind{
-			{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
-		}
-	case flaggerv1.SkipperProvider:
-		kinds = []schema.GroupVersionKind{
-			{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
-		}
-	case flaggerv1.TraefikProvider:
-		kinds = []schema.GroupVersionKind{
-			{Group: "traefik.containo.us", Version: "v1alpha1", Kind: "traefikservice"},
-		}
-	case flaggerv1.OsmProvider:
-		kinds = []schema.GroupVersionKind{
-			{Group: "split.smi-spec.io", Version: "v1alpha3", Kind: "trafficsplit"},
-		}
-	case flaggerv1.KumaProvider:
-		kinds = []schema.GroupVersionKind{
-			{Group: "kuma.io", Version: "v1alpha1", Kind: "trafficroute"},
-		}
-	case flaggerv1.GatewayAPIProvider:
-		kinds = []schema.GroupVersionKind{
-			{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Kind: "httproute"},
-		}
-	case flaggerv1.KubernetesProvider:
-		kinds = []schema.GroupVersionKind{}
-	}
-
-	return kinds
-}
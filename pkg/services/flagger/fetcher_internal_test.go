@@ -0,0 +1,136 @@
+package flagger
+
+import (
+	"context"
+	"testing"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/clustersmngrfakes"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// installedCRDs serves every CRD as installed.
+type installedCRDs struct {
+	crd.Fetcher
+}
+
+func (installedCRDs) IsAvailable(string, string) bool {
+	return true
+}
+
+var knative = schema.GroupVersion{Group: "serving.knative.dev", Version: "v1"}
+
+// newKnativeClient returns a fake client serving the Knative kinds and the
+// core kinds, with the objects.
+func newKnativeClient(objects ...client.Object) clustersmngr.Client {
+	scheme := kube.CreateScheme()
+	mapper := apimeta.NewDefaultRESTMapper([]schema.GroupVersion{knative})
+
+	for _, kind := range []string{"Service", "Configuration", "Revision", "Route"} {
+		scheme.AddKnownTypeWithName(knative.WithKind(kind), &unstructured.Unstructured{})
+		scheme.AddKnownTypeWithName(knative.WithKind(kind+"List"), &unstructured.UnstructuredList{})
+		mapper.Add(knative.WithKind(kind), apimeta.RESTScopeNamespace)
+	}
+
+	for _, kind := range coreObjectKinds {
+		mapper.Add(kind.WithVersion("v1"), apimeta.RESTScopeNamespace)
+	}
+
+	pool := &clustersmngrfakes.FakeClientsPool{}
+	pool.ClientReturns(fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithObjects(objects...).Build(), nil)
+
+	return clustersmngr.NewClient(pool, nil, logr.Discard())
+}
+
+func newKnativeObject(kind, name string, labels map[string]string, owner types.UID) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(knative.WithKind(kind))
+	obj.SetName(name)
+	obj.SetNamespace("test")
+	obj.SetUID(types.UID(name))
+	obj.SetLabels(labels)
+
+	if owner != "" {
+		obj.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: knative.String(), Kind: kind, Name: string(owner), UID: owner}})
+	}
+
+	return obj
+}
+
+func newKnativeCanary(name string) *flaggerv1.Canary {
+	return &flaggerv1.Canary{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+		Spec: flaggerv1.CanarySpec{
+			Provider:  KnativeProvider,
+			TargetRef: flaggerv1.LocalObjectReference{APIVersion: knative.String(), Kind: "Service", Name: name},
+		},
+	}
+}
+
+func TestListCanaryObjects_Knative(t *testing.T) {
+	clusterClient := newKnativeClient(
+		newKnativeObject("Service", "backend", nil, ""),
+		newKnativeObject("Configuration", "backend-config", nil, "backend"),
+		newKnativeObject("Revision", "backend-00001", map[string]string{"serving.knative.dev/service": "backend"}, "backend-config"),
+		newKnativeObject("Revision", "frontend-00001", map[string]string{"serving.knative.dev/service": "frontend"}, "frontend-config"),
+	)
+
+	service := &defaultFetcher{crdService: installedCRDs{}, logger: logr.Discard()}
+	canary := newKnativeCanary("backend")
+
+	objects, err := service.listCanaryObjects(context.Background(), clusterClient, canary, ListCanaryObjectsOptions{ClusterName: "Default"})
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, obj := range objects {
+		names = append(names, obj.GetName())
+	}
+
+	assert.ElementsMatch(t, []string{"backend", "backend-config", "backend-00001"}, names, "revisions should be matched by the label of their service")
+}
+
+func TestFetchCanaryObjects_Knative(t *testing.T) {
+	ctx := context.Background()
+
+	target := newKnativeObject("Service", "backend", nil, "")
+	require.NoError(t, unstructured.SetNestedSlice(target.Object, []interface{}{
+		map[string]interface{}{"name": "app", "image": "backend:2"},
+	}, "spec", "template", "spec", "containers"))
+
+	clusterClient := newKnativeClient(
+		target,
+		// A core Service with the same name must not be taken as the target.
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "test", UID: "core"}},
+	)
+
+	service := &defaultFetcher{crdService: installedCRDs{}, logger: logr.Discard()}
+	canary := newKnativeCanary("backend")
+
+	workload, err := service.FetchTargetRef(ctx, "Default", clusterClient, canary)
+	require.NoError(t, err)
+	assert.Equal(t, types.UID("backend"), workload.UID)
+	assert.Equal(t, map[string]string{"app": "backend:2"}, workload.Images())
+
+	workload, err = service.FetchPromoted(ctx, "Default", clusterClient, canary)
+	assert.NoError(t, err, "knative services have no primary")
+	assert.Empty(t, workload.Name)
+
+	objects, errs := service.FetchCanaryObjects(ctx, clusterClient, map[string][]flaggerv1.Canary{"Default": {*canary}}, FetchCanaryObjectsOptions{})
+	assert.Empty(t, errs)
+	require.Len(t, objects["Default"], 1)
+	assert.Equal(t, types.UID("backend"), objects["Default"][0].Target.UID)
+	assert.Empty(t, objects["Default"][0].Promoted.Name)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
 	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"github.com/weaveworks/progressive-delivery/pkg/services/delivery"
 	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -108,3 +109,64 @@ func TestFetcher_ListCanaryDeployments(t *testing.T) {
 	assert.Empty(t, cerrs)
 	assert.NotEmpty(t, canaries["Default"])
 }
+
+func TestFetcher_ListCanaryDeployments_Filter(t *testing.T) {
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	defer cancelFn()
+
+	k, err := client.New(k8sEnv.Rest, client.Options{
+		Scheme: kube.CreateScheme(),
+	})
+	assert.NoError(t, err)
+
+	ns := pdtesting.NewNamespace(ctx, t, k)
+
+	cl, service, err := newService(ctx, k8sEnv)
+	assert.NoError(t, err)
+
+	for _, info := range []pdtesting.CanaryInfo{
+		{Name: "podinfo-a", Namespace: ns.Name, Labels: map[string]string{"app": "a"}},
+		{Name: "podinfo-b", Namespace: ns.Name, Labels: map[string]string{"app": "b"}},
+		{Name: "backend", Namespace: ns.Name, Labels: map[string]string{"app": "b"}},
+	} {
+		canary := pdtesting.NewCanary(ctx, t, k, info)
+		defer pdtesting.Cleanup(ctx, t, k, &canary)
+	}
+
+	names := func(opts flagger.ListCanaryDeploymentsOptions) []string {
+		opts.Namespace = ns.Name
+
+		canaries, _, cerrs, err := service.ListCanaryDeployments(ctx, cl, opts)
+		assert.NoError(t, err)
+		assert.Empty(t, cerrs)
+
+		result := []string{}
+		for _, canary := range canaries["Default"] {
+			result = append(result, canary.GetName())
+		}
+
+		return result
+	}
+
+	assert.ElementsMatch(t, []string{"podinfo-a", "podinfo-b"}, names(flagger.ListCanaryDeploymentsOptions{
+		Filter: delivery.Filter{NameContains: "PODINFO"},
+	}))
+
+	assert.ElementsMatch(t, []string{"podinfo-b", "backend"}, names(flagger.ListCanaryDeploymentsOptions{
+		Filter: delivery.Filter{LabelSelector: "app=b"},
+	}))
+
+	assert.Equal(t, []string{"podinfo-b", "podinfo-a", "backend"}, names(flagger.ListCanaryDeploymentsOptions{
+		Sort: delivery.SortOptions{By: delivery.SortByName, Descending: true},
+	}))
+
+	assert.Empty(t, names(flagger.ListCanaryDeploymentsOptions{
+		Filter: delivery.Filter{ClusterName: "other"},
+	}))
+
+	_, _, _, err = service.ListCanaryDeployments(ctx, cl, flagger.ListCanaryDeploymentsOptions{
+		Filter: delivery.Filter{LabelSelector: "app in ("},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,29 @@
+package flagger
+
+import (
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/weaveworks/progressive-delivery/pkg/services/delivery"
+)
+
+// AttributesOf returns the values filters and sort orders are applied to. The
+// provider is matched without its version, Canaries without one match
+// DefaultProvider.
+func (service *defaultFetcher) AttributesOf(clusterName string, canary flaggerv1.Canary) delivery.Attributes {
+	return delivery.Attributes{
+		ClusterName:        clusterName,
+		Namespace:          canary.GetNamespace(),
+		Name:               canary.GetName(),
+		Phase:              string(canary.Status.Phase),
+		Provider:           providerAttribute(canary.Spec.Provider),
+		Strategy:           service.DeploymentStrategyFor(canary),
+		LastTransitionTime: canary.Status.LastTransitionTime.Time,
+	}
+}
+
+func providerAttribute(provider string) string {
+	if provider = MeshProvider(provider); provider == "" {
+		return DefaultProvider
+	}
+
+	return provider
+}
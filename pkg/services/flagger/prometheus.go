@@ -0,0 +1,149 @@
+package flagger
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"time"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/fluxcd/flagger/pkg/metrics/providers"
+)
+
+const prometheusQueryTimeout = 5 * time.Second
+
+var querySpaces = regexp.MustCompile(`\s+`)
+
+// prometheusProvider runs queries the way the Flagger Prometheus provider
+// does, with a client that doesn't follow redirects: they could point the
+// request to a host that isn't allowed.
+type prometheusProvider struct {
+	url      url.URL
+	token    string
+	username string
+	password string
+	client   *http.Client
+}
+
+func newPrometheusProvider(provider flaggerv1.MetricTemplateProvider, credentials map[string][]byte) (*prometheusProvider, error) {
+	promURL, err := url.Parse(provider.Address)
+	if provider.Address == "" || err != nil {
+		return nil, fmt.Errorf("%s address %s is not a valid URL", provider.Type, provider.Address)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if provider.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec // Set by the template.
+	}
+
+	prom := &prometheusProvider{
+		url: *promURL,
+		client: &http.Client{
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+
+	if provider.SecretRef != nil {
+		token, hasToken := credentials["token"]
+		username, hasUsername := credentials["username"]
+		password, hasPassword := credentials["password"]
+
+		switch {
+		case hasToken:
+			prom.token = string(token)
+		case !hasUsername:
+			return nil, fmt.Errorf("%s credentials does not contain a username", provider.Type)
+		case !hasPassword:
+			return nil, fmt.Errorf("%s credentials does not contain a password", provider.Type)
+		default:
+			prom.username = string(username)
+			prom.password = string(password)
+		}
+	}
+
+	return prom, nil
+}
+
+func (p *prometheusProvider) RunQuery(query string) (float64, error) {
+	u, err := url.Parse("./api/v1/query?query=" + url.QueryEscape(querySpaces.ReplaceAllString(query, " ")))
+	if err != nil {
+		return 0, fmt.Errorf("failed parsing query url: %w", err)
+	}
+
+	u.Path = path.Join(p.url.Path, u.Path)
+
+	ctx, cancel := context.WithTimeout(context.Background(), prometheusQueryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url.ResolveReference(u).String(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed creating request: %w", err)
+	}
+
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	} else if p.username != "" && p.password != "" {
+		req.SetBasicAuth(p.username, p.password)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("error reading body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return 0, fmt.Errorf("error response %d: %s", resp.StatusCode, body)
+	}
+
+	result := struct {
+		Data struct {
+			Result []struct {
+				Value []interface{} `json:"value"`
+			} `json:"result"`
+		} `json:"data"`
+	}{}
+
+	if err := json.Unmarshal(body, &result); err != nil {
+		return 0, fmt.Errorf("error unmarshaling result: %w, '%s'", err, body)
+	}
+
+	var value *float64
+
+	for _, r := range result.Data.Result {
+		if len(r.Value) < 2 {
+			continue
+		}
+
+		if s, ok := r.Value[1].(string); ok {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return 0, err
+			}
+
+			value = &f
+		}
+	}
+
+	if value == nil || math.IsNaN(*value) {
+		return 0, providers.ErrNoValuesFound
+	}
+
+	return *value, nil
+}
@@ -0,0 +1,53 @@
+package flagger
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPrometheusProvider(t *testing.T) {
+	redirected := false
+
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected = true
+	}))
+	defer internal.Close()
+
+	prometheus := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("query") == "redirect" {
+			http.Redirect(w, r, internal.URL, http.StatusFound)
+			return
+		}
+
+		username, password, _ := r.BasicAuth()
+		assert.Equal(t, "admin:secret", username+":"+password)
+		assert.Equal(t, "/prometheus/api/v1/query", r.URL.Path)
+		// Whitespace is collapsed, the same way Flagger does.
+		assert.Equal(t, "sum( requests )", r.URL.Query().Get("query"))
+
+		fmt.Fprint(w, `{"data":{"result":[{"metric":{},"value":[1680000000,"0.95"]}]}}`)
+	}))
+	defer prometheus.Close()
+
+	provider, err := newMetricProvider("1m", flaggerv1.MetricTemplateProvider{
+		Type:      "prometheus",
+		Address:   prometheus.URL + "/prometheus",
+		SecretRef: &corev1.LocalObjectReference{Name: "prometheus"},
+	}, map[string][]byte{"username": []byte("admin"), "password": []byte("secret")})
+	require.NoError(t, err)
+
+	value, err := provider.RunQuery("sum(\n  requests\n)")
+	require.NoError(t, err)
+	assert.Equal(t, 0.95, value)
+
+	_, err = provider.RunQuery("redirect")
+	assert.ErrorContains(t, err, "error response 302")
+	assert.False(t, redirected, "redirects shouldn't be followed")
+}
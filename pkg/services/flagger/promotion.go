@@ -0,0 +1,74 @@
+package flagger
+
+import (
+	"context"
+	"fmt"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PromotionResetter enables the analysis of Canaries promoted by PromoteCanary
+// again once their rollout ends, so only the rollout that was promoted skips
+// its analysis.
+type PromotionResetter struct {
+	clustersManager clustersmngr.ClustersManager
+	logger          logr.Logger
+}
+
+func NewPromotionResetter(clustersManager clustersmngr.ClustersManager, logger logr.Logger) *PromotionResetter {
+	return &PromotionResetter{
+		clustersManager: clustersManager,
+		logger:          logger,
+	}
+}
+
+// Start resets the promoted Canaries of the events until the channel is
+// closed. Canaries whose rollout ended while the server was down are reset
+// once they're listed.
+func (r *PromotionResetter) Start(ctx context.Context, events <-chan CanaryEvent) {
+	for event := range events {
+		if event.Type == watch.Deleted || !promotionEnded(event.Canary) {
+			continue
+		}
+
+		if err := r.reset(ctx, event.ClusterName, event.Canary); err != nil {
+			r.logger.Error(err, "failed resetting promoted canary", "cluster", event.ClusterName, "canary", event.Canary.GetName(), "namespace", event.Canary.GetNamespace())
+		}
+	}
+}
+
+// promotionEnded reports whether the Canary was promoted by PromoteCanary and
+// its rollout has ended.
+func promotionEnded(canary flaggerv1.Canary) bool {
+	if canary.GetAnnotations()[PromotedAnnotation] == "" {
+		return false
+	}
+
+	switch canary.Status.Phase {
+	case flaggerv1.CanaryPhaseSucceeded, flaggerv1.CanaryPhaseFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// reset enables the analysis of the Canary and removes its annotation, with
+// the permissions of the server.
+func (r *PromotionResetter) reset(ctx context.Context, clusterName string, canary flaggerv1.Canary) error {
+	clusterClient, err := r.clustersManager.GetServerClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	patch := client.RawPatch(types.MergePatchType, []byte(fmt.Sprintf(
+		`{"metadata":{"annotations":{%q:null}},"spec":{"skipAnalysis":false}}`,
+		PromotedAnnotation,
+	)))
+
+	return clusterClient.Patch(ctx, clusterName, &canary, patch)
+}
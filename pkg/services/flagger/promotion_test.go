@@ -0,0 +1,116 @@
+package flagger
+
+import (
+	"context"
+	"testing"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/clustersmngrfakes"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPromotionResetter(t *testing.T) {
+	ctx := context.Background()
+
+	newCanary := func(name string, phase flaggerv1.CanaryPhase, promoted bool) *flaggerv1.Canary {
+		canary := &flaggerv1.Canary{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+			Spec:       flaggerv1.CanarySpec{SkipAnalysis: true},
+		}
+		canary.Status.Phase = phase
+
+		if promoted {
+			canary.SetAnnotations(map[string]string{PromotedAnnotation: "true"})
+		}
+
+		return canary
+	}
+
+	succeeded := newCanary("succeeded", flaggerv1.CanaryPhaseSucceeded, true)
+	progressing := newCanary("progressing", flaggerv1.CanaryPhaseProgressing, true)
+	skipped := newCanary("skipped", flaggerv1.CanaryPhaseSucceeded, false)
+
+	c := fake.NewClientBuilder().WithScheme(kube.CreateScheme()).WithObjects(succeeded, progressing, skipped).Build()
+
+	pool := &clustersmngrfakes.FakeClientsPool{}
+	pool.ClientReturns(c, nil)
+
+	clustersManager := &clustersmngrfakes.FakeClustersManager{}
+	clustersManager.GetServerClientReturns(clustersmngr.NewClient(pool, nil, logr.Discard()), nil)
+
+	events := make(chan CanaryEvent, 3)
+	for _, canary := range []*flaggerv1.Canary{succeeded, progressing, skipped} {
+		events <- CanaryEvent{Type: watch.Added, ClusterName: "Default", Canary: *canary}
+	}
+	close(events)
+
+	NewPromotionResetter(clustersManager, logr.Discard()).Start(ctx, events)
+
+	stored := map[string]*flaggerv1.Canary{}
+	for _, name := range []string{"succeeded", "progressing", "skipped"} {
+		stored[name] = &flaggerv1.Canary{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: name, Namespace: "test"}, stored[name]))
+	}
+
+	assert.False(t, stored["succeeded"].Spec.SkipAnalysis, "the analysis should be enabled once the promoted rollout ends")
+	assert.NotContains(t, stored["succeeded"].GetAnnotations(), PromotedAnnotation)
+	assert.True(t, stored["progressing"].Spec.SkipAnalysis, "rollouts in progress should stay promoted")
+	assert.True(t, stored["skipped"].Spec.SkipAnalysis, "canaries not promoted by the server should be left as is")
+}
+
+func TestSupportsSuspend(t *testing.T) {
+	definition := func(specProperties ...string) apiextensionsv1.CustomResourceDefinition {
+		spec := apiextensionsv1.JSONSchemaProps{Properties: map[string]apiextensionsv1.JSONSchemaProps{}}
+		for _, property := range specProperties {
+			spec.Properties[property] = apiextensionsv1.JSONSchemaProps{}
+		}
+
+		return apiextensionsv1.CustomResourceDefinition{
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+					Name: "v1beta1",
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{"spec": spec},
+						},
+					},
+				}},
+			},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		definition apiextensionsv1.CustomResourceDefinition
+		found      bool
+		expected   bool
+	}{
+		"suspend":    {definition: definition("skipAnalysis", "suspend"), found: true, expected: true},
+		"no suspend": {definition: definition("skipAnalysis"), found: true},
+		"no flagger": {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			service := &defaultFetcher{crdService: canaryCRD{definition: tc.definition, found: tc.found}}
+			assert.Equal(t, tc.expected, service.supportsSuspend("Default"))
+		})
+	}
+}
+
+// canaryCRD serves a single CRD for any name.
+type canaryCRD struct {
+	installedCRDs
+	definition apiextensionsv1.CustomResourceDefinition
+	found      bool
+}
+
+func (c canaryCRD) Get(string, string) (apiextensionsv1.CustomResourceDefinition, bool) {
+	return c.definition, c.found
+}
@@ -0,0 +1,148 @@
+package flagger
+
+import (
+	"sort"
+	"strings"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// KnativeProvider routes the traffic of Knative Services, it's missing from
+// the version of the Flagger API the server is built with.
+const KnativeProvider = "knative"
+
+// objectKind is a kind of object related to Canaries. Its version is resolved
+// per cluster, clusters serve different versions of the same kind.
+type objectKind struct {
+	schema.GroupKind
+	// CRD is the name of the CustomResourceDefinition of the kind, empty for
+	// built-in kinds.
+	CRD string
+	// OwnedByTarget is set on kinds generated by the controller of the target
+	// instead of by Flagger.
+	OwnedByTarget bool
+	// TargetLabel is the label set to the name of the target on kinds
+	// generated by the controller of the target, but owned by other objects
+	// it generated. Their owner is not checked.
+	TargetLabel string
+}
+
+// coreObjectKinds are the kinds all canaries generate independently of mesh
+// provider.
+var coreObjectKinds = []objectKind{
+	{GroupKind: schema.GroupKind{Group: "", Kind: "Service"}},
+	{GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"}},
+	{GroupKind: schema.GroupKind{Group: "autoscaling", Kind: "HorizontalPodAutoscaler"}},
+}
+
+var (
+	ingressKind      = objectKind{GroupKind: schema.GroupKind{Group: "networking.k8s.io", Kind: "Ingress"}}
+	trafficSplitKind = objectKind{GroupKind: schema.GroupKind{Group: "split.smi-spec.io", Kind: "TrafficSplit"}, CRD: "trafficsplits.split.smi-spec.io"}
+)
+
+var providerObjectKinds = map[string][]objectKind{
+	flaggerv1.ApisixProvider: {
+		{GroupKind: schema.GroupKind{Group: "apisix.apache.org", Kind: "ApisixRoute"}, CRD: "apisixroutes.apisix.apache.org"},
+	},
+	flaggerv1.AppMeshProvider: {
+		{GroupKind: schema.GroupKind{Group: "appmesh.k8s.aws", Kind: "VirtualNode"}, CRD: "virtualnodes.appmesh.k8s.aws"},
+		{GroupKind: schema.GroupKind{Group: "appmesh.k8s.aws", Kind: "VirtualRouter"}, CRD: "virtualrouters.appmesh.k8s.aws"},
+		{GroupKind: schema.GroupKind{Group: "appmesh.k8s.aws", Kind: "VirtualService"}, CRD: "virtualservices.appmesh.k8s.aws"},
+	},
+	flaggerv1.ContourProvider: {
+		{GroupKind: schema.GroupKind{Group: "projectcontour.io", Kind: "HTTPProxy"}, CRD: "httpproxies.projectcontour.io"},
+	},
+	flaggerv1.GatewayAPIProvider: {
+		{GroupKind: schema.GroupKind{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute"}, CRD: crd.GatewayAPICRDName},
+	},
+	flaggerv1.GlooProvider: {
+		{GroupKind: schema.GroupKind{Group: "gateway.solo.io", Kind: "RouteTable"}, CRD: "routetables.gateway.solo.io"},
+		{GroupKind: schema.GroupKind{Group: "gloo.solo.io", Kind: "Upstream"}, CRD: "upstreams.gloo.solo.io"},
+	},
+	flaggerv1.IstioProvider: {
+		{GroupKind: schema.GroupKind{Group: "networking.istio.io", Kind: "DestinationRule"}, CRD: "destinationrules.networking.istio.io"},
+		{GroupKind: schema.GroupKind{Group: "networking.istio.io", Kind: "VirtualService"}, CRD: "virtualservices.networking.istio.io"},
+	},
+	KnativeProvider: {
+		{GroupKind: schema.GroupKind{Group: "serving.knative.dev", Kind: "Configuration"}, CRD: "configurations.serving.knative.dev", OwnedByTarget: true},
+		// Revisions are owned by the Configuration.
+		{GroupKind: schema.GroupKind{Group: "serving.knative.dev", Kind: "Revision"}, CRD: "revisions.serving.knative.dev", TargetLabel: "serving.knative.dev/service"},
+		{GroupKind: schema.GroupKind{Group: "serving.knative.dev", Kind: "Route"}, CRD: "routes.serving.knative.dev", OwnedByTarget: true},
+	},
+	flaggerv1.KubernetesProvider: {},
+	flaggerv1.KumaProvider: {
+		{GroupKind: schema.GroupKind{Group: "kuma.io", Kind: "TrafficRoute"}, CRD: "trafficroutes.kuma.io"},
+	},
+	flaggerv1.LinkerdProvider: {trafficSplitKind},
+	flaggerv1.NGINXProvider:   {ingressKind},
+	flaggerv1.OsmProvider:     {trafficSplitKind},
+	flaggerv1.SkipperProvider: {ingressKind},
+	flaggerv1.SMIProvider:     {trafficSplitKind},
+	flaggerv1.TraefikProvider: {
+		{GroupKind: schema.GroupKind{Group: "traefik.containo.us", Kind: "TraefikService"}, CRD: "traefikservices.traefik.containo.us"},
+	},
+}
+
+// MeshProvider returns the name of the provider without the version some
+// are suffixed with, appmesh:v1beta2 or gatewayapi:v1beta1 for example.
+func MeshProvider(provider string) string {
+	name, _, _ := strings.Cut(provider, ":")
+
+	return name
+}
+
+// meshProviderObjectKinds returns the kinds generated for the provider, none
+// for the kubernetes provider and unknown ones.
+func meshProviderObjectKinds(provider string) []objectKind {
+	return providerObjectKinds[MeshProvider(provider)]
+}
+
+// AvailableProviders returns the providers whose kinds are all installed on
+// the cluster, sorted by name. Providers of built-in kinds only, kubernetes
+// and nginx for example, are always available.
+func AvailableProviders(crdService crd.Fetcher, clusterName string) []string {
+	providers := []string{}
+
+ProvidersLoop:
+	for provider, kinds := range providerObjectKinds {
+		for _, kind := range kinds {
+			if kind.CRD != "" && !crdService.IsAvailable(clusterName, kind.CRD) {
+				continue ProvidersLoop
+			}
+		}
+
+		providers = append(providers, provider)
+	}
+
+	sort.Strings(providers)
+
+	return providers
+}
+
+// resolveKind returns the version of the kind preferred by the cluster, it
+// returns false if the kind is not installed.
+func (service *defaultFetcher) resolveKind(clusterName string, clusterClient clustersmngr.Client, kind objectKind) (schema.GroupVersionKind, bool, error) {
+	if kind.CRD != "" && !service.crdService.IsAvailable(clusterName, kind.CRD) {
+		return schema.GroupVersionKind{}, false, nil
+	}
+
+	c, err := clusterClient.ClientsPool().Client(clusterName)
+	if err != nil {
+		return schema.GroupVersionKind{}, false, err
+	}
+
+	mapping, err := c.RESTMapper().RESTMapping(kind.GroupKind)
+	if err != nil {
+		if apimeta.IsNoMatchError(err) {
+			return schema.GroupVersionKind{}, false, nil
+		}
+
+		return schema.GroupVersionKind{}, false, err
+	}
+
+	return mapping.GroupVersionKind, true, nil
+}
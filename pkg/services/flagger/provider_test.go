@@ -0,0 +1,38 @@
+package flagger_test
+
+import (
+	"testing"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/pkg/services/delivery"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+)
+
+func TestMeshProvider(t *testing.T) {
+	for provider, expected := range map[string]string{
+		"":                   "",
+		"istio":              "istio",
+		"appmesh:v1beta2":    "appmesh",
+		"gatewayapi:v1beta1": "gatewayapi",
+	} {
+		assert.Equal(t, expected, flagger.MeshProvider(provider), provider)
+	}
+}
+
+func TestAttributesOf_Provider(t *testing.T) {
+	service := flagger.NewFetcher(nil, logr.Discard())
+
+	for provider, expected := range map[string]string{
+		"":                "default",
+		"istio":           "istio",
+		"appmesh:v1beta2": "appmesh",
+	} {
+		canary := flaggerv1.Canary{Spec: flaggerv1.CanarySpec{Provider: provider, Analysis: &flaggerv1.CanaryAnalysis{}}}
+		attrs := service.AttributesOf("Default", canary)
+
+		assert.Equal(t, expected, attrs.Provider, provider)
+		assert.True(t, delivery.Filter{Providers: []string{expected}}.Matches(attrs), "the provider filter should match %q", provider)
+	}
+}
@@ -0,0 +1,45 @@
+package flagger
+
+import (
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/weaveworks/progressive-delivery/pkg/services/delivery"
+)
+
+// DefaultProvider is counted for Canaries without a provider, they use the
+// default of their controller.
+const DefaultProvider = delivery.DefaultProvider
+
+// CanaryCounts counts Canaries by phase, deployment strategy and provider,
+// without the version of the provider.
+type CanaryCounts struct {
+	Total      int32
+	Phases     map[string]int32
+	Strategies map[string]int32
+	Providers  map[string]int32
+}
+
+func NewCanaryCounts() *CanaryCounts {
+	return &CanaryCounts{
+		Phases:     map[string]int32{},
+		Strategies: map[string]int32{},
+		Providers:  map[string]int32{},
+	}
+}
+
+func (c *CanaryCounts) Add(attrs delivery.Attributes) {
+	provider := MeshProvider(attrs.Provider)
+	if provider == "" {
+		provider = DefaultProvider
+	}
+
+	// Flagger hasn't set the status of new Canaries yet.
+	phase := attrs.Phase
+	if phase == "" {
+		phase = string(flaggerv1.CanaryPhaseInitializing)
+	}
+
+	c.Total++
+	c.Phases[phase]++
+	c.Strategies[string(attrs.Strategy)]++
+	c.Providers[provider]++
+}
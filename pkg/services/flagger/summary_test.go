@@ -0,0 +1,24 @@
+package flagger_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/pkg/services/delivery"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+)
+
+func TestCanaryCounts_Add(t *testing.T) {
+	counts := flagger.NewCanaryCounts()
+
+	counts.Add(delivery.Attributes{Phase: "Succeeded", Provider: "istio", Strategy: delivery.CanaryDeploymentStrategy})
+	counts.Add(delivery.Attributes{Phase: "Failed", Provider: "istio", Strategy: delivery.CanaryDeploymentStrategy})
+	counts.Add(delivery.Attributes{Strategy: delivery.BlueGreenDeploymentStrategy})
+	counts.Add(delivery.Attributes{Phase: "Succeeded", Provider: "appmesh:v1beta2", Strategy: delivery.CanaryDeploymentStrategy})
+	counts.Add(delivery.Attributes{Phase: "Succeeded", Provider: "appmesh", Strategy: delivery.CanaryDeploymentStrategy})
+
+	assert.Equal(t, int32(5), counts.Total)
+	assert.Equal(t, map[string]int32{"Succeeded": 3, "Failed": 1, "Initializing": 1}, counts.Phases)
+	assert.Equal(t, map[string]int32{"canary": 4, "blue-green": 1}, counts.Strategies)
+	assert.Equal(t, map[string]int32{"istio": 2, "appmesh": 2, flagger.DefaultProvider: 1}, counts.Providers, "providers should be counted without their version")
+}
@@ -0,0 +1,177 @@
+package flagger
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type TimelineEventKind string
+
+const (
+	NewRevisionTimelineEvent     TimelineEventKind = "new-revision"
+	AnalysisStartedTimelineEvent TimelineEventKind = "analysis-started"
+	WeightStepTimelineEvent      TimelineEventKind = "weight-step"
+	IterationStepTimelineEvent   TimelineEventKind = "iteration-step"
+	MetricCheckTimelineEvent     TimelineEventKind = "metric-check"
+	WebhookTimelineEvent         TimelineEventKind = "webhook"
+	PromotionTimelineEvent       TimelineEventKind = "promotion"
+	RollbackTimelineEvent        TimelineEventKind = "rollback"
+	OtherTimelineEvent           TimelineEventKind = "other"
+)
+
+// Flagger records every event with the same reason, so they are classified
+// by the prefix of their message.
+var timelineEventPrefixes = []struct {
+	prefix string
+	kind   TimelineEventKind
+}{
+	{"New revision detected", NewRevisionTimelineEvent},
+	{"Starting canary analysis", AnalysisStartedTimelineEvent},
+	{"Halt advancement no values found", MetricCheckTimelineEvent},
+	{"Metric query failed", MetricCheckTimelineEvent},
+	{"Metric template", MetricCheckTimelineEvent},
+	{"Prometheus query failed", MetricCheckTimelineEvent},
+	{"Pre-rollout", WebhookTimelineEvent},
+	{"Post-rollout", WebhookTimelineEvent},
+	{"Confirm-", WebhookTimelineEvent},
+	{"Rollback hook", WebhookTimelineEvent},
+	{"Rollback check", WebhookTimelineEvent},
+	{"Copying", PromotionTimelineEvent},
+	{"Routing all traffic to primary", PromotionTimelineEvent},
+	{"Promotion completed", PromotionTimelineEvent},
+	{"Rolling back", RollbackTimelineEvent},
+	{"Canary failed", RollbackTimelineEvent},
+}
+
+var (
+	canaryWeightRegexp    = regexp.MustCompile(`canary weight (\d+)$`)
+	canaryIterationRegexp = regexp.MustCompile(`canary iteration \d+/\d+$`)
+	primaryWeightRegexp   = regexp.MustCompile(`primary weight \d+$`)
+	haltWaitingRegexp     = regexp.MustCompile(`^Halt .* advancement waiting for`)
+	haltMetricRegexp      = regexp.MustCompile(`^Halt .* advancement .* [<>] `)
+)
+
+// TimelineEvent is a Kubernetes event related to a Canary rollout.
+// CanaryWeight is only set on weight steps.
+type TimelineEvent struct {
+	Kind         TimelineEventKind
+	CanaryWeight int32
+	Event        corev1.Event
+}
+
+type ListCanaryEventsOptions struct {
+	Name        string
+	Namespace   string
+	ClusterName string
+}
+
+// ListCanaryEvents returns the events of a Canary, its target and its primary
+// ordered by the time they were last seen.
+func (service *defaultFetcher) ListCanaryEvents(ctx context.Context, clusterClient clustersmngr.Client, opts ListCanaryEventsOptions) ([]TimelineEvent, error) {
+	canary, err := service.GetCanary(ctx, clusterClient, GetCanaryOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	involvedObjects := []struct{ kind, name string }{
+		{"Canary", canary.GetName()},
+		{canary.Spec.TargetRef.Kind, canary.Spec.TargetRef.Name},
+	}
+
+	if HasPrimary(*canary) {
+		involvedObjects = append(involvedObjects, struct{ kind, name string }{canary.Spec.TargetRef.Kind, PrimaryName(*canary)})
+	}
+
+	result := []TimelineEvent{}
+
+	for _, obj := range involvedObjects {
+		list := &corev1.EventList{}
+
+		err := clusterClient.List(ctx, opts.ClusterName, list,
+			client.InNamespace(canary.GetNamespace()),
+			client.MatchingFields{
+				"involvedObject.kind": obj.kind,
+				"involvedObject.name": obj.name,
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed listing events for %s %s: %w", obj.kind, obj.name, err)
+		}
+
+		for _, event := range list.Items {
+			result = append(result, TimelineEventFor(event))
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return EventTime(result[i].Event).Before(EventTime(result[j].Event))
+	})
+
+	return result, nil
+}
+
+// TimelineEventFor classifies an event recorded by Flagger.
+func TimelineEventFor(event corev1.Event) TimelineEvent {
+	result := TimelineEvent{Kind: OtherTimelineEvent, Event: event}
+	message := event.Message
+
+	if match := canaryWeightRegexp.FindStringSubmatch(message); match != nil {
+		weight, _ := strconv.ParseInt(match[1], 10, 32)
+
+		result.Kind = WeightStepTimelineEvent
+		result.CanaryWeight = int32(weight)
+
+		return result
+	}
+
+	switch {
+	case canaryIterationRegexp.MatchString(message):
+		result.Kind = IterationStepTimelineEvent
+
+		return result
+	case primaryWeightRegexp.MatchString(message):
+		result.Kind = PromotionTimelineEvent
+
+		return result
+	case haltWaitingRegexp.MatchString(message):
+		result.Kind = WebhookTimelineEvent
+
+		return result
+	case haltMetricRegexp.MatchString(message):
+		result.Kind = MetricCheckTimelineEvent
+
+		return result
+	}
+
+	for _, item := range timelineEventPrefixes {
+		if strings.HasPrefix(message, item.prefix) {
+			result.Kind = item.kind
+
+			break
+		}
+	}
+
+	return result
+}
+
+// EventTime returns the last time an event was seen, falling back to the
+// fields older and newer event APIs set instead.
+func EventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	}
+
+	return event.FirstTimestamp.Time
+}
@@ -0,0 +1,106 @@
+package flagger_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestTimelineEventFor(t *testing.T) {
+	tests := []struct {
+		message string
+		kind    flagger.TimelineEventKind
+		weight  int32
+	}{
+		{"New revision detected! Scaling up podinfo.test", flagger.NewRevisionTimelineEvent, 0},
+		{"Starting canary analysis for podinfo.test", flagger.AnalysisStartedTimelineEvent, 0},
+		{"Advance podinfo.test canary weight 20", flagger.WeightStepTimelineEvent, 20},
+		{"Advance podinfo.test canary iteration 2/10", flagger.IterationStepTimelineEvent, 0},
+		{"Advance podinfo.test primary weight 60", flagger.PromotionTimelineEvent, 0},
+		{"Halt podinfo.test advancement request-success-rate 87.00 < 99", flagger.MetricCheckTimelineEvent, 0},
+		{"Halt advancement no values found for custom metric: error-rate", flagger.MetricCheckTimelineEvent, 0},
+		{"Halt podinfo.test advancement waiting for approval gate", flagger.WebhookTimelineEvent, 0},
+		{"Pre-rollout check acceptance-test passed", flagger.WebhookTimelineEvent, 0},
+		{"Copying podinfo.test template spec to podinfo-primary.test", flagger.PromotionTimelineEvent, 0},
+		{"Promotion completed! Scaling down podinfo.test", flagger.PromotionTimelineEvent, 0},
+		{"Rolling back podinfo.test failed checks threshold reached 5", flagger.RollbackTimelineEvent, 0},
+		{"Canary failed! Scaling down podinfo.test", flagger.RollbackTimelineEvent, 0},
+		{"something else", flagger.OtherTimelineEvent, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			result := flagger.TimelineEventFor(corev1.Event{Message: tt.message})
+
+			assert.Equal(t, tt.kind, result.Kind)
+			assert.Equal(t, tt.weight, result.CanaryWeight)
+		})
+	}
+}
+
+func TestFetcher_ListCanaryEvents(t *testing.T) {
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	defer cancelFn()
+
+	k, err := client.New(k8sEnv.Rest, client.Options{
+		Scheme: kube.CreateScheme(),
+	})
+	require.NoError(t, err)
+
+	ns := pdtesting.NewNamespace(ctx, t, k)
+
+	cl, service, err := newService(ctx, k8sEnv)
+	require.NoError(t, err)
+
+	canary := pdtesting.NewCanary(ctx, t, k, pdtesting.CanaryInfo{
+		Name:      "timeline",
+		Namespace: ns.Name,
+	})
+	defer pdtesting.Cleanup(ctx, t, k, &canary)
+
+	now := time.Now()
+
+	newEvent := func(name, kind, objName, message string, at time.Time) {
+		event := &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns.Name},
+			InvolvedObject: corev1.ObjectReference{
+				Kind:      kind,
+				Name:      objName,
+				Namespace: ns.Name,
+			},
+			Reason:        "Synced",
+			Message:       message,
+			Type:          corev1.EventTypeNormal,
+			LastTimestamp: metav1.NewTime(at),
+		}
+		require.NoError(t, k.Create(ctx, event))
+	}
+
+	newEvent("second", "Canary", canary.Name, "Advance timeline canary weight 10", now)
+	newEvent("first", "Canary", canary.Name, "Starting canary analysis for timeline", now.Add(-time.Minute))
+	newEvent("target", "Deployment", canary.Name, "Scaled up replica set", now.Add(-2*time.Minute))
+	newEvent("unrelated", "Deployment", "other", "Scaled up replica set", now)
+
+	events, err := service.ListCanaryEvents(ctx, cl, flagger.ListCanaryEventsOptions{
+		Name:        canary.Name,
+		Namespace:   canary.Namespace,
+		ClusterName: "Default",
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, "target", events[0].Event.Name)
+	assert.Equal(t, flagger.AnalysisStartedTimelineEvent, events[1].Kind)
+	assert.Equal(t, flagger.WeightStepTimelineEvent, events[2].Kind)
+	assert.Equal(t, int32(10), events[2].CanaryWeight)
+}
@@ -0,0 +1,164 @@
+package flagger
+
+import (
+	"context"
+	"fmt"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/hashicorp/go-multierror"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ObjectNode is an object related to a Canary, with the objects it owns.
+type ObjectNode struct {
+	Object   unstructured.Unstructured
+	Children []*ObjectNode
+}
+
+// ownedObjectKinds are the kinds listed under the workloads of a Canary,
+// Deployments own ReplicaSets owning Pods, DaemonSets own Pods.
+var ownedObjectKinds = []schema.GroupVersionKind{
+	{Group: "apps", Version: "v1", Kind: "ReplicaSet"},
+	{Group: "", Version: "v1", Kind: "Pod"},
+}
+
+// workloadKinds are the kinds of the objects of a Canary whose ReplicaSets and
+// Pods are listed, with the selector of their spec.
+var workloadKinds = map[string]bool{
+	"Deployment": true,
+	"DaemonSet":  true,
+}
+
+// ListCanaryObjectTree returns the Canary with the objects ListCanaryObjects
+// returns as its children, and the ReplicaSets and Pods of the workloads
+// under them. ReplicaSets scaled to zero, the previous revisions of a
+// Deployment, are left out. Failed lists of ReplicaSets and Pods are returned
+// in a multierror with the tree.
+func (service *defaultFetcher) ListCanaryObjectTree(ctx context.Context, clusterClient clustersmngr.Client, opts ListCanaryObjectsOptions) (*ObjectNode, error) {
+	canary, err := service.GetCanary(ctx, clusterClient, GetCanaryOptions(opts))
+	if err != nil {
+		return nil, fmt.Errorf("unable to find canary object: %w", err)
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(canary)
+	if err != nil {
+		return nil, fmt.Errorf("failed converting canary: %w", err)
+	}
+
+	root := &ObjectNode{Object: unstructured.Unstructured{Object: content}}
+	root.Object.SetGroupVersionKind(flaggerv1.SchemeGroupVersion.WithKind("Canary"))
+
+	var merr *multierror.Error
+
+	objects, err := service.listCanaryObjects(ctx, clusterClient, canary, opts)
+	if err != nil {
+		listErr, ok := err.(*multierror.Error)
+		if !ok {
+			return nil, err
+		}
+
+		merr = multierror.Append(merr, listErr.Errors...)
+	}
+
+	owned, err := service.listOwnedObjects(ctx, clusterClient, opts.ClusterName, canary.GetNamespace(), objects)
+	if err != nil {
+		service.logger.Error(err, "failed listing owned objects", "cluster", opts.ClusterName)
+
+		merr = multierror.Append(merr, err)
+	}
+
+	for _, obj := range objects {
+		root.Children = append(root.Children, newObjectNode(obj, owned))
+	}
+
+	return root, merr.ErrorOrNil()
+}
+
+func newObjectNode(obj unstructured.Unstructured, owned map[types.UID][]unstructured.Unstructured) *ObjectNode {
+	node := &ObjectNode{Object: obj}
+
+	for _, child := range owned[obj.GetUID()] {
+		node.Children = append(node.Children, newObjectNode(child, owned))
+	}
+
+	return node
+}
+
+// listOwnedObjects lists the ReplicaSets and Pods selected by the workloads,
+// by the UID of their controller. The lists that failed are left out and
+// returned in a multierror.
+func (service *defaultFetcher) listOwnedObjects(ctx context.Context, clusterClient clustersmngr.Client, clusterName, namespace string, workloads []unstructured.Unstructured) (map[types.UID][]unstructured.Unstructured, error) {
+	owned := map[types.UID][]unstructured.Unstructured{}
+	listed := map[types.UID]bool{}
+
+	var merr *multierror.Error
+
+	for _, workload := range workloads {
+		if !workloadKinds[workload.GetKind()] {
+			continue
+		}
+
+		selector, err := workloadSelector(workload)
+		if err != nil {
+			merr = multierror.Append(merr, fmt.Errorf("invalid selector of %s %s: %w", workload.GetKind(), workload.GetName(), err))
+			continue
+		}
+
+		for _, gvk := range ownedObjectKinds {
+			list := unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(gvk)
+
+			if err := clusterClient.List(ctx, clusterName, &list, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+				merr = multierror.Append(merr, fmt.Errorf("failed listing %s of %s %s: %w", gvk.Kind, workload.GetKind(), workload.GetName(), err))
+				continue
+			}
+
+			for _, obj := range list.Items {
+				// Workloads may select the same objects.
+				if listed[obj.GetUID()] {
+					continue
+				}
+
+				listed[obj.GetUID()] = true
+
+				if replicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas"); found && replicas == 0 {
+					continue
+				}
+
+				for _, ref := range obj.GetOwnerReferences() {
+					if ref.Controller != nil && *ref.Controller {
+						owned[ref.UID] = append(owned[ref.UID], obj)
+					}
+				}
+			}
+		}
+	}
+
+	return owned, merr.ErrorOrNil()
+}
+
+// workloadSelector returns the label selector of the spec of the workload.
+func workloadSelector(workload unstructured.Unstructured) (labels.Selector, error) {
+	content, found, err := unstructured.NestedMap(workload.Object, "spec", "selector")
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, fmt.Errorf("spec.selector not found")
+	}
+
+	selector := metav1.LabelSelector{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, &selector); err != nil {
+		return nil, err
+	}
+
+	return metav1.LabelSelectorAsSelector(&selector)
+}
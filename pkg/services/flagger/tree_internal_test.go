@@ -0,0 +1,90 @@
+package flagger
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/clustersmngrfakes"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// podListFailingClient fails listing Pods.
+type podListFailingClient struct {
+	client.Client
+}
+
+func (c podListFailingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if list.GetObjectKind().GroupVersionKind().Kind == "Pod" {
+		return errors.New("list failed")
+	}
+
+	return c.Client.List(ctx, list, opts...)
+}
+
+func TestListOwnedObjects(t *testing.T) {
+	ctx := context.Background()
+
+	newPod := func(name, app string, owner types.UID) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "test",
+				UID:             types.UID(name),
+				Labels:          map[string]string{"app": app},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: app, UID: owner, Controller: pointer.Bool(true)}},
+			},
+		}
+	}
+
+	workload := func(app string, uid types.UID) unstructured.Unstructured {
+		daemonSet := &appsv1.DaemonSet{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DaemonSet"},
+			ObjectMeta: metav1.ObjectMeta{Name: app, Namespace: "test", UID: uid},
+			Spec:       appsv1.DaemonSetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}}},
+		}
+
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(daemonSet)
+		require.NoError(t, err)
+
+		return unstructured.Unstructured{Object: content}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(kube.CreateScheme()).WithObjects(
+		newPod("backend-x2k4p", "backend", "backend"),
+		// Owned by the workload, but not selected by it.
+		newPod("frontend-z7q2m", "frontend", "backend"),
+	).Build()
+
+	service := &defaultFetcher{logger: logr.Discard()}
+	workloads := []unstructured.Unstructured{workload("backend", "backend")}
+
+	newClient := func(c client.Client) clustersmngr.Client {
+		pool := &clustersmngrfakes.FakeClientsPool{}
+		pool.ClientReturns(c, nil)
+
+		return clustersmngr.NewClient(pool, nil, logr.Discard())
+	}
+
+	owned, err := service.listOwnedObjects(ctx, newClient(c), "Default", "test", workloads)
+	require.NoError(t, err)
+	require.Len(t, owned["backend"], 1)
+	assert.Equal(t, "backend-x2k4p", owned["backend"][0].GetName())
+
+	owned, err = service.listOwnedObjects(ctx, newClient(podListFailingClient{c}), "Default", "test", workloads)
+	assert.ErrorContains(t, err, "failed listing Pod of DaemonSet backend: list failed")
+	assert.Empty(t, owned)
+}
@@ -0,0 +1,101 @@
+package flagger_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestFetcher_ListCanaryObjectTree(t *testing.T) {
+	ctx := context.Background()
+
+	clusterClient, service, err := newService(ctx, k8sEnv)
+	require.NoError(t, err)
+
+	ns := pdtesting.NewNamespace(ctx, t, k8sEnv.Client)
+	target := pdtesting.NewDeployment(ctx, t, k8sEnv.Client, "backend", ns.Name)
+	canary := pdtesting.NewCanary(ctx, t, k8sEnv.Client, pdtesting.CanaryInfo{Name: "backend", Namespace: ns.Name})
+
+	current := newReplicaSet(ctx, t, k8sEnv.Client, target, "backend-7d9f", 1)
+	_ = newReplicaSet(ctx, t, k8sEnv.Client, target, "backend-5c4b", 0)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "backend-7d9f-x2k4p",
+			Namespace:       ns.Name,
+			Labels:          target.Spec.Template.Labels,
+			OwnerReferences: []metav1.OwnerReference{controllerRef("ReplicaSet", current.Name, current.UID)},
+		},
+		Spec: target.Spec.Template.Spec,
+	}
+	require.NoError(t, k8sEnv.Client.Create(ctx, pod))
+
+	// Pods the workloads don't select are not listed, whoever owns them.
+	unrelated := pod.DeepCopy()
+	unrelated.Name = "frontend-6b8c-z7q2m"
+	unrelated.ResourceVersion = ""
+	unrelated.UID = ""
+	unrelated.Labels = map[string]string{"app": "frontend"}
+	require.NoError(t, k8sEnv.Client.Create(ctx, unrelated))
+
+	tree, err := service.ListCanaryObjectTree(ctx, clusterClient, flagger.ListCanaryObjectsOptions{
+		Name:        canary.Name,
+		Namespace:   ns.Name,
+		ClusterName: "Default",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Canary", tree.Object.GetKind())
+	assert.Equal(t, canary.GetUID(), tree.Object.GetUID())
+	require.Len(t, tree.Children, 1)
+
+	deployment := tree.Children[0]
+	assert.Equal(t, "Deployment", deployment.Object.GetKind())
+	require.Len(t, deployment.Children, 1, "replica sets scaled to zero should be left out")
+
+	replicaSet := deployment.Children[0]
+	assert.Equal(t, current.Name, replicaSet.Object.GetName())
+	require.Len(t, replicaSet.Children, 1)
+	assert.Equal(t, pod.Name, replicaSet.Children[0].Object.GetName())
+	assert.Empty(t, replicaSet.Children[0].Children)
+}
+
+func newReplicaSet(ctx context.Context, t *testing.T, k client.Client, owner *appsv1.Deployment, name string, replicas int32) *appsv1.ReplicaSet {
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       owner.Namespace,
+			Labels:          owner.Spec.Template.Labels,
+			OwnerReferences: []metav1.OwnerReference{controllerRef("Deployment", owner.Name, owner.UID)},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Replicas: pointer.Int32(replicas),
+			Selector: owner.Spec.Selector,
+			Template: owner.Spec.Template,
+		},
+	}
+
+	require.NoError(t, k.Create(ctx, replicaSet))
+
+	return replicaSet
+}
+
+func controllerRef(kind, name string, uid types.UID) metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion: "apps/v1",
+		Kind:       kind,
+		Name:       name,
+		UID:        uid,
+		Controller: pointer.Bool(true),
+	}
+}
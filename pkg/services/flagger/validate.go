@@ -0,0 +1,370 @@
+package flagger
+
+import (
+	"context"
+	"fmt"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"github.com/weaveworks/progressive-delivery/pkg/services/delivery"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Finding is a problem found in a Canary, Field is the path of the field
+// causing it.
+type Finding struct {
+	Severity Severity
+	Field    string
+	Message  string
+}
+
+// Metrics Flagger queries without a template, for the providers supporting
+// them.
+var builtinMetrics = map[string]bool{
+	"request-success-rate": true,
+	"request-duration":     true,
+}
+
+var webhookTypes = map[flaggerv1.HookType]bool{
+	"":                             true,
+	flaggerv1.RolloutHook:          true,
+	flaggerv1.PreRolloutHook:       true,
+	flaggerv1.PostRolloutHook:      true,
+	flaggerv1.ConfirmRolloutHook:   true,
+	flaggerv1.ConfirmPromotionHook: true,
+	flaggerv1.EventHook:            true,
+	flaggerv1.RollbackHook:         true,
+}
+
+// ParseCanary decodes a Canary manifest. Unknown fields are ignored and
+// reported as warnings, as the API server would drop them.
+func ParseCanary(manifest []byte) (*flaggerv1.Canary, []Finding, error) {
+	findings := []Finding{}
+	canary := &flaggerv1.Canary{}
+
+	strict := serializer.NewCodecFactory(kube.CreateScheme(), serializer.EnableStrict).UniversalDeserializer()
+	if _, _, err := strict.Decode(manifest, nil, canary); err != nil {
+		lenient := serializer.NewCodecFactory(kube.CreateScheme()).UniversalDeserializer()
+
+		canary = &flaggerv1.Canary{}
+		if _, _, lenientErr := lenient.Decode(manifest, nil, canary); lenientErr != nil {
+			return nil, nil, fmt.Errorf("invalid canary manifest: %w", lenientErr)
+		}
+
+		findings = append(findings, Finding{Severity: SeverityWarning, Message: err.Error()})
+	}
+
+	return canary, findings, nil
+}
+
+// ValidateCanary checks the spec of a Canary and cross-checks the resources
+// it references on the cluster. Errors of the cluster client are returned,
+// missing resources are reported as findings.
+func (service *defaultFetcher) ValidateCanary(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, canary *flaggerv1.Canary) ([]Finding, error) {
+	findings := []Finding{}
+
+	if canary.GetName() == "" {
+		findings = append(findings, Finding{Severity: SeverityError, Field: "metadata.name", Message: "name is required"})
+	}
+
+	targetFindings, err := service.validateTarget(ctx, clusterName, clusterClient, canary)
+	if err != nil {
+		return nil, err
+	}
+
+	findings = append(findings, targetFindings...)
+
+	if canary.Spec.Analysis == nil {
+		if !canary.Spec.SkipAnalysis {
+			findings = append(findings, Finding{Severity: SeverityError, Field: "spec.analysis", Message: "analysis is required unless skipAnalysis is set"})
+		}
+
+		return findings, nil
+	}
+
+	findings = append(findings, service.validateStrategy(canary)...)
+
+	metricFindings, err := service.validateMetrics(ctx, clusterName, clusterClient, canary)
+	if err != nil {
+		return nil, err
+	}
+
+	findings = append(findings, metricFindings...)
+	findings = append(findings, validateWebhooks(canary)...)
+
+	providerFindings, err := service.validateProvider(ctx, clusterName, clusterClient, canary)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(findings, providerFindings...), nil
+}
+
+func (service *defaultFetcher) validateTarget(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, canary *flaggerv1.Canary) ([]Finding, error) {
+	ref := canary.Spec.TargetRef
+
+	if ref.Name == "" {
+		return []Finding{{Severity: SeverityError, Field: "spec.targetRef.name", Message: "target name is required"}}, nil
+	}
+
+	switch ref.Kind {
+	case "Deployment", "DaemonSet", "Service":
+	default:
+		return []Finding{{Severity: SeverityError, Field: "spec.targetRef.kind", Message: fmt.Sprintf("unsupported target kind %q", ref.Kind)}}, nil
+	}
+
+	findings := []Finding{}
+
+	if _, err := getRef(ctx, clusterClient, &ref, canary.GetNamespace(), clusterName); err != nil {
+		if !k8serrors.IsNotFound(err) && !apimeta.IsNoMatchError(err) {
+			return nil, fmt.Errorf("failed getting canary target: %w", err)
+		}
+
+		findings = append(findings, Finding{
+			Severity: SeverityError,
+			Field:    "spec.targetRef",
+			Message:  fmt.Sprintf("%s %s/%s not found", ref.Kind, canary.GetNamespace(), ref.Name),
+		})
+	}
+
+	// Flagger creates the primary objects when it initializes the Canary.
+	if !initialized(canary) || ref.Kind == "Service" {
+		return findings, nil
+	}
+
+	serviceName := ref.Name
+	if canary.Spec.Service.Name != "" {
+		serviceName = canary.Spec.Service.Name
+	}
+
+	primaries := []struct {
+		field string
+		obj   client.Object
+		name  string
+	}{
+		{"spec.targetRef", &unstructured.Unstructured{Object: map[string]interface{}{"apiVersion": ref.APIVersion, "kind": ref.Kind}}, ref.Name + "-primary"},
+		{"spec.service", &corev1.Service{}, serviceName + "-primary"},
+	}
+
+	for _, primary := range primaries {
+		key := client.ObjectKey{Name: primary.name, Namespace: canary.GetNamespace()}
+
+		if err := clusterClient.Get(ctx, clusterName, key, primary.obj); err != nil {
+			if !k8serrors.IsNotFound(err) && !apimeta.IsNoMatchError(err) {
+				return nil, fmt.Errorf("failed getting canary primary: %w", err)
+			}
+
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Field:    primary.field,
+				Message:  fmt.Sprintf("primary %s/%s not found", canary.GetNamespace(), primary.name),
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+func (service *defaultFetcher) validateStrategy(canary *flaggerv1.Canary) []Finding {
+	findings := []Finding{}
+	analysis := canary.Spec.Analysis
+	strategy := service.DeploymentStrategyFor(*canary)
+
+	if MeshProvider(canary.Spec.Provider) == flaggerv1.KubernetesProvider && strategy == delivery.CanaryDeploymentStrategy {
+		findings = append(findings, Finding{
+			Severity: SeverityError,
+			Field:    "spec.provider",
+			Message:  "the kubernetes provider can't shift traffic, set analysis iterations for blue/green",
+		})
+	}
+
+	if strategy != delivery.CanaryDeploymentStrategy {
+		return findings
+	}
+
+	maxWeight := analysis.MaxWeight
+	if maxWeight == 0 {
+		maxWeight = 100
+	}
+
+	if maxWeight > 100 {
+		findings = append(findings, Finding{Severity: SeverityError, Field: "spec.analysis.maxWeight", Message: "maxWeight can't be greater than 100"})
+	}
+
+	if len(analysis.StepWeights) == 0 {
+		switch {
+		case analysis.StepWeight <= 0:
+			findings = append(findings, Finding{Severity: SeverityError, Field: "spec.analysis.stepWeight", Message: "stepWeight or stepWeights is required"})
+		case analysis.StepWeight > maxWeight:
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Field:    "spec.analysis.stepWeight",
+				Message:  fmt.Sprintf("stepWeight %d is greater than maxWeight %d", analysis.StepWeight, maxWeight),
+			})
+		}
+
+		return findings
+	}
+
+	for i, weight := range analysis.StepWeights {
+		field := fmt.Sprintf("spec.analysis.stepWeights[%d]", i)
+
+		if weight <= 0 || weight > 100 {
+			findings = append(findings, Finding{Severity: SeverityError, Field: field, Message: "step weights must be between 1 and 100"})
+		}
+
+		if i > 0 && weight <= analysis.StepWeights[i-1] {
+			findings = append(findings, Finding{Severity: SeverityError, Field: field, Message: "step weights must be increasing"})
+		}
+	}
+
+	return findings
+}
+
+func (service *defaultFetcher) validateMetrics(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, canary *flaggerv1.Canary) ([]Finding, error) {
+	findings := []Finding{}
+
+	for i, metric := range canary.Spec.Analysis.Metrics {
+		field := fmt.Sprintf("spec.analysis.metrics[%d]", i)
+
+		if metric.Name == "" {
+			findings = append(findings, Finding{Severity: SeverityError, Field: field + ".name", Message: "metric name is required"})
+		}
+
+		if r := metric.ThresholdRange; r != nil && r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+			findings = append(findings, Finding{Severity: SeverityError, Field: field + ".thresholdRange", Message: "min is greater than max"})
+		}
+
+		if metric.TemplateRef == nil {
+			if metric.Name != "" && !builtinMetrics[metric.Name] {
+				findings = append(findings, Finding{
+					Severity: SeverityError,
+					Field:    field + ".templateRef",
+					Message:  fmt.Sprintf("metric %s is not a builtin metric and has no template", metric.Name),
+				})
+			}
+
+			continue
+		}
+
+		namespace := metric.TemplateRef.Namespace
+		if namespace == "" {
+			namespace = canary.GetNamespace()
+		}
+
+		if _, err := service.GetMetricTemplate(ctx, clusterName, clusterClient, metric.TemplateRef.Name, namespace); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed getting metric template: %w", err)
+			}
+
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Field:    field + ".templateRef",
+				Message:  fmt.Sprintf("metric template %s/%s not found", namespace, metric.TemplateRef.Name),
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+func validateWebhooks(canary *flaggerv1.Canary) []Finding {
+	findings := []Finding{}
+
+	for i, webhook := range canary.Spec.Analysis.Webhooks {
+		field := fmt.Sprintf("spec.analysis.webhooks[%d]", i)
+
+		if webhook.URL == "" {
+			findings = append(findings, Finding{Severity: SeverityError, Field: field + ".url", Message: "webhook url is required"})
+		}
+
+		if !webhookTypes[webhook.Type] {
+			findings = append(findings, Finding{Severity: SeverityError, Field: field + ".type", Message: fmt.Sprintf("unknown webhook type %q", webhook.Type)})
+		}
+	}
+
+	return findings
+}
+
+// validateProvider checks the mesh provider objects can be created on the
+// cluster, and that they have been once the Canary is initialized.
+func (service *defaultFetcher) validateProvider(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, canary *flaggerv1.Canary) ([]Finding, error) {
+	findings := []Finding{}
+
+	switch MeshProvider(canary.Spec.Provider) {
+	case flaggerv1.NGINXProvider, flaggerv1.SkipperProvider:
+		if canary.Spec.IngressRef == nil {
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Field:    "spec.ingressRef",
+				Message:  fmt.Sprintf("the %s provider requires an ingress reference", canary.Spec.Provider),
+			})
+		}
+	}
+
+	for _, kind := range meshProviderObjectKinds(canary.Spec.Provider) {
+		gvk, available, err := service.resolveKind(clusterName, clusterClient, kind)
+		if err != nil {
+			return nil, fmt.Errorf("failed resolving version of %s: %w", kind, err)
+		}
+
+		// Objects generated by the controller of the target are not owned by
+		// the Canary.
+		owned := kind.OwnedByTarget
+
+		if available && !owned {
+			list := unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(gvk)
+
+			if err := clusterClient.List(ctx, clusterName, &list, client.InNamespace(canary.GetNamespace())); err != nil {
+				return nil, fmt.Errorf("failed listing mesh provider resource: %w", err)
+			}
+
+			for _, obj := range list.Items {
+				for _, ref := range obj.GetOwnerReferences() {
+					if ref.UID == canary.GetUID() {
+						owned = true
+					}
+				}
+			}
+		}
+
+		switch {
+		case !available:
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Field:    "spec.provider",
+				Message:  fmt.Sprintf("%s resources of the %s provider are not available on the cluster", kind.Kind, canary.Spec.Provider),
+			})
+		case initialized(canary) && !owned:
+			findings = append(findings, Finding{
+				Severity: SeverityWarning,
+				Field:    "spec.provider",
+				Message:  fmt.Sprintf("no %s of the %s provider has been created for the canary", kind.Kind, canary.Spec.Provider),
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// initialized reports whether Flagger has created the primary and provider
+// objects of the Canary.
+func initialized(canary *flaggerv1.Canary) bool {
+	return canary.GetUID() != "" &&
+		canary.Status.Phase != "" &&
+		canary.Status.Phase != flaggerv1.CanaryPhaseInitializing
+}
@@ -0,0 +1,180 @@
+package flagger_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestParseCanary(t *testing.T) {
+	canary, findings, err := flagger.ParseCanary([]byte(`
+apiVersion: flagger.app/v1beta1
+kind: Canary
+metadata:
+  name: podinfo
+spec:
+  targetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: podinfo
+  analysis:
+    stepWeight: 10
+    unknownField: true
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, "podinfo", canary.GetName())
+	assert.Equal(t, 10, canary.Spec.Analysis.StepWeight)
+	assert.Len(t, findings, 1)
+	assert.Equal(t, flagger.SeverityWarning, findings[0].Severity)
+
+	_, _, err = flagger.ParseCanary([]byte("not: [a canary"))
+	assert.Error(t, err)
+}
+
+func TestFetcher_ValidateCanary(t *testing.T) {
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	defer cancelFn()
+
+	k, err := client.New(k8sEnv.Rest, client.Options{
+		Scheme: kube.CreateScheme(),
+	})
+	assert.NoError(t, err)
+
+	ns := pdtesting.NewNamespace(ctx, t, k)
+
+	cl, service, err := newService(ctx, k8sEnv)
+	assert.NoError(t, err)
+
+	deployment := pdtesting.NewDeployment(ctx, t, k, "podinfo", ns.GetName())
+	defer pdtesting.Cleanup(ctx, t, k, deployment)
+
+	template := pdtesting.NewMetricTemplate(ctx, t, k, pdtesting.MetricTemplateInfo{
+		Name:            "latency",
+		Namespace:       ns.GetName(),
+		ProviderType:    "prometheus",
+		ProviderAddress: "http://prometheus:9090",
+		Query:           "custom query",
+	})
+	defer pdtesting.Cleanup(ctx, t, k, template)
+
+	manifest := func(target, provider, analysis string) []byte {
+		return []byte(fmt.Sprintf(`
+apiVersion: flagger.app/v1beta1
+kind: Canary
+metadata:
+  name: podinfo
+  namespace: %s
+spec:
+  provider: %s
+  targetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: %s
+  service:
+    port: 80
+  analysis:
+%s
+`, ns.GetName(), provider, target, analysis))
+	}
+
+	tests := []struct {
+		name     string
+		manifest []byte
+		expected []flagger.Finding
+	}{
+		{
+			name: "valid",
+			manifest: manifest("podinfo", "linkerd", `
+    interval: 1m
+    stepWeight: 10
+    maxWeight: 50
+    metrics:
+      - name: request-success-rate
+        thresholdRange:
+          min: 99
+      - name: latency
+        templateRef:
+          name: latency
+`),
+			expected: []flagger.Finding{},
+		},
+		{
+			name: "step weight greater than max weight",
+			manifest: manifest("podinfo", "linkerd", `
+    stepWeight: 60
+    maxWeight: 50
+`),
+			expected: []flagger.Finding{
+				{Severity: flagger.SeverityError, Field: "spec.analysis.stepWeight", Message: "stepWeight 60 is greater than maxWeight 50"},
+			},
+		},
+		{
+			name: "missing target and metric template",
+			manifest: manifest("missing", "linkerd", `
+    stepWeight: 10
+    metrics:
+      - name: errors
+        templateRef:
+          name: errors
+      - name: custom
+`),
+			expected: []flagger.Finding{
+				{Severity: flagger.SeverityError, Field: "spec.targetRef", Message: fmt.Sprintf("Deployment %s/missing not found", ns.GetName())},
+				{Severity: flagger.SeverityError, Field: "spec.analysis.metrics[0].templateRef", Message: fmt.Sprintf("metric template %s/errors not found", ns.GetName())},
+				{Severity: flagger.SeverityError, Field: "spec.analysis.metrics[1].templateRef", Message: "metric custom is not a builtin metric and has no template"},
+			},
+		},
+		{
+			name: "provider not available",
+			manifest: manifest("podinfo", "istio", `
+    stepWeights: [10, 5]
+`),
+			expected: []flagger.Finding{
+				{Severity: flagger.SeverityError, Field: "spec.analysis.stepWeights[1]", Message: "step weights must be increasing"},
+				{Severity: flagger.SeverityError, Field: "spec.provider", Message: "DestinationRule resources of the istio provider are not available on the cluster"},
+				{Severity: flagger.SeverityError, Field: "spec.provider", Message: "VirtualService resources of the istio provider are not available on the cluster"},
+			},
+		},
+		{
+			name: "apisix not available",
+			manifest: manifest("podinfo", "apisix", `
+    iterations: 10
+`),
+			expected: []flagger.Finding{
+				{Severity: flagger.SeverityError, Field: "spec.provider", Message: "ApisixRoute resources of the apisix provider are not available on the cluster"},
+			},
+		},
+		{
+			name: "nginx without ingress",
+			manifest: manifest("podinfo", "nginx", `
+    iterations: 10
+    match:
+      - headers:
+          x-canary:
+            exact: "insider"
+`),
+			expected: []flagger.Finding{
+				{Severity: flagger.SeverityError, Field: "spec.ingressRef", Message: "the nginx provider requires an ingress reference"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			canary, findings, err := flagger.ParseCanary(tt.manifest)
+			assert.NoError(t, err)
+			assert.Empty(t, findings)
+
+			findings, err = service.ValidateCanary(ctx, "Default", cl, canary)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, findings)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package flagger
+
+import (
+	"context"
+
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// flaggerSelectors match the Deployment of Flagger, as labelled by its Helm
+// chart and by its kustomization.
+var flaggerSelectors = []client.MatchingLabels{
+	{"app.kubernetes.io/name": "flagger"},
+	{"app": "flagger"},
+}
+
+// FlaggerVersion returns the version of Flagger running on the cluster, the
+// tag of the image of its Deployment. It's empty if no Deployment of Flagger
+// is found.
+func (service *defaultFetcher) FlaggerVersion(ctx context.Context, clusterName string, clusterClient clustersmngr.Client) (string, error) {
+	for _, selector := range flaggerSelectors {
+		list := appsv1.DeploymentList{}
+
+		if err := clusterClient.List(ctx, clusterName, &list, selector); err != nil {
+			return "", err
+		}
+
+		for _, deployment := range list.Items {
+			for _, container := range deployment.Spec.Template.Spec.Containers {
+				if container.Name == "flagger" {
+					return ParseImageReference(container.Image).Tag, nil
+				}
+			}
+		}
+	}
+
+	return "", nil
+}
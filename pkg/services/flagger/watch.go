@@ -0,0 +1,347 @@
+package flagger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster"
+	wgkube "github.com/weaveworks/weave-gitops/pkg/kube"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const watchRetryInterval = 5 * time.Second
+
+type WatchCanariesOptions struct {
+	ClusterName string
+	Namespace   string
+}
+
+// CanaryEvent is a change of a Canary on a cluster. Type is one of
+// watch.Added, watch.Modified or watch.Deleted. Modifications are only sent
+// when the progress of the Canary changes.
+type CanaryEvent struct {
+	Type        watch.EventType
+	ClusterName string
+	Canary      flaggerv1.Canary
+}
+
+// canaryProgress holds the status fields that describe how far a rollout went,
+// modifications that leave them unchanged are not reported.
+type canaryProgress struct {
+	Phase        flaggerv1.CanaryPhase
+	CanaryWeight int
+	Iterations   int
+	FailedChecks int
+}
+
+// seenCanary is the last version of a Canary sent, it's sent again when the
+// Canary is found deleted.
+type seenCanary struct {
+	Progress canaryProgress
+	Canary   flaggerv1.Canary
+}
+
+func progressOf(canary *flaggerv1.Canary) canaryProgress {
+	return canaryProgress{
+		Phase:        canary.Status.Phase,
+		CanaryWeight: canary.Status.CanaryWeight,
+		Iterations:   canary.Status.Iterations,
+		FailedChecks: canary.Status.FailedChecks,
+	}
+}
+
+// WatchCanaries watches Canaries in every namespace the user has access to on
+// the given clusters. The returned channel is closed when ctx is done.
+func (service *defaultFetcher) WatchCanaries(
+	ctx context.Context,
+	clusterClient clustersmngr.Client,
+	clusters []cluster.Cluster,
+	user *auth.UserPrincipal,
+	opts WatchCanariesOptions,
+) (<-chan CanaryEvent, error) {
+	if user == nil {
+		return nil, fmt.Errorf("no user principal found in context")
+	}
+
+	namespaces := clusterClient.Namespaces()
+
+	return service.watchCanaries(ctx, clusters, opts, func(c cluster.Cluster) ([]string, *rest.Config, error) {
+		cfg, err := c.GetServerConfig()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		names := []string{}
+		for _, ns := range namespaces[c.GetName()] {
+			names = append(names, ns.Name)
+		}
+
+		return names, wgkube.ConfigWithPrincipal(user, cfg), nil
+	})
+}
+
+// WatchAllCanaries watches Canaries in all namespaces of the given clusters
+// with the permissions of the server. It must not be used to serve users.
+func (service *defaultFetcher) WatchAllCanaries(
+	ctx context.Context,
+	clusters []cluster.Cluster,
+	opts WatchCanariesOptions,
+) (<-chan CanaryEvent, error) {
+	return service.watchCanaries(ctx, clusters, opts, func(c cluster.Cluster) ([]string, *rest.Config, error) {
+		cfg, err := c.GetServerConfig()
+
+		return []string{metav1.NamespaceAll}, cfg, err
+	})
+}
+
+// watchTargetsFunc returns the namespaces to watch on a cluster and the
+// config to watch them with.
+type watchTargetsFunc func(c cluster.Cluster) ([]string, *rest.Config, error)
+
+func (service *defaultFetcher) watchCanaries(
+	ctx context.Context,
+	clusters []cluster.Cluster,
+	opts WatchCanariesOptions,
+	targets watchTargetsFunc,
+) (<-chan CanaryEvent, error) {
+	events := make(chan CanaryEvent)
+	watchers := 0
+
+	done := make(chan struct{})
+
+	for _, c := range clusters {
+		clusterName := c.GetName()
+
+		if opts.ClusterName != "" && opts.ClusterName != clusterName {
+			continue
+		}
+
+		if !service.crdService.IsAvailable(clusterName, crd.FlaggerCRDName) {
+			service.logger.Error(FlaggerIsNotAvailableError{ClusterName: clusterName}, "flagger unavailable")
+			continue
+		}
+
+		namespaces, cfg, err := targets(c)
+		if err != nil {
+			return nil, fmt.Errorf("failed getting config for cluster %s: %w", clusterName, err)
+		}
+
+		watchClient, err := client.NewWithWatch(cfg, client.Options{
+			Scheme: kube.CreateScheme(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed creating watch client for cluster %s: %w", clusterName, err)
+		}
+
+		for _, ns := range namespaces {
+			switch {
+			case opts.Namespace == "":
+			case ns == metav1.NamespaceAll:
+				ns = opts.Namespace
+			case ns != opts.Namespace:
+				continue
+			}
+
+			watchers++
+
+			go func(clusterName, namespace string) {
+				service.watchNamespace(ctx, watchClient, clusterName, namespace, events)
+				done <- struct{}{}
+			}(clusterName, ns)
+		}
+	}
+
+	go func() {
+		for i := 0; i < watchers; i++ {
+			<-done
+		}
+
+		close(events)
+	}()
+
+	return events, nil
+}
+
+// watchNamespace keeps a watch open on a namespace until ctx is done. The
+// Canaries are listed first and the watch starts from the version of the list.
+// When the API server closes the watch, it's restarted from the last seen
+// resource version, or after listing again if that version is gone.
+func (service *defaultFetcher) watchNamespace(
+	ctx context.Context,
+	watchClient client.WithWatch,
+	clusterName, namespace string,
+	events chan<- CanaryEvent,
+) {
+	seen := map[types.UID]seenCanary{}
+	resourceVersion := ""
+
+	for {
+		var err error
+
+		if resourceVersion == "" {
+			resourceVersion, err = service.relist(ctx, watchClient, clusterName, namespace, seen, events)
+		}
+
+		if err == nil {
+			var w watch.Interface
+
+			w, err = watchClient.Watch(ctx, &flaggerv1.CanaryList{}, &client.ListOptions{
+				Namespace: namespace,
+				Raw:       &metav1.ListOptions{ResourceVersion: resourceVersion},
+			})
+			if err == nil {
+				resourceVersion = service.forwardEvents(ctx, w, clusterName, seen, resourceVersion, events)
+			}
+		}
+
+		if err != nil {
+			service.logger.Error(err, "failed watching canaries", "cluster", clusterName, "namespace", namespace)
+
+			if k8serrors.IsForbidden(err) {
+				return
+			}
+
+			if k8serrors.IsResourceExpired(err) || k8serrors.IsGone(err) {
+				resourceVersion = ""
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRetryInterval):
+		}
+	}
+}
+
+// relist lists the Canaries of the namespace and sends the changes since they
+// were last seen, including the deletion of the Canaries missing from the
+// list. It returns the resource version of the list to watch from.
+func (service *defaultFetcher) relist(
+	ctx context.Context,
+	watchClient client.WithWatch,
+	clusterName, namespace string,
+	seen map[types.UID]seenCanary,
+	events chan<- CanaryEvent,
+) (string, error) {
+	list := &flaggerv1.CanaryList{}
+	if err := watchClient.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return "", err
+	}
+
+	listed := map[types.UID]bool{}
+
+	for i := range list.Items {
+		canary := &list.Items[i]
+		listed[canary.GetUID()] = true
+
+		eventType, send := nextEventType(seen, watch.Added, canary)
+		if send && !sendEvent(ctx, events, CanaryEvent{Type: eventType, ClusterName: clusterName, Canary: *canary}) {
+			return list.GetResourceVersion(), nil
+		}
+	}
+
+	for uid, previous := range seen {
+		if listed[uid] {
+			continue
+		}
+
+		delete(seen, uid)
+
+		if !sendEvent(ctx, events, CanaryEvent{Type: watch.Deleted, ClusterName: clusterName, Canary: previous.Canary}) {
+			break
+		}
+	}
+
+	return list.GetResourceVersion(), nil
+}
+
+// forwardEvents sends changes from a watch to events until the watch or ctx
+// is closed, and returns the resource version to resume from.
+func (service *defaultFetcher) forwardEvents(
+	ctx context.Context,
+	w watch.Interface,
+	clusterName string,
+	seen map[types.UID]seenCanary,
+	resourceVersion string,
+	events chan<- CanaryEvent,
+) string {
+	defer w.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return resourceVersion
+			}
+
+			if event.Type == watch.Error {
+				service.logger.Error(k8serrors.FromObject(event.Object), "canary watch error", "cluster", clusterName)
+
+				// The resource version is too old, start again with a fresh list.
+				return ""
+			}
+
+			canary, ok := event.Object.(*flaggerv1.Canary)
+			if !ok {
+				continue
+			}
+
+			resourceVersion = canary.GetResourceVersion()
+
+			eventType, send := nextEventType(seen, event.Type, canary)
+			if send && !sendEvent(ctx, events, CanaryEvent{Type: eventType, ClusterName: clusterName, Canary: *canary}) {
+				return resourceVersion
+			}
+		}
+	}
+}
+
+// sendEvent returns false if ctx is done before the event is sent.
+func sendEvent(ctx context.Context, events chan<- CanaryEvent, event CanaryEvent) bool {
+	select {
+	case events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// nextEventType records the progress of the canary and returns which event
+// should be sent for it, if any. Relisted Canaries are replayed as added, they
+// are only sent again if they progressed in the meantime.
+func nextEventType(seen map[types.UID]seenCanary, eventType watch.EventType, canary *flaggerv1.Canary) (watch.EventType, bool) {
+	uid := canary.GetUID()
+	progress := progressOf(canary)
+	previous, known := seen[uid]
+
+	switch eventType {
+	case watch.Deleted:
+		delete(seen, uid)
+
+		return watch.Deleted, true
+	case watch.Added, watch.Modified:
+		seen[uid] = seenCanary{Progress: progress, Canary: *canary}
+
+		if !known {
+			return watch.Added, true
+		}
+
+		return watch.Modified, previous.Progress != progress
+	}
+
+	return eventType, false
+}
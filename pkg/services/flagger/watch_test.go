@@ -0,0 +1,68 @@
+package flagger
+
+import (
+	"context"
+	"testing"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/progressive-delivery/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRelist(t *testing.T) {
+	ctx := context.Background()
+
+	newCanary := func(name string) *flaggerv1.Canary {
+		return &flaggerv1.Canary{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test", UID: types.UID(name)},
+		}
+	}
+
+	kept, removed := newCanary("kept"), newCanary("removed")
+
+	c := fake.NewClientBuilder().WithScheme(kube.CreateScheme()).WithObjects(kept, removed).Build()
+	service := &defaultFetcher{logger: logr.Discard()}
+	seen := map[types.UID]seenCanary{}
+
+	relist := func() []CanaryEvent {
+		events := make(chan CanaryEvent, 10)
+
+		_, err := service.relist(ctx, c, "Default", "test", seen, events)
+		require.NoError(t, err)
+
+		close(events)
+
+		sent := []CanaryEvent{}
+		for event := range events {
+			sent = append(sent, event)
+		}
+
+		return sent
+	}
+
+	events := relist()
+	require.Len(t, events, 2)
+	assert.Equal(t, watch.Added, events[0].Type)
+	assert.Equal(t, watch.Added, events[1].Type)
+
+	assert.Empty(t, relist(), "unchanged canaries are not sent again")
+
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "kept", Namespace: "test"}, kept))
+	kept.Status.Phase = flaggerv1.CanaryPhaseProgressing
+	require.NoError(t, c.Status().Update(ctx, kept))
+	require.NoError(t, c.Delete(ctx, removed))
+
+	events = relist()
+	require.Len(t, events, 2)
+	assert.Equal(t, watch.Modified, events[0].Type)
+	assert.Equal(t, "kept", events[0].Canary.GetName())
+	assert.Equal(t, watch.Deleted, events[1].Type)
+	assert.Equal(t, "removed", events[1].Canary.GetName())
+	assert.NotContains(t, seen, types.UID("removed"))
+}
@@ -0,0 +1,212 @@
+package flagger
+
+import (
+	"context"
+	"fmt"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Kinds of objects a Canary can target.
+const (
+	DeploymentKind = "Deployment"
+	DaemonSetKind  = "DaemonSet"
+	ServiceKind    = "Service"
+)
+
+// knativeServiceKind is the Knative Service a Canary of the Knative provider
+// targets, it has no primary.
+var knativeServiceKind = schema.GroupVersionKind{Group: "serving.knative.dev", Version: "v1", Kind: ServiceKind}
+
+// Workload is the target of a Canary or its primary, a Deployment, a
+// DaemonSet, a Service or a Knative Service. Services have no containers.
+type Workload struct {
+	Kind            string
+	Name            string
+	Namespace       string
+	UID             types.UID
+	ResourceVersion string
+	Labels          map[string]string
+	Containers      []corev1.Container
+}
+
+// Images returns the image of each container by container name.
+func (w Workload) Images() map[string]string {
+	images := map[string]string{}
+
+	for _, container := range w.Containers {
+		images[container.Name] = container.Image
+	}
+
+	return images
+}
+
+// TargetKind returns the kind of the target of the Canary, Flagger defaults
+// to Deployment.
+func TargetKind(canary flaggerv1.Canary) string {
+	return TargetGroupVersionKind(canary).Kind
+}
+
+// TargetGroupVersionKind returns the group, version and kind of the target of
+// the Canary, keyed on both group and kind so a Knative Service isn't taken
+// for a core Service. Unknown targets default to Deployment.
+func TargetGroupVersionKind(canary flaggerv1.Canary) schema.GroupVersionKind {
+	ref := canary.Spec.TargetRef
+
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		gv = schema.GroupVersion{}
+	}
+
+	switch (schema.GroupKind{Group: gv.Group, Kind: ref.Kind}) {
+	case schema.GroupKind{Group: v1.GroupName, Kind: DaemonSetKind}, schema.GroupKind{Kind: DaemonSetKind}:
+		return v1.SchemeGroupVersion.WithKind(DaemonSetKind)
+	case schema.GroupKind{Group: corev1.GroupName, Kind: ServiceKind}:
+		return corev1.SchemeGroupVersion.WithKind(ServiceKind)
+	case knativeServiceKind.GroupKind():
+		if gv.Version != "" {
+			return gv.WithKind(ServiceKind)
+		}
+
+		return knativeServiceKind
+	default:
+		return v1.SchemeGroupVersion.WithKind(DeploymentKind)
+	}
+}
+
+// HasPrimary reports whether Flagger creates a primary for the target of the
+// Canary, Knative Services are routed to their revisions instead.
+func HasPrimary(canary flaggerv1.Canary) bool {
+	return TargetGroupVersionKind(canary).GroupKind() != knativeServiceKind.GroupKind()
+}
+
+// PrimaryName returns the name of the primary Flagger creates for the Canary.
+func PrimaryName(canary flaggerv1.Canary) string {
+	return fmt.Sprintf("%s-primary", canary.Spec.TargetRef.Name)
+}
+
+// WorkloadFromObject returns the Workload of a Deployment, a DaemonSet, a
+// Service or a Knative Service read as unstructured.
+func WorkloadFromObject(obj client.Object) Workload {
+	workload := Workload{
+		Name:            obj.GetName(),
+		Namespace:       obj.GetNamespace(),
+		UID:             obj.GetUID(),
+		ResourceVersion: obj.GetResourceVersion(),
+		Labels:          obj.GetLabels(),
+	}
+
+	switch o := obj.(type) {
+	case *v1.Deployment:
+		workload.Kind = DeploymentKind
+		workload.Containers = o.Spec.Template.Spec.Containers
+	case *v1.DaemonSet:
+		workload.Kind = DaemonSetKind
+		workload.Containers = o.Spec.Template.Spec.Containers
+	case *corev1.Service:
+		workload.Kind = ServiceKind
+	case *unstructured.Unstructured:
+		workload.Kind = o.GetKind()
+		workload.Containers = unstructuredContainers(o)
+	}
+
+	return workload
+}
+
+// unstructuredContainers returns the containers of the pod template of an
+// object, Knative Services have one in spec.template.
+func unstructuredContainers(obj *unstructured.Unstructured) []corev1.Container {
+	spec, found, err := unstructured.NestedMap(obj.Object, "spec", "template", "spec")
+	if err != nil || !found {
+		return nil
+	}
+
+	podSpec := corev1.PodSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(spec, &podSpec); err != nil {
+		return nil
+	}
+
+	return podSpec.Containers
+}
+
+func newWorkloadObject(gvk schema.GroupVersionKind) client.Object {
+	switch gvk.GroupKind() {
+	case schema.GroupKind{Group: v1.GroupName, Kind: DaemonSetKind}:
+		return &v1.DaemonSet{}
+	case schema.GroupKind{Group: corev1.GroupName, Kind: ServiceKind}:
+		return &corev1.Service{}
+	case knativeServiceKind.GroupKind():
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+
+		return obj
+	default:
+		return &v1.Deployment{}
+	}
+}
+
+func newWorkloadList(gvk schema.GroupVersionKind) client.ObjectList {
+	switch gvk.GroupKind() {
+	case schema.GroupKind{Group: v1.GroupName, Kind: DaemonSetKind}:
+		return &v1.DaemonSetList{}
+	case schema.GroupKind{Group: corev1.GroupName, Kind: ServiceKind}:
+		return &corev1.ServiceList{}
+	case knativeServiceKind.GroupKind():
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+		return list
+	default:
+		return &v1.DeploymentList{}
+	}
+}
+
+// workloadsFromList returns the Workloads of a list made with
+// newWorkloadList.
+func workloadsFromList(list client.ObjectList) []Workload {
+	workloads := []Workload{}
+
+	switch l := list.(type) {
+	case *v1.DeploymentList:
+		for i := range l.Items {
+			workloads = append(workloads, WorkloadFromObject(&l.Items[i]))
+		}
+	case *v1.DaemonSetList:
+		for i := range l.Items {
+			workloads = append(workloads, WorkloadFromObject(&l.Items[i]))
+		}
+	case *corev1.ServiceList:
+		for i := range l.Items {
+			workloads = append(workloads, WorkloadFromObject(&l.Items[i]))
+		}
+	case *unstructured.UnstructuredList:
+		for i := range l.Items {
+			workloads = append(workloads, WorkloadFromObject(&l.Items[i]))
+		}
+	}
+
+	return workloads
+}
+
+func (service *defaultFetcher) getWorkload(ctx context.Context, clusterName string, c clustersmngr.Client, gvk schema.GroupVersionKind, name, namespace string) (Workload, error) {
+	obj := newWorkloadObject(gvk)
+
+	key := client.ObjectKey{
+		Name:      name,
+		Namespace: namespace,
+	}
+
+	if err := service.get(ctx, clusterName, c, key, obj); err != nil {
+		return Workload{}, err
+	}
+
+	return WorkloadFromObject(obj), nil
+}
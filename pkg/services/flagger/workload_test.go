@@ -0,0 +1,111 @@
+package flagger_test
+
+import (
+	"context"
+	"testing"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestFetcher_FetchTargetRef_DaemonSet(t *testing.T) {
+	ctx := context.Background()
+
+	clusterClient, service, err := newService(ctx, k8sEnv)
+	assert.NoError(t, err)
+
+	ns := pdtesting.NewNamespace(ctx, t, k8sEnv.Client)
+	target := pdtesting.NewDaemonSet(ctx, t, k8sEnv.Client, "agent", ns.Name)
+	promoted := pdtesting.NewDaemonSet(ctx, t, k8sEnv.Client, "agent-primary", ns.Name)
+
+	canary := &flaggerv1.Canary{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: ns.Name},
+		Spec: flaggerv1.CanarySpec{
+			TargetRef: flaggerv1.LocalObjectReference{Kind: "DaemonSet", Name: "agent"},
+		},
+	}
+
+	workload, err := service.FetchTargetRef(ctx, "Default", clusterClient, canary)
+	assert.NoError(t, err)
+	assert.Equal(t, flagger.DaemonSetKind, workload.Kind)
+	assert.Equal(t, target.GetUID(), workload.UID)
+	assert.Equal(t, target.GetLabels(), workload.Labels)
+	assert.Equal(t, map[string]string{"nginx": "nginx"}, workload.Images())
+
+	workload, err = service.FetchPromoted(ctx, "Default", clusterClient, canary)
+	assert.NoError(t, err)
+	assert.Equal(t, promoted.GetUID(), workload.UID)
+
+	objects, errs := service.FetchCanaryObjects(ctx, clusterClient, map[string][]flaggerv1.Canary{"Default": {*canary}}, flagger.FetchCanaryObjectsOptions{})
+	assert.Empty(t, errs)
+	assert.Equal(t, target.GetUID(), objects["Default"][0].Target.UID)
+	assert.Equal(t, promoted.GetUID(), objects["Default"][0].Promoted.UID)
+}
+
+func TestFetcher_FetchTargetRef_Service(t *testing.T) {
+	ctx := context.Background()
+
+	clusterClient, service, err := newService(ctx, k8sEnv)
+	assert.NoError(t, err)
+
+	ns := pdtesting.NewNamespace(ctx, t, k8sEnv.Client)
+	target := pdtesting.NewService(ctx, t, k8sEnv.Client, "frontend", ns.Name)
+
+	// A Deployment with the same name must not be taken as the target.
+	pdtesting.NewDeployment(ctx, t, k8sEnv.Client, "frontend", ns.Name)
+
+	canary := &flaggerv1.Canary{
+		ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: ns.Name},
+		Spec: flaggerv1.CanarySpec{
+			TargetRef: flaggerv1.LocalObjectReference{Kind: "Service", Name: "frontend"},
+		},
+	}
+
+	workload, err := service.FetchTargetRef(ctx, "Default", clusterClient, canary)
+	assert.NoError(t, err)
+	assert.Equal(t, flagger.ServiceKind, workload.Kind)
+	assert.Equal(t, target.GetUID(), workload.UID)
+	assert.Empty(t, workload.Images())
+
+	_, err = service.FetchPromoted(ctx, "Default", clusterClient, canary)
+	assert.Error(t, err)
+
+	objects, errs := service.FetchCanaryObjects(ctx, clusterClient, map[string][]flaggerv1.Canary{"Default": {*canary}}, flagger.FetchCanaryObjectsOptions{})
+	assert.Empty(t, errs)
+	assert.Equal(t, target.GetUID(), objects["Default"][0].Target.UID)
+	assert.Empty(t, objects["Default"][0].Promoted.Name)
+}
+
+func TestTargetKind(t *testing.T) {
+	tests := []struct {
+		apiVersion string
+		kind       string
+		expected   schema.GroupVersionKind
+	}{
+		{apiVersion: "apps/v1", kind: "Deployment", expected: appsv1.SchemeGroupVersion.WithKind(flagger.DeploymentKind)},
+		{apiVersion: "apps/v1", kind: "DaemonSet", expected: appsv1.SchemeGroupVersion.WithKind(flagger.DaemonSetKind)},
+		{kind: "DaemonSet", expected: appsv1.SchemeGroupVersion.WithKind(flagger.DaemonSetKind)},
+		{apiVersion: "v1", kind: "Service", expected: corev1.SchemeGroupVersion.WithKind(flagger.ServiceKind)},
+		{apiVersion: "serving.knative.dev/v1", kind: "Service", expected: schema.GroupVersionKind{Group: "serving.knative.dev", Version: "v1", Kind: flagger.ServiceKind}},
+		{apiVersion: "apps/v1", kind: "Service", expected: appsv1.SchemeGroupVersion.WithKind(flagger.DeploymentKind)},
+		{expected: appsv1.SchemeGroupVersion.WithKind(flagger.DeploymentKind)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.apiVersion+"/"+tt.kind, func(t *testing.T) {
+			canary := flaggerv1.Canary{}
+			canary.Spec.TargetRef.APIVersion = tt.apiVersion
+			canary.Spec.TargetRef.Kind = tt.kind
+
+			assert.Equal(t, tt.expected, flagger.TargetGroupVersionKind(canary))
+			assert.Equal(t, tt.expected.Kind, flagger.TargetKind(canary))
+			assert.Equal(t, tt.expected.Group != "serving.knative.dev", flagger.HasPrimary(canary))
+		})
+	}
+}
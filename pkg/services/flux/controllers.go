@@ -0,0 +1,57 @@
+package flux
+
+import (
+	"github.com/weaveworks/progressive-delivery/pkg/services/crd"
+)
+
+// Controller is a Flux controller, detected by the CRDs of its kinds.
+type Controller struct {
+	Name string
+	// Kinds are the kinds of the controller installed on the cluster.
+	Kinds []string
+}
+
+// controllerKinds are the kinds of each Flux controller, with their CRD.
+var controllerKinds = []struct {
+	controller string
+	kind       string
+	crd        string
+}{
+	{"source-controller", "GitRepository", "gitrepositories.source.toolkit.fluxcd.io"},
+	{"source-controller", "OCIRepository", "ocirepositories.source.toolkit.fluxcd.io"},
+	{"source-controller", "HelmRepository", "helmrepositories.source.toolkit.fluxcd.io"},
+	{"source-controller", "HelmChart", "helmcharts.source.toolkit.fluxcd.io"},
+	{"source-controller", "Bucket", "buckets.source.toolkit.fluxcd.io"},
+	{"kustomize-controller", KustomizationKind, "kustomizations.kustomize.toolkit.fluxcd.io"},
+	{"helm-controller", HelmReleaseKind, "helmreleases.helm.toolkit.fluxcd.io"},
+	{"notification-controller", "Alert", "alerts.notification.toolkit.fluxcd.io"},
+	{"notification-controller", "Provider", "providers.notification.toolkit.fluxcd.io"},
+	{"notification-controller", "Receiver", "receivers.notification.toolkit.fluxcd.io"},
+	{"image-reflector-controller", "ImageRepository", "imagerepositories.image.toolkit.fluxcd.io"},
+	{"image-reflector-controller", "ImagePolicy", "imagepolicies.image.toolkit.fluxcd.io"},
+	{"image-automation-controller", "ImageUpdateAutomation", "imageupdateautomations.image.toolkit.fluxcd.io"},
+}
+
+// InstalledControllers returns the Flux controllers with at least one of
+// their CRDs installed on the cluster.
+func InstalledControllers(crdService crd.Fetcher, clusterName string) []Controller {
+	controllers := []Controller{}
+	index := map[string]int{}
+
+	for _, kind := range controllerKinds {
+		if !crdService.IsAvailable(clusterName, kind.crd) {
+			continue
+		}
+
+		i, ok := index[kind.controller]
+		if !ok {
+			i = len(controllers)
+			index[kind.controller] = i
+			controllers = append(controllers, Controller{Name: kind.controller})
+		}
+
+		controllers[i].Kinds = append(controllers[i].Kinds, kind.kind)
+	}
+
+	return controllers
+}
@@ -0,0 +1,19 @@
+package flux
+
+import "fmt"
+
+type UnsupportedAutomationKindError struct {
+	Kind string
+}
+
+func (e UnsupportedAutomationKindError) Error() string {
+	return fmt.Sprintf("unsupported automation kind: %s", e.Kind)
+}
+
+type UnsupportedSourceKindError struct {
+	Kind string
+}
+
+func (e UnsupportedSourceKindError) Error() string {
+	return fmt.Sprintf("unsupported source kind: %s", e.Kind)
+}
@@ -0,0 +1,244 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2beta1"
+	kustomizev2 "github.com/fluxcd/kustomize-controller/api/v1beta2"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/go-logr/logr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Kinds of Flux objects applying workloads.
+const (
+	KustomizationKind = "Kustomization"
+	HelmReleaseKind   = "HelmRelease"
+)
+
+type Fetcher interface {
+	GetAutomation(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, ref AutomationReference) (*Automation, error)
+	RequestReconcile(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, ref AutomationReference, opts ReconcileOptions) (time.Time, error)
+}
+
+func NewFetcher(logger logr.Logger) Fetcher {
+	return &defaultFetcher{logger: logger}
+}
+
+type defaultFetcher struct {
+	logger logr.Logger
+}
+
+// AutomationReference is a Kustomization or a HelmRelease.
+type AutomationReference struct {
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+// Automation is the Flux object applying a workload, with the source it
+// applies.
+type Automation struct {
+	AutomationReference
+	Suspended bool
+	// Ready is nil until the object is reconciled.
+	Ready                 *metav1.Condition
+	LastAppliedRevision   string
+	LastAttemptedRevision string
+	// Commit is the Git commit applied, empty if the source isn't a
+	// GitRepository. HelmRelease revisions are chart versions, the commit of
+	// the latest artifact of the source is used for them.
+	Commit string
+	// Source is nil if it can't be read.
+	Source *Source
+}
+
+// Source is a Flux source, a GitRepository, a HelmRepository, an
+// OCIRepository or a Bucket.
+type Source struct {
+	Kind      string
+	Name      string
+	Namespace string
+	URL       string
+	// Revision is the revision of the latest artifact of the source.
+	Revision string
+	Ready    *metav1.Condition
+}
+
+// GetAutomation reads the Kustomization or the HelmRelease and its source. A
+// source that can't be read is left out, the automation is still returned.
+func (service *defaultFetcher) GetAutomation(
+	ctx context.Context,
+	clusterName string,
+	clusterClient clustersmngr.Client,
+	ref AutomationReference,
+) (*Automation, error) {
+	object, err := getAutomationObject(ctx, clusterName, clusterClient, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	automation := &Automation{AutomationReference: ref}
+
+	var sourceRef AutomationReference
+
+	switch o := object.(type) {
+	case *kustomizev2.Kustomization:
+		automation.Suspended = o.Spec.Suspend
+		automation.Ready = apimeta.FindStatusCondition(o.GetConditions(), meta.ReadyCondition)
+		automation.LastAppliedRevision = o.Status.LastAppliedRevision
+		automation.LastAttemptedRevision = o.Status.LastAttemptedRevision
+		sourceRef = kustomizationSourceRef(o)
+	case *helmv2.HelmRelease:
+		automation.Suspended = o.Spec.Suspend
+		automation.Ready = apimeta.FindStatusCondition(o.GetConditions(), meta.ReadyCondition)
+		automation.LastAppliedRevision = o.Status.LastAppliedRevision
+		automation.LastAttemptedRevision = o.Status.LastAttemptedRevision
+		sourceRef = AutomationReference{
+			Kind:      o.Spec.Chart.Spec.SourceRef.Kind,
+			Name:      o.Spec.Chart.Spec.SourceRef.Name,
+			Namespace: o.Spec.Chart.GetNamespace(o.GetNamespace()),
+		}
+	}
+
+	source, err := service.getSource(ctx, clusterName, clusterClient, sourceRef)
+	if err != nil {
+		service.logger.V(1).Info("unable to read automation source", "kind", sourceRef.Kind, "name", sourceRef.Name, "namespace", sourceRef.Namespace, "error", err)
+		return automation, nil
+	}
+
+	automation.Source = source
+
+	if source.Kind == sourcev1.GitRepositoryKind {
+		// A Kustomization applies the revision of its source, a HelmRelease
+		// the version of the chart built from it.
+		revision := automation.LastAppliedRevision
+		if ref.Kind == HelmReleaseKind {
+			revision = source.Revision
+		}
+
+		automation.Commit = CommitOf(revision)
+	}
+
+	return automation, nil
+}
+
+// getAutomationObject reads a Kustomization or a HelmRelease.
+func getAutomationObject(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, ref AutomationReference) (client.Object, error) {
+	var object client.Object
+
+	switch ref.Kind {
+	case KustomizationKind:
+		object = &kustomizev2.Kustomization{}
+	case HelmReleaseKind:
+		object = &helmv2.HelmRelease{}
+	default:
+		return nil, UnsupportedAutomationKindError{Kind: ref.Kind}
+	}
+
+	key := client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace}
+
+	if err := clusterClient.Get(ctx, clusterName, key, object); err != nil {
+		return nil, err
+	}
+
+	return object, nil
+}
+
+func kustomizationSourceRef(kustomization *kustomizev2.Kustomization) AutomationReference {
+	ref := AutomationReference{
+		Kind:      kustomization.Spec.SourceRef.Kind,
+		Name:      kustomization.Spec.SourceRef.Name,
+		Namespace: kustomization.Spec.SourceRef.Namespace,
+	}
+
+	if ref.Namespace == "" {
+		ref.Namespace = kustomization.GetNamespace()
+	}
+
+	return ref
+}
+
+func (service *defaultFetcher) getSource(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, ref AutomationReference) (*Source, error) {
+	key := client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace}
+
+	object, err := newSourceObject(ref.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := clusterClient.Get(ctx, clusterName, key, object); err != nil {
+		return nil, err
+	}
+
+	source := &Source{
+		Kind:      ref.Kind,
+		Name:      ref.Name,
+		Namespace: ref.Namespace,
+		URL:       sourceURL(object),
+		Ready:     apimeta.FindStatusCondition(object.GetConditions(), meta.ReadyCondition),
+	}
+
+	if artifact := object.GetArtifact(); artifact != nil {
+		source.Revision = artifact.Revision
+	}
+
+	return source, nil
+}
+
+type sourceObject interface {
+	client.Object
+	GetArtifact() *sourcev1.Artifact
+	GetConditions() []metav1.Condition
+}
+
+func newSourceObject(kind string) (sourceObject, error) {
+	switch kind {
+	case sourcev1.GitRepositoryKind:
+		return &sourcev1.GitRepository{}, nil
+	case sourcev1.HelmRepositoryKind:
+		return &sourcev1.HelmRepository{}, nil
+	case sourcev1.OCIRepositoryKind:
+		return &sourcev1.OCIRepository{}, nil
+	case sourcev1.BucketKind:
+		return &sourcev1.Bucket{}, nil
+	default:
+		return nil, UnsupportedSourceKindError{Kind: kind}
+	}
+}
+
+func sourceURL(object sourceObject) string {
+	switch o := object.(type) {
+	case *sourcev1.GitRepository:
+		return o.Spec.URL
+	case *sourcev1.HelmRepository:
+		return o.Spec.URL
+	case *sourcev1.OCIRepository:
+		return o.Spec.URL
+	case *sourcev1.Bucket:
+		return fmt.Sprintf("%s/%s", o.Spec.Endpoint, o.Spec.BucketName)
+	}
+
+	return ""
+}
+
+// commitPattern matches the commit at the end of a Git revision, either
+// "<branch>@sha1:<commit>" or the older "<branch>/<commit>".
+var commitPattern = regexp.MustCompile(`(?:^|[/:])([0-9a-f]{40}|[0-9a-f]{64})$`)
+
+// CommitOf returns the commit of a Git revision, empty if it has none.
+func CommitOf(revision string) string {
+	match := commitPattern.FindStringSubmatch(revision)
+	if match == nil {
+		return ""
+	}
+
+	return match[1]
+}
@@ -0,0 +1,144 @@
+package flux_test
+
+import (
+	"context"
+	"testing"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2beta1"
+	kustomizev2 "github.com/fluxcd/kustomize-controller/api/v1beta2"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flux"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const commit = "8f3a3e1b6f9c2d7a4e5b0c1d2e3f4a5b6c7d8e9f"
+
+func TestFetcher_GetAutomation_Kustomization(t *testing.T) {
+	ctx := context.Background()
+
+	clusterClient, service, err := newService(ctx, k8sEnv)
+	assert.NoError(t, err)
+
+	ns := pdtesting.NewNamespace(ctx, t, k8sEnv.Client)
+
+	repository := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: ns.Name},
+		Spec:       sourcev1.GitRepositorySpec{URL: "https://github.com/stefanprodan/podinfo"},
+	}
+	create(ctx, t, repository, func() {
+		repository.Status.Artifact = &sourcev1.Artifact{Revision: "main@sha1:" + commit}
+		repository.Status.Conditions = []metav1.Condition{readyCondition()}
+	})
+
+	kustomization := &kustomizev2.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: ns.Name},
+		Spec: kustomizev2.KustomizationSpec{
+			SourceRef: kustomizev2.CrossNamespaceSourceReference{Kind: sourcev1.GitRepositoryKind, Name: "podinfo"},
+		},
+	}
+	create(ctx, t, kustomization, func() {
+		kustomization.Status.LastAppliedRevision = "main@sha1:" + commit
+		kustomization.Status.LastAttemptedRevision = "main@sha1:" + commit
+		kustomization.Status.Conditions = []metav1.Condition{readyCondition()}
+	})
+
+	automation, err := service.GetAutomation(ctx, "Default", clusterClient, flux.AutomationReference{
+		Kind:      flux.KustomizationKind,
+		Name:      "podinfo",
+		Namespace: ns.Name,
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "main@sha1:"+commit, automation.LastAppliedRevision)
+	assert.Equal(t, commit, automation.Commit)
+	assert.Equal(t, metav1.ConditionTrue, automation.Ready.Status)
+
+	assert.NotNil(t, automation.Source)
+	assert.Equal(t, sourcev1.GitRepositoryKind, automation.Source.Kind)
+	assert.Equal(t, ns.Name, automation.Source.Namespace)
+	assert.Equal(t, "https://github.com/stefanprodan/podinfo", automation.Source.URL)
+	assert.Equal(t, "main@sha1:"+commit, automation.Source.Revision)
+}
+
+func TestFetcher_GetAutomation_HelmRelease(t *testing.T) {
+	ctx := context.Background()
+
+	clusterClient, service, err := newService(ctx, k8sEnv)
+	assert.NoError(t, err)
+
+	ns := pdtesting.NewNamespace(ctx, t, k8sEnv.Client)
+
+	release := &helmv2.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: ns.Name},
+		Spec: helmv2.HelmReleaseSpec{
+			Chart: helmv2.HelmChartTemplate{
+				Spec: helmv2.HelmChartTemplateSpec{
+					Chart:     "podinfo",
+					SourceRef: helmv2.CrossNamespaceObjectReference{Kind: sourcev1.HelmRepositoryKind, Name: "missing"},
+				},
+			},
+		},
+	}
+	create(ctx, t, release, func() {
+		release.Status.LastAppliedRevision = "6.3.5"
+	})
+
+	// The missing source is left out.
+	automation, err := service.GetAutomation(ctx, "Default", clusterClient, flux.AutomationReference{
+		Kind:      flux.HelmReleaseKind,
+		Name:      "podinfo",
+		Namespace: ns.Name,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "6.3.5", automation.LastAppliedRevision)
+	assert.Nil(t, automation.Ready)
+	assert.Nil(t, automation.Source)
+	assert.Empty(t, automation.Commit)
+
+	_, err = service.GetAutomation(ctx, "Default", clusterClient, flux.AutomationReference{
+		Kind:      flux.KustomizationKind,
+		Name:      "missing",
+		Namespace: ns.Name,
+	})
+	assert.Error(t, err)
+}
+
+func TestCommitOf(t *testing.T) {
+	tests := []struct {
+		revision string
+		expected string
+	}{
+		{revision: "main@sha1:" + commit, expected: commit},
+		{revision: "main/" + commit, expected: commit},
+		{revision: commit, expected: commit},
+		{revision: "6.3.5", expected: ""},
+		{revision: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.revision, func(t *testing.T) {
+			assert.Equal(t, tt.expected, flux.CommitOf(tt.revision))
+		})
+	}
+}
+
+func create(ctx context.Context, t *testing.T, obj client.Object, setStatus func()) {
+	assert.NoError(t, k8sEnv.Client.Create(ctx, obj))
+
+	setStatus()
+
+	assert.NoError(t, k8sEnv.Client.Status().Update(ctx, obj))
+}
+
+func readyCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:               meta.ReadyCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             meta.SucceededReason,
+		LastTransitionTime: metav1.Now(),
+	}
+}
@@ -0,0 +1,89 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2beta1"
+	kustomizev2 "github.com/fluxcd/kustomize-controller/api/v1beta2"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type ReconcileOptions struct {
+	// WithSource requests the reconciliation of the source first, the
+	// GitRepository of a Kustomization or the HelmChart of a HelmRelease, so
+	// its latest revision is applied.
+	WithSource bool
+}
+
+// RequestReconcile sets the reconcile.fluxcd.io/requestedAt annotation of the
+// Kustomization or the HelmRelease, the same way the flux CLI does, and
+// returns the time set.
+func (service *defaultFetcher) RequestReconcile(
+	ctx context.Context,
+	clusterName string,
+	clusterClient clustersmngr.Client,
+	ref AutomationReference,
+	opts ReconcileOptions,
+) (time.Time, error) {
+	object, err := getAutomationObject(ctx, clusterName, clusterClient, ref)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	requestedAt := time.Now()
+
+	if opts.WithSource {
+		var source client.Object
+
+		switch o := object.(type) {
+		case *kustomizev2.Kustomization:
+			sourceRef := kustomizationSourceRef(o)
+
+			source, err = newSourceObject(sourceRef.Kind)
+			if err != nil {
+				return time.Time{}, err
+			}
+
+			source.SetName(sourceRef.Name)
+			source.SetNamespace(sourceRef.Namespace)
+		case *helmv2.HelmRelease:
+			source = &sourcev1.HelmChart{}
+			source.SetName(o.GetHelmChartName())
+			source.SetNamespace(o.Spec.Chart.GetNamespace(o.GetNamespace()))
+		}
+
+		if err := requestReconcile(ctx, clusterName, clusterClient, source, requestedAt); err != nil {
+			return time.Time{}, fmt.Errorf("requesting reconciliation of source %s/%s: %w", source.GetNamespace(), source.GetName(), err)
+		}
+	}
+
+	if err := requestReconcile(ctx, clusterName, clusterClient, object, requestedAt); err != nil {
+		return time.Time{}, fmt.Errorf("requesting reconciliation of %s %s/%s: %w", ref.Kind, ref.Namespace, ref.Name, err)
+	}
+
+	return requestedAt, nil
+}
+
+// requestReconcile reads the object and patches its reconcile annotation.
+func requestReconcile(ctx context.Context, clusterName string, clusterClient clustersmngr.Client, object client.Object, requestedAt time.Time) error {
+	if err := clusterClient.Get(ctx, clusterName, client.ObjectKeyFromObject(object), object); err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(object.DeepCopyObject().(client.Object))
+
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[meta.ReconcileRequestAnnotation] = requestedAt.Format(time.RFC3339Nano)
+	object.SetAnnotations(annotations)
+
+	return clusterClient.Patch(ctx, clusterName, object, patch)
+}
@@ -0,0 +1,101 @@
+package flux_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2beta1"
+	kustomizev2 "github.com/fluxcd/kustomize-controller/api/v1beta2"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/internal/pdtesting"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flux"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestFetcher_RequestReconcile_Kustomization(t *testing.T) {
+	ctx := context.Background()
+
+	clusterClient, service, err := newService(ctx, k8sEnv)
+	assert.NoError(t, err)
+
+	ns := pdtesting.NewNamespace(ctx, t, k8sEnv.Client)
+
+	repository := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: ns.Name},
+		Spec:       sourcev1.GitRepositorySpec{URL: "https://github.com/stefanprodan/podinfo"},
+	}
+	assert.NoError(t, k8sEnv.Client.Create(ctx, repository))
+
+	kustomization := &kustomizev2.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: ns.Name},
+		Spec: kustomizev2.KustomizationSpec{
+			SourceRef: kustomizev2.CrossNamespaceSourceReference{Kind: sourcev1.GitRepositoryKind, Name: "podinfo"},
+		},
+	}
+	assert.NoError(t, k8sEnv.Client.Create(ctx, kustomization))
+
+	ref := flux.AutomationReference{Kind: flux.KustomizationKind, Name: "podinfo", Namespace: ns.Name}
+
+	requestedAt, err := service.RequestReconcile(ctx, "Default", clusterClient, ref, flux.ReconcileOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, requestedAt.Format(time.RFC3339Nano), requestedAnnotation(ctx, t, kustomization))
+	assert.Empty(t, requestedAnnotation(ctx, t, repository))
+
+	requestedAt, err = service.RequestReconcile(ctx, "Default", clusterClient, ref, flux.ReconcileOptions{WithSource: true})
+	assert.NoError(t, err)
+	assert.Equal(t, requestedAt.Format(time.RFC3339Nano), requestedAnnotation(ctx, t, kustomization))
+	assert.Equal(t, requestedAt.Format(time.RFC3339Nano), requestedAnnotation(ctx, t, repository))
+}
+
+func TestFetcher_RequestReconcile_HelmRelease(t *testing.T) {
+	ctx := context.Background()
+
+	clusterClient, service, err := newService(ctx, k8sEnv)
+	assert.NoError(t, err)
+
+	ns := pdtesting.NewNamespace(ctx, t, k8sEnv.Client)
+
+	release := &helmv2.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: ns.Name},
+		Spec: helmv2.HelmReleaseSpec{
+			Chart: helmv2.HelmChartTemplate{
+				Spec: helmv2.HelmChartTemplateSpec{
+					Chart:     "podinfo",
+					SourceRef: helmv2.CrossNamespaceObjectReference{Kind: sourcev1.HelmRepositoryKind, Name: "podinfo"},
+				},
+			},
+		},
+	}
+	assert.NoError(t, k8sEnv.Client.Create(ctx, release))
+
+	ref := flux.AutomationReference{Kind: flux.HelmReleaseKind, Name: "podinfo", Namespace: ns.Name}
+
+	// The chart isn't created yet.
+	_, err = service.RequestReconcile(ctx, "Default", clusterClient, ref, flux.ReconcileOptions{WithSource: true})
+	assert.Error(t, err)
+	assert.Empty(t, requestedAnnotation(ctx, t, release))
+
+	chart := &sourcev1.HelmChart{
+		ObjectMeta: metav1.ObjectMeta{Name: release.GetHelmChartName(), Namespace: ns.Name},
+		Spec: sourcev1.HelmChartSpec{
+			Chart:     "podinfo",
+			SourceRef: sourcev1.LocalHelmChartSourceReference{Kind: sourcev1.HelmRepositoryKind, Name: "podinfo"},
+		},
+	}
+	assert.NoError(t, k8sEnv.Client.Create(ctx, chart))
+
+	requestedAt, err := service.RequestReconcile(ctx, "Default", clusterClient, ref, flux.ReconcileOptions{WithSource: true})
+	assert.NoError(t, err)
+	assert.Equal(t, requestedAt.Format(time.RFC3339Nano), requestedAnnotation(ctx, t, release))
+	assert.Equal(t, requestedAt.Format(time.RFC3339Nano), requestedAnnotation(ctx, t, chart))
+}
+
+func requestedAnnotation(ctx context.Context, t *testing.T, obj client.Object) string {
+	assert.NoError(t, k8sEnv.Client.Get(ctx, client.ObjectKeyFromObject(obj), obj))
+
+	return obj.GetAnnotations()[meta.ReconcileRequestAnnotation]
+}
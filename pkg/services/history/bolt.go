@@ -0,0 +1,244 @@
+package history
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var revisionsBucket = []byte("revisions")
+
+const openTimeout = 5 * time.Second
+
+// pruneInterval is how often revisions older than the retention are removed,
+// revisions of Canaries that are no longer recorded included.
+const pruneInterval = time.Hour
+
+// NewBoltStore opens, or creates, a BoltDB file at path. Revisions are keyed
+// by cluster, namespace, name, timestamp and sequence number, so the history
+// of a Canary can be read with a single prefix scan. Timestamps have a second
+// precision, the sequence number keeps revisions of the same second apart.
+//
+// Revisions beyond the retention are removed as revisions of the same Canary
+// are recorded, and every pruneInterval until the store is closed.
+func NewBoltStore(path string, retention Retention) (Store, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("failed opening history database %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(revisionsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed creating history bucket: %w", err)
+	}
+
+	store := &boltStore{db: db, retention: retention, done: make(chan struct{})}
+
+	if retention.MaxAge > 0 {
+		go store.pruneEvery(pruneInterval)
+	}
+
+	return store, nil
+}
+
+type boltStore struct {
+	db        *bolt.DB
+	retention Retention
+	// done is closed when the store is closed, to stop pruning.
+	done chan struct{}
+}
+
+func (s *boltStore) Record(revision Revision) error {
+	value, err := json.Marshal(revision)
+	if err != nil {
+		return fmt.Errorf("failed encoding revision: %w", err)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(revisionsBucket)
+
+		seq, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		if err := bucket.Put(revisionKey(revision, seq), value); err != nil {
+			return err
+		}
+
+		return s.pruneCanary(bucket, canaryPrefix(revision.ClusterName, revision.Namespace, revision.Name))
+	})
+}
+
+// pruneCanary removes the revisions of the Canary of the prefix beyond the
+// retention, oldest first.
+func (s *boltStore) pruneCanary(bucket *bolt.Bucket, prefix []byte) error {
+	if s.retention.MaxAge <= 0 && s.retention.MaxRevisions <= 0 {
+		return nil
+	}
+
+	keys := [][]byte{}
+
+	c := bucket.Cursor()
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		keys = append(keys, append([]byte{}, k...))
+	}
+
+	expired := 0
+	if s.retention.MaxRevisions > 0 && len(keys) > s.retention.MaxRevisions {
+		expired = len(keys) - s.retention.MaxRevisions
+	}
+
+	for expired < len(keys) && s.expired(keys[expired]) {
+		expired++
+	}
+
+	for _, k := range keys[:expired] {
+		if err := bucket.Delete(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// prune removes the revisions of every Canary older than the retention.
+func (s *boltStore) prune() error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(revisionsBucket)
+
+		expired := [][]byte{}
+
+		if err := bucket.ForEach(func(k, _ []byte) error {
+			if s.expired(k) {
+				expired = append(expired, append([]byte{}, k...))
+			}
+
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, k := range expired {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *boltStore) pruneEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			// Failures are retried on the next tick, and revisions are
+			// pruned as they're recorded meanwhile.
+			_ = s.prune()
+		}
+	}
+}
+
+// expired reports whether the revision of the key is older than MaxAge.
+func (s *boltStore) expired(key []byte) bool {
+	if s.retention.MaxAge <= 0 || len(key) < 16 {
+		return false
+	}
+
+	timestamp := time.Unix(0, int64(binary.BigEndian.Uint64(key[len(key)-16:])))
+
+	return time.Since(timestamp) > s.retention.MaxAge
+}
+
+func (s *boltStore) Last(clusterName, namespace, name string) (*Revision, error) {
+	var result *Revision
+
+	prefix := canaryPrefix(clusterName, namespace, name)
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(revisionsBucket).Cursor()
+
+		// Seek to the first key after the prefix, the one before it is the
+		// latest revision of the canary if it has any.
+		upper := append(append([]byte{}, prefix...), 0xff)
+
+		k, v := c.Seek(upper)
+		if k == nil {
+			k, v = c.Last()
+		} else {
+			k, v = c.Prev()
+		}
+
+		if k == nil || !bytes.HasPrefix(k, prefix) {
+			return nil
+		}
+
+		result = &Revision{}
+
+		return json.Unmarshal(v, result)
+	})
+
+	return result, err
+}
+
+func (s *boltStore) List(opts ListOptions) ([]Revision, error) {
+	result := []Revision{}
+
+	var prefix []byte
+	if opts.ClusterName != "" && opts.Namespace != "" && opts.Name != "" {
+		prefix = canaryPrefix(opts.ClusterName, opts.Namespace, opts.Name)
+	}
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(revisionsBucket).Cursor()
+
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			revision := Revision{}
+			if err := json.Unmarshal(v, &revision); err != nil {
+				return fmt.Errorf("failed decoding revision %q: %w", k, err)
+			}
+
+			if opts.matches(revision) {
+				result = append(result, revision)
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+func (s *boltStore) Close() error {
+	close(s.done)
+
+	return s.db.Close()
+}
+
+func canaryPrefix(clusterName, namespace, name string) []byte {
+	return []byte(fmt.Sprintf("%s\x00%s\x00%s\x00", clusterName, namespace, name))
+}
+
+func revisionKey(revision Revision, seq uint64) []byte {
+	key := canaryPrefix(revision.ClusterName, revision.Namespace, revision.Name)
+
+	suffix := make([]byte, 16)
+	binary.BigEndian.PutUint64(suffix, uint64(revision.Timestamp.UnixNano()))
+	binary.BigEndian.PutUint64(suffix[8:], seq)
+
+	return append(key, suffix...)
+}
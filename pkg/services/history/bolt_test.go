@@ -0,0 +1,149 @@
+package history_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/progressive-delivery/pkg/services/history"
+)
+
+func TestBoltStore_Last(t *testing.T) {
+	store := newStore(t)
+
+	last, err := store.Last("Default", "test", "podinfo")
+	require.NoError(t, err)
+	assert.Nil(t, last, "canary without revisions has no last revision")
+
+	now := time.Now()
+
+	require.NoError(t, store.Record(history.Revision{ClusterName: "Default", Namespace: "test", Name: "podinfo", Phase: "Progressing", Timestamp: now.Add(-time.Minute)}))
+	require.NoError(t, store.Record(history.Revision{ClusterName: "Default", Namespace: "test", Name: "podinfo", Phase: "Succeeded", Timestamp: now}))
+	require.NoError(t, store.Record(history.Revision{ClusterName: "Default", Namespace: "test", Name: "podinfo-other", Phase: "Failed", Timestamp: now.Add(time.Minute)}))
+
+	last, err = store.Last("Default", "test", "podinfo")
+	require.NoError(t, err)
+	require.NotNil(t, last)
+	assert.Equal(t, "Succeeded", last.Phase)
+
+	last, err = store.Last("Default", "test", "podinfo-other")
+	require.NoError(t, err)
+	require.NotNil(t, last)
+	assert.Equal(t, "Failed", last.Phase)
+}
+
+func TestBoltStore_List(t *testing.T) {
+	store := newStore(t)
+
+	now := time.Now().UTC()
+
+	revisions := []history.Revision{
+		{ClusterName: "Default", Namespace: "test", Name: "podinfo", Phase: "Progressing", Timestamp: now.Add(-2 * time.Hour)},
+		{ClusterName: "Default", Namespace: "test", Name: "podinfo", Phase: "Succeeded", Timestamp: now.Add(-time.Hour), PromotedImageVersions: map[string]string{"podinfo": "podinfo:6.0.1"}},
+		{ClusterName: "Default", Namespace: "other", Name: "podinfo", Phase: "Failed", Timestamp: now},
+		{ClusterName: "Remote", Namespace: "test", Name: "podinfo", Phase: "Failed", Timestamp: now},
+	}
+
+	for _, revision := range revisions {
+		require.NoError(t, store.Record(revision))
+	}
+
+	result, err := store.List(history.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, result, 4)
+
+	result, err = store.List(history.ListOptions{ClusterName: "Default", Namespace: "test", Name: "podinfo"})
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.Equal(t, "Progressing", result[0].Phase)
+	assert.Equal(t, "podinfo:6.0.1", result[1].PromotedImageVersions["podinfo"])
+
+	result, err = store.List(history.ListOptions{Namespace: "test", Start: now.Add(-90 * time.Minute)})
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.Equal(t, "Succeeded", result[0].Phase)
+	assert.Equal(t, "Remote", result[1].ClusterName)
+
+	result, err = store.List(history.ListOptions{End: now.Add(-90 * time.Minute)})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "Progressing", result[0].Phase)
+}
+
+func TestBoltStore_SameTimestamp(t *testing.T) {
+	store := newStore(t)
+
+	now := time.Now().Truncate(time.Second)
+
+	require.NoError(t, store.Record(history.Revision{ClusterName: "Default", Namespace: "test", Name: "podinfo", Phase: "Progressing", Timestamp: now}))
+	require.NoError(t, store.Record(history.Revision{ClusterName: "Default", Namespace: "test", Name: "podinfo", Phase: "Failed", Timestamp: now}))
+
+	result, err := store.List(history.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, result, 2, "revisions of the same second are both kept")
+	assert.Equal(t, "Progressing", result[0].Phase)
+	assert.Equal(t, "Failed", result[1].Phase)
+
+	last, err := store.Last("Default", "test", "podinfo")
+	require.NoError(t, err)
+	require.NotNil(t, last)
+	assert.Equal(t, "Failed", last.Phase)
+}
+
+func TestBoltStore_Reopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.db")
+
+	store, err := history.NewBoltStore(path, history.Retention{})
+	require.NoError(t, err)
+	require.NoError(t, store.Record(history.Revision{ClusterName: "Default", Namespace: "test", Name: "podinfo", Phase: "Succeeded", Timestamp: time.Now()}))
+	require.NoError(t, store.Close())
+
+	store, err = history.NewBoltStore(path, history.Retention{})
+	require.NoError(t, err)
+	defer store.Close()
+
+	result, err := store.List(history.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, result, 1)
+}
+
+func TestBoltStore_Retention(t *testing.T) {
+	store, err := history.NewBoltStore(filepath.Join(t.TempDir(), "history.db"), history.Retention{MaxAge: 24 * time.Hour, MaxRevisions: 2})
+	require.NoError(t, err)
+	defer store.Close()
+
+	now := time.Now()
+	record := func(name, phase string, timestamp time.Time) {
+		require.NoError(t, store.Record(history.Revision{ClusterName: "Default", Namespace: "test", Name: name, Phase: phase, Timestamp: timestamp}))
+	}
+
+	record("podinfo", "Initialized", now.Add(-48*time.Hour))
+	record("podinfo", "Progressing", now.Add(-2*time.Minute))
+	record("podinfo", "Promoting", now.Add(-time.Minute))
+	record("podinfo", "Succeeded", now)
+	record("backend", "Initialized", now.Add(-48*time.Hour))
+	record("backend", "Progressing", now)
+
+	result, err := store.List(history.ListOptions{ClusterName: "Default", Namespace: "test", Name: "podinfo"})
+	require.NoError(t, err)
+	require.Len(t, result, 2, "revisions beyond the cap should be removed")
+	assert.Equal(t, []string{"Promoting", "Succeeded"}, []string{result[0].Phase, result[1].Phase})
+
+	result, err = store.List(history.ListOptions{ClusterName: "Default", Namespace: "test", Name: "backend"})
+	require.NoError(t, err)
+	require.Len(t, result, 1, "revisions older than the max age should be removed")
+	assert.Equal(t, "Progressing", result[0].Phase)
+}
+
+func newStore(t *testing.T) history.Store {
+	store, err := history.NewBoltStore(filepath.Join(t.TempDir(), "history.db"), history.Retention{})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		store.Close()
+	})
+
+	return store
+}
@@ -0,0 +1,97 @@
+package history
+
+import (
+	"context"
+	"time"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// Recorder records a revision in the store each time a Canary changes phase.
+type Recorder struct {
+	store           Store
+	flagger         flagger.Fetcher
+	clustersManager clustersmngr.ClustersManager
+	logger          logr.Logger
+}
+
+func NewRecorder(store Store, flaggerService flagger.Fetcher, clustersManager clustersmngr.ClustersManager, logger logr.Logger) *Recorder {
+	return &Recorder{
+		store:           store,
+		flagger:         flaggerService,
+		clustersManager: clustersManager,
+		logger:          logger,
+	}
+}
+
+// Start records revisions for the Canary events until the channel is closed.
+// Canaries are only recorded again if their phase changed since their last
+// revision, so restarts of the server don't record them again.
+func (r *Recorder) Start(ctx context.Context, events <-chan flagger.CanaryEvent) {
+	for event := range events {
+		if event.Type == watch.Deleted {
+			continue
+		}
+
+		if err := r.observe(ctx, event.ClusterName, event.Canary); err != nil {
+			r.logger.Error(err, "failed recording canary revision", "cluster", event.ClusterName, "canary", event.Canary.GetName(), "namespace", event.Canary.GetNamespace())
+		}
+	}
+}
+
+// observe records a revision for the canary if its phase is different from
+// the last recorded one.
+func (r *Recorder) observe(ctx context.Context, clusterName string, canary flaggerv1.Canary) error {
+	phase := string(canary.Status.Phase)
+	if phase == "" {
+		return nil
+	}
+
+	last, err := r.store.Last(clusterName, canary.GetNamespace(), canary.GetName())
+	if err != nil {
+		return err
+	}
+
+	if last != nil && last.Phase == phase {
+		return nil
+	}
+
+	revision := Revision{
+		ClusterName:        clusterName,
+		Namespace:          canary.GetNamespace(),
+		Name:               canary.GetName(),
+		Phase:              phase,
+		Timestamp:          canary.Status.LastTransitionTime.Time,
+		CanaryWeight:       int32(canary.Status.CanaryWeight),
+		FailedChecks:       int32(canary.Status.FailedChecks),
+		Iterations:         int32(canary.Status.Iterations),
+		DeploymentStrategy: string(r.flagger.DeploymentStrategyFor(canary)),
+	}
+
+	if last != nil {
+		revision.PreviousPhase = last.Phase
+	}
+
+	if revision.Timestamp.IsZero() {
+		revision.Timestamp = time.Now()
+	}
+
+	clusterClient, err := r.clustersManager.GetServerClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Ignored intentionally, a revision is still worth recording if the target
+	// or the primary can't be found.
+	target, _ := r.flagger.FetchTargetRef(ctx, clusterName, clusterClient, &canary)
+	promoted, _ := r.flagger.FetchPromoted(ctx, clusterName, clusterClient, &canary)
+
+	revision.AppliedImageVersions = target.Images()
+	revision.PromotedImageVersions = promoted.Images()
+
+	return r.store.Record(revision)
+}
@@ -0,0 +1,81 @@
+package history
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/progressive-delivery/pkg/services/delivery"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/clustersmngrfakes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// fakeFlagger serves the target and primary of every Canary with a single
+// container.
+type fakeFlagger struct {
+	flagger.Fetcher
+}
+
+func (fakeFlagger) DeploymentStrategyFor(flaggerv1.Canary) delivery.DeploymentStrategy {
+	return delivery.DeploymentStrategy("canary")
+}
+
+func (fakeFlagger) FetchTargetRef(context.Context, string, clustersmngr.Client, *flaggerv1.Canary) (flagger.Workload, error) {
+	return flagger.Workload{Containers: []corev1.Container{{Name: "podinfo", Image: "podinfo:6.0.1"}}}, nil
+}
+
+func (fakeFlagger) FetchPromoted(context.Context, string, clustersmngr.Client, *flaggerv1.Canary) (flagger.Workload, error) {
+	return flagger.Workload{Containers: []corev1.Container{{Name: "podinfo", Image: "podinfo:6.0.0"}}}, nil
+}
+
+func TestRecorder_Start(t *testing.T) {
+	store, err := NewBoltStore(filepath.Join(t.TempDir(), "history.db"), Retention{})
+	require.NoError(t, err)
+
+	defer store.Close()
+
+	recorder := NewRecorder(store, fakeFlagger{}, &clustersmngrfakes.FakeClustersManager{}, logr.Discard())
+
+	transition := metav1.NewTime(time.Now().Truncate(time.Second))
+
+	canaryEvent := func(eventType watch.EventType, phase flaggerv1.CanaryPhase, weight int) flagger.CanaryEvent {
+		canary := flaggerv1.Canary{ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "test"}}
+		canary.Status.Phase = phase
+		canary.Status.CanaryWeight = weight
+		canary.Status.LastTransitionTime = transition
+
+		return flagger.CanaryEvent{Type: eventType, ClusterName: "Default", Canary: canary}
+	}
+
+	events := make(chan flagger.CanaryEvent, 5)
+	events <- canaryEvent(watch.Added, flaggerv1.CanaryPhaseProgressing, 10)
+	events <- canaryEvent(watch.Modified, flaggerv1.CanaryPhaseProgressing, 20)
+	events <- canaryEvent(watch.Modified, flaggerv1.CanaryPhaseFailed, 0)
+	events <- canaryEvent(watch.Deleted, flaggerv1.CanaryPhaseFailed, 0)
+	close(events)
+
+	recorder.Start(context.Background(), events)
+
+	revisions, err := store.List(ListOptions{ClusterName: "Default", Namespace: "test", Name: "podinfo"})
+	require.NoError(t, err)
+	require.Len(t, revisions, 2, "weight changes and deletions are not recorded")
+
+	assert.Equal(t, "Progressing", revisions[0].Phase)
+	assert.Equal(t, "", revisions[0].PreviousPhase)
+	assert.Equal(t, int32(10), revisions[0].CanaryWeight)
+	assert.Equal(t, "Failed", revisions[1].Phase)
+	assert.Equal(t, "Progressing", revisions[1].PreviousPhase)
+	assert.True(t, transition.Time.Equal(revisions[1].Timestamp))
+	assert.Equal(t, "canary", revisions[1].DeploymentStrategy)
+	assert.Equal(t, map[string]string{"podinfo": "podinfo:6.0.1"}, revisions[1].AppliedImageVersions)
+	assert.Equal(t, map[string]string{"podinfo": "podinfo:6.0.0"}, revisions[1].PromotedImageVersions)
+}
@@ -0,0 +1,78 @@
+package history
+
+import (
+	"time"
+)
+
+// Revision is a snapshot of a Canary taken when its phase changed.
+type Revision struct {
+	ClusterName           string            `json:"clusterName"`
+	Namespace             string            `json:"namespace"`
+	Name                  string            `json:"name"`
+	Phase                 string            `json:"phase"`
+	PreviousPhase         string            `json:"previousPhase"`
+	Timestamp             time.Time         `json:"timestamp"`
+	CanaryWeight          int32             `json:"canaryWeight"`
+	FailedChecks          int32             `json:"failedChecks"`
+	Iterations            int32             `json:"iterations"`
+	DeploymentStrategy    string            `json:"deploymentStrategy"`
+	AppliedImageVersions  map[string]string `json:"appliedImageVersions"`
+	PromotedImageVersions map[string]string `json:"promotedImageVersions"`
+}
+
+// ListOptions filters revisions. Empty fields match everything, Start and
+// End are inclusive.
+type ListOptions struct {
+	ClusterName string
+	Namespace   string
+	Name        string
+	Start       time.Time
+	End         time.Time
+}
+
+// Retention bounds the revisions kept by a store. Zero fields keep
+// revisions forever.
+type Retention struct {
+	// MaxAge is how long revisions are kept.
+	MaxAge time.Duration
+	// MaxRevisions is the number of revisions kept per Canary, the oldest
+	// are removed beyond it.
+	MaxRevisions int
+}
+
+// Store persists Canary revisions.
+type Store interface {
+	// Record appends a revision to the history of its Canary.
+	Record(revision Revision) error
+	// Last returns the most recent revision of a Canary, or nil if none was
+	// recorded yet.
+	Last(clusterName, namespace, name string) (*Revision, error)
+	// List returns the matching revisions ordered by Canary and time.
+	List(opts ListOptions) ([]Revision, error)
+	// Close releases the resources held by the store.
+	Close() error
+}
+
+func (opts ListOptions) matches(revision Revision) bool {
+	if opts.ClusterName != "" && opts.ClusterName != revision.ClusterName {
+		return false
+	}
+
+	if opts.Namespace != "" && opts.Namespace != revision.Namespace {
+		return false
+	}
+
+	if opts.Name != "" && opts.Name != revision.Name {
+		return false
+	}
+
+	if !opts.Start.IsZero() && revision.Timestamp.Before(opts.Start) {
+		return false
+	}
+
+	if !opts.End.IsZero() && revision.Timestamp.After(opts.End) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,261 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Receiver types, the payload sent depends on it.
+const (
+	SlackReceiver   = "slack"
+	MSTeamsReceiver = "msteams"
+	GenericReceiver = "generic"
+)
+
+// defaultTemplate is the message of rules without a template.
+const defaultTemplate = `Canary {{ .Namespace }}/{{ .Name }} on cluster {{ .ClusterName }} is {{ .Phase }}` +
+	`{{ with .PreviousPhase }} (was {{ . }}){{ end }}`
+
+// Config holds the receivers notifications are sent to and the rules sending
+// them, read from a YAML file:
+//
+//	receivers:
+//	  - name: payments-slack
+//	    type: slack
+//	    url: https://hooks.slack.com/services/...
+//	rules:
+//	  - name: payments-failures
+//	    receivers: [payments-slack]
+//	    transitions:
+//	      - from: Progressing
+//	        to: Failed
+//	    clusters: ["prod-*"]
+//	    selector: team=payments
+type Config struct {
+	Receivers []Receiver `yaml:"receivers"`
+	Rules     []Rule     `yaml:"rules"`
+	// Retries is the number of times a failed delivery is retried.
+	Retries int `yaml:"retries"`
+	// Backoff is the wait before the first retry, doubled on each one.
+	Backoff time.Duration `yaml:"backoff"`
+}
+
+type Receiver struct {
+	Name string `yaml:"name"`
+	// Type is slack, msteams or generic. Generic receivers get the
+	// notification as JSON.
+	Type string `yaml:"type"`
+	URL  string `yaml:"url"`
+}
+
+type Rule struct {
+	Name      string   `yaml:"name"`
+	Receivers []string `yaml:"receivers"`
+	// Transitions are the phase changes notified, all of them if empty.
+	Transitions []Transition `yaml:"transitions"`
+	// Clusters and Namespaces are glob patterns, any if empty.
+	Clusters   []string `yaml:"clusters"`
+	Namespaces []string `yaml:"namespaces"`
+	// Selector is a label selector on the Canaries, any if empty.
+	Selector string `yaml:"selector"`
+	// Template is a text/template of the message, executed with the
+	// Notification.
+	Template string `yaml:"template"`
+
+	selector labels.Selector
+	template *template.Template
+}
+
+// Transition is a phase change of a Canary, empty phases match any.
+type Transition struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
+}
+
+// LoadConfig reads and validates the config file.
+func LoadConfig(filename string) (*Config, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseConfig(data)
+}
+
+// ParseConfig parses and validates a config, unknown fields are rejected.
+func ParseConfig(data []byte) (*Config, error) {
+	config := &Config{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(config); err != nil {
+		return nil, fmt.Errorf("parsing notification config: %w", err)
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid notification config: %w", err)
+	}
+
+	return config, nil
+}
+
+func (c *Config) validate() error {
+	if c.Retries < 0 {
+		return fmt.Errorf("retries must not be negative")
+	}
+
+	receivers := map[string]bool{}
+
+	for _, receiver := range c.Receivers {
+		if receiver.Name == "" {
+			return fmt.Errorf("receiver without a name")
+		}
+
+		if receivers[receiver.Name] {
+			return fmt.Errorf("duplicate receiver %q", receiver.Name)
+		}
+
+		receivers[receiver.Name] = true
+
+		switch receiver.Type {
+		case SlackReceiver, MSTeamsReceiver, GenericReceiver:
+		default:
+			return fmt.Errorf("receiver %q has unknown type %q", receiver.Name, receiver.Type)
+		}
+
+		if u, err := url.Parse(receiver.URL); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("receiver %q has an invalid url", receiver.Name)
+		}
+	}
+
+	rules := map[string]bool{}
+
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+
+		if rule.Name == "" {
+			return fmt.Errorf("rule without a name")
+		}
+
+		if rules[rule.Name] {
+			return fmt.Errorf("duplicate rule %q", rule.Name)
+		}
+
+		rules[rule.Name] = true
+
+		if len(rule.Receivers) == 0 {
+			return fmt.Errorf("rule %q has no receivers", rule.Name)
+		}
+
+		for _, name := range rule.Receivers {
+			if !receivers[name] {
+				return fmt.Errorf("rule %q references unknown receiver %q", rule.Name, name)
+			}
+		}
+
+		for _, pattern := range append(append([]string{}, rule.Clusters...), rule.Namespaces...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("rule %q has an invalid pattern %q", rule.Name, pattern)
+			}
+		}
+
+		selector, err := labels.Parse(rule.Selector)
+		if err != nil {
+			return fmt.Errorf("rule %q has an invalid selector: %w", rule.Name, err)
+		}
+
+		rule.selector = selector
+
+		text := rule.Template
+		if text == "" {
+			text = defaultTemplate
+		}
+
+		tmpl, err := template.New(rule.Name).Parse(text)
+		if err != nil {
+			return fmt.Errorf("rule %q has an invalid template: %w", rule.Name, err)
+		}
+
+		rule.template = tmpl
+	}
+
+	return nil
+}
+
+func (c *Config) receiver(name string) (Receiver, bool) {
+	for _, receiver := range c.Receivers {
+		if receiver.Name == name {
+			return receiver, true
+		}
+	}
+
+	return Receiver{}, false
+}
+
+func (c *Config) rule(name string) (*Rule, bool) {
+	for i := range c.Rules {
+		if c.Rules[i].Name == name {
+			return &c.Rules[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// Matches reports whether the rule notifies the notification.
+func (r *Rule) Matches(notification Notification) bool {
+	if !matchesAny(r.Clusters, notification.ClusterName) || !matchesAny(r.Namespaces, notification.Namespace) {
+		return false
+	}
+
+	if r.selector != nil && !r.selector.Matches(labels.Set(notification.Labels)) {
+		return false
+	}
+
+	if len(r.Transitions) == 0 {
+		return true
+	}
+
+	for _, transition := range r.Transitions {
+		if (transition.From == "" || transition.From == notification.PreviousPhase) &&
+			(transition.To == "" || transition.To == notification.Phase) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Message executes the template of the rule.
+func (r *Rule) Message(notification Notification) (string, error) {
+	buf := &bytes.Buffer{}
+
+	if err := r.template.Execute(buf, notification); err != nil {
+		return "", fmt.Errorf("executing template of rule %q: %w", r.Name, err)
+	}
+
+	return buf.String(), nil
+}
+
+func matchesAny(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, value); ok {
+			return true
+		}
+	}
+
+	return false
+}
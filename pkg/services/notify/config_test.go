@@ -0,0 +1,84 @@
+package notify_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/pkg/services/notify"
+)
+
+const testConfig = `
+receivers:
+  - name: sink
+    type: generic
+    url: http://localhost:9999/hook
+rules:
+  - name: prod-failures
+    receivers: [sink]
+    transitions:
+      - from: Progressing
+        to: Failed
+      - to: Succeeded
+    clusters: ["prod-*"]
+    selector: team=payments
+  - name: everything
+    receivers: [sink]
+    template: "{{ .Name }} {{ .Phase }}"
+`
+
+func TestParseConfig(t *testing.T) {
+	config, err := notify.ParseConfig([]byte(testConfig))
+	assert.NoError(t, err)
+	assert.Len(t, config.Rules, 2)
+
+	failures := config.Rules[0]
+
+	notification := notify.Notification{
+		ClusterName:   "prod-eu",
+		Namespace:     "payments",
+		Name:          "backend",
+		Labels:        map[string]string{"team": "payments"},
+		PreviousPhase: "Progressing",
+		Phase:         "Failed",
+	}
+	assert.True(t, failures.Matches(notification))
+
+	notification.PreviousPhase = "Promoting"
+	assert.False(t, failures.Matches(notification), "only failures of progressing canaries match")
+
+	notification.Phase = "Succeeded"
+	assert.True(t, failures.Matches(notification), "successes match from any phase")
+
+	notification.ClusterName = "staging"
+	assert.False(t, failures.Matches(notification))
+
+	notification.ClusterName = "prod-us"
+	notification.Labels = map[string]string{"team": "search"}
+	assert.False(t, failures.Matches(notification))
+
+	message, err := config.Rules[1].Message(notification)
+	assert.NoError(t, err)
+	assert.Equal(t, "backend Succeeded", message)
+}
+
+func TestParseConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+	}{
+		{name: "unknown field", config: "rules:\n  - name: a\n    unknown: true\n"},
+		{name: "unknown receiver type", config: "receivers:\n  - name: a\n    type: pager\n    url: http://localhost\n"},
+		{name: "invalid url", config: "receivers:\n  - name: a\n    type: slack\n    url: localhost\n"},
+		{name: "unknown receiver", config: "rules:\n  - name: a\n    receivers: [missing]\n"},
+		{name: "no receivers", config: "rules:\n  - name: a\n"},
+		{name: "invalid selector", config: "receivers:\n  - name: a\n    type: slack\n    url: http://localhost\nrules:\n  - name: a\n    receivers: [a]\n    selector: '!!'\n"},
+		{name: "invalid template", config: "receivers:\n  - name: a\n    type: slack\n    url: http://localhost\nrules:\n  - name: a\n    receivers: [a]\n    template: '{{ .Name'\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := notify.ParseConfig([]byte(tt.config))
+			assert.Error(t, err)
+		})
+	}
+}
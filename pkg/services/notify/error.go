@@ -0,0 +1,11 @@
+package notify
+
+import "fmt"
+
+type RuleNotFoundError struct {
+	Name string
+}
+
+func (e RuleNotFoundError) Error() string {
+	return fmt.Sprintf("notification rule %s not found", e.Name)
+}
@@ -0,0 +1,224 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// queueSize is the number of notifications queued per receiver, observing
+// events blocks once a receiver's queue is full.
+const queueSize = 100
+
+// Notifier sends a notification through the matching rules each time a
+// Canary changes phase.
+type Notifier struct {
+	// phases are the last phases seen, by cluster, namespace and name.
+	phases map[string]string
+	// queues hold the notifications of each receiver, by name. They're sent
+	// one at a time so a receiver gets them in the order of the phases.
+	queues map[string]chan Notification
+	// deliveries tracks the goroutines sending the queued notifications.
+	deliveries sync.WaitGroup
+
+	config *Config
+	sender *sender
+	logger logr.Logger
+}
+
+func NewNotifier(config *Config, logger logr.Logger) *Notifier {
+	queues := map[string]chan Notification{}
+	for _, receiver := range config.Receivers {
+		queues[receiver.Name] = make(chan Notification, queueSize)
+	}
+
+	return &Notifier{
+		phases: map[string]string{},
+		queues: queues,
+		config: config,
+		sender: newSender(config),
+		logger: logger,
+	}
+}
+
+// Rules returns the configured rules.
+func (n *Notifier) Rules() []Rule {
+	return n.config.Rules
+}
+
+// Receiver returns a configured receiver.
+func (n *Notifier) Receiver(name string) (Receiver, bool) {
+	return n.config.receiver(name)
+}
+
+// Start sends notifications for the Canary events until the channel is
+// closed, and returns once the queued notifications are sent. The phase a
+// Canary has when it's first seen is not notified, there is no previous phase
+// to compare it to, so restarts of the server don't notify every Canary
+// again.
+func (n *Notifier) Start(ctx context.Context, events <-chan flagger.CanaryEvent) {
+	for name, queue := range n.queues {
+		n.deliveries.Add(1)
+
+		go func(name string, queue <-chan Notification) {
+			defer n.deliveries.Done()
+
+			n.deliver(ctx, name, queue)
+		}(name, queue)
+	}
+
+	for event := range events {
+		n.observe(ctx, event)
+	}
+
+	for _, queue := range n.queues {
+		close(queue)
+	}
+
+	n.deliveries.Wait()
+}
+
+// deliver sends the notifications queued for a receiver in order, until the
+// queue is closed.
+func (n *Notifier) deliver(ctx context.Context, name string, queue <-chan Notification) {
+	receiver, _ := n.config.receiver(name)
+
+	for notification := range queue {
+		if err := n.sender.send(ctx, receiver, notification); err != nil {
+			n.logger.Error(err, "failed sending notification", "rule", notification.Rule, "receiver", name,
+				"cluster", notification.ClusterName, "canary", notification.Name, "namespace", notification.Namespace)
+		}
+	}
+}
+
+// observe sends a notification if the Canary changed phase since it was last
+// seen.
+func (n *Notifier) observe(ctx context.Context, event flagger.CanaryEvent) {
+	key := fmt.Sprintf("%s/%s/%s", event.ClusterName, event.Canary.GetNamespace(), event.Canary.GetName())
+
+	if event.Type == watch.Deleted {
+		delete(n.phases, key)
+		return
+	}
+
+	phase := string(event.Canary.Status.Phase)
+	if phase == "" {
+		return
+	}
+
+	previous, seen := n.phases[key]
+	n.phases[key] = phase
+
+	if !seen || previous == phase {
+		return
+	}
+
+	notification := newNotification(event.ClusterName, event.Canary)
+	notification.PreviousPhase = previous
+
+	n.notify(ctx, notification)
+}
+
+// notify queues the notification to the receivers of every matching rule.
+func (n *Notifier) notify(ctx context.Context, notification Notification) {
+	for i := range n.config.Rules {
+		rule := &n.config.Rules[i]
+
+		if !rule.Matches(notification) {
+			continue
+		}
+
+		message, err := rule.Message(notification)
+		if err != nil {
+			n.logger.Error(err, "failed rendering notification", "rule", rule.Name,
+				"cluster", notification.ClusterName, "canary", notification.Name, "namespace", notification.Namespace)
+
+			continue
+		}
+
+		ruleNotification := notification
+		ruleNotification.Message = message
+		ruleNotification.Rule = rule.Name
+
+		for _, name := range rule.Receivers {
+			select {
+			case n.queues[name] <- ruleNotification:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// DeliveryResult is the outcome of sending a notification to a receiver.
+type DeliveryResult struct {
+	Receiver string
+	Err      error
+}
+
+// Test sends the notification through the receivers of the rule, whether the
+// rule matches it or not, and returns the result of each delivery.
+func (n *Notifier) Test(ctx context.Context, ruleName string, notification Notification) ([]DeliveryResult, error) {
+	rule, ok := n.config.rule(ruleName)
+	if !ok {
+		return nil, RuleNotFoundError{Name: ruleName}
+	}
+
+	notification.Test = true
+	if notification.Timestamp.IsZero() {
+		notification.Timestamp = time.Now()
+	}
+
+	return n.sendRule(ctx, rule, notification), nil
+}
+
+func (n *Notifier) sendRule(ctx context.Context, rule *Rule, notification Notification) []DeliveryResult {
+	results := []DeliveryResult{}
+
+	message, err := rule.Message(notification)
+	if err != nil {
+		for _, name := range rule.Receivers {
+			results = append(results, DeliveryResult{Receiver: name, Err: err})
+		}
+
+		return results
+	}
+
+	notification.Message = message
+	notification.Rule = rule.Name
+
+	for _, name := range rule.Receivers {
+		receiver, _ := n.config.receiver(name)
+
+		results = append(results, DeliveryResult{
+			Receiver: name,
+			Err:      n.sender.send(ctx, receiver, notification),
+		})
+	}
+
+	return results
+}
+
+func newNotification(clusterName string, canary flaggerv1.Canary) Notification {
+	timestamp := canary.Status.LastTransitionTime.Time
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	return Notification{
+		ClusterName:  clusterName,
+		Namespace:    canary.GetNamespace(),
+		Name:         canary.GetName(),
+		Labels:       canary.GetLabels(),
+		Phase:        string(canary.Status.Phase),
+		CanaryWeight: canary.Status.CanaryWeight,
+		FailedChecks: canary.Status.FailedChecks,
+		Timestamp:    timestamp,
+	}
+}
@@ -0,0 +1,174 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	flaggerv1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/progressive-delivery/pkg/services/flagger"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// sink records the notifications it receives, failing the first failures
+// requests with status.
+type sink struct {
+	mu            sync.Mutex
+	notifications []Notification
+	requests      int
+	failures      int
+	status        int
+}
+
+func (s *sink) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+
+	if s.requests <= s.failures {
+		w.WriteHeader(s.status)
+		return
+	}
+
+	notification := Notification{}
+	_ = json.NewDecoder(r.Body).Decode(&notification)
+
+	s.notifications = append(s.notifications, notification)
+}
+
+func newTestNotifier(t *testing.T, s *sink, rules string) *Notifier {
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+
+	config, err := ParseConfig([]byte(fmt.Sprintf(`
+receivers:
+  - name: sink
+    type: generic
+    url: %s
+retries: 2
+backoff: 1ms
+rules:
+%s`, server.URL, rules)))
+	assert.NoError(t, err)
+
+	return NewNotifier(config, logr.Discard())
+}
+
+func canaryEvent(eventType watch.EventType, phase flaggerv1.CanaryPhase) flagger.CanaryEvent {
+	canary := flaggerv1.Canary{ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "payments"}}
+	canary.Status.Phase = phase
+
+	return flagger.CanaryEvent{Type: eventType, ClusterName: "Default", Canary: canary}
+}
+
+// start runs the notifier over the events and waits for the notifications to
+// be sent.
+func start(notifier *Notifier, events ...flagger.CanaryEvent) {
+	ch := make(chan flagger.CanaryEvent, len(events))
+	for _, event := range events {
+		ch <- event
+	}
+	close(ch)
+
+	notifier.Start(context.Background(), ch)
+}
+
+func TestNotifier_Observe(t *testing.T) {
+	s := &sink{}
+	notifier := newTestNotifier(t, s, `
+  - name: failures
+    receivers: [sink]
+    transitions:
+      - to: Failed
+`)
+
+	start(notifier,
+		// The first phase seen is not a transition.
+		canaryEvent(watch.Added, flaggerv1.CanaryPhaseProgressing),
+		canaryEvent(watch.Modified, flaggerv1.CanaryPhaseProgressing),
+		canaryEvent(watch.Modified, flaggerv1.CanaryPhaseFailed),
+		canaryEvent(watch.Modified, flaggerv1.CanaryPhaseSucceeded),
+		// Deleted Canaries are seen again from scratch.
+		canaryEvent(watch.Deleted, flaggerv1.CanaryPhaseSucceeded),
+		canaryEvent(watch.Added, flaggerv1.CanaryPhaseFailed),
+	)
+
+	assert.Len(t, s.notifications, 1)
+	assert.Equal(t, "Progressing", s.notifications[0].PreviousPhase)
+	assert.Equal(t, "Failed", s.notifications[0].Phase)
+	assert.Equal(t, "failures", s.notifications[0].Rule)
+	assert.Equal(t, "Canary payments/backend on cluster Default is Failed (was Progressing)", s.notifications[0].Message)
+}
+
+func TestNotifier_Order(t *testing.T) {
+	s := &sink{}
+	notifier := newTestNotifier(t, s, `
+  - name: all
+    receivers: [sink]
+`)
+
+	phases := []flaggerv1.CanaryPhase{
+		flaggerv1.CanaryPhaseInitializing,
+		flaggerv1.CanaryPhaseProgressing,
+		flaggerv1.CanaryPhaseWaiting,
+		flaggerv1.CanaryPhaseProgressing,
+		flaggerv1.CanaryPhasePromoting,
+		flaggerv1.CanaryPhaseFinalising,
+		flaggerv1.CanaryPhaseSucceeded,
+	}
+
+	events := []flagger.CanaryEvent{}
+	for _, phase := range phases {
+		events = append(events, canaryEvent(watch.Modified, phase))
+	}
+
+	start(notifier, events...)
+
+	received := []flaggerv1.CanaryPhase{}
+	for _, notification := range s.notifications {
+		received = append(received, flaggerv1.CanaryPhase(notification.Phase))
+	}
+
+	assert.Equal(t, phases[1:], received, "a receiver should get the transitions in order")
+}
+
+func TestNotifier_Test(t *testing.T) {
+	s := &sink{failures: 2, status: http.StatusServiceUnavailable}
+	notifier := newTestNotifier(t, s, `
+  - name: failures
+    receivers: [sink]
+`)
+
+	results, err := notifier.Test(context.Background(), "failures", Notification{Name: "backend", Phase: "Failed"})
+	assert.NoError(t, err)
+	assert.Equal(t, []DeliveryResult{{Receiver: "sink"}}, results)
+	assert.Equal(t, 3, s.requests, "should retry server errors")
+	assert.True(t, s.notifications[0].Test)
+	assert.WithinDuration(t, time.Now(), s.notifications[0].Timestamp, time.Minute)
+
+	_, err = notifier.Test(context.Background(), "missing", Notification{})
+	assert.ErrorIs(t, err, RuleNotFoundError{Name: "missing"})
+}
+
+func TestNotifier_Test_ClientError(t *testing.T) {
+	s := &sink{failures: 1, status: http.StatusBadRequest}
+	notifier := newTestNotifier(t, s, `
+  - name: failures
+    receivers: [sink]
+`)
+
+	results, err := notifier.Test(context.Background(), "failures", Notification{})
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.ErrorAs(t, results[0].Err, &DeliveryError{})
+	assert.Equal(t, 1, s.requests, "should not retry client errors")
+}
@@ -0,0 +1,143 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Delivery defaults, used if not set in the config.
+const (
+	defaultRetries        = 3
+	defaultBackoff        = time.Second
+	deliveryTimeout       = 10 * time.Second
+	maxErrorResponseBytes = 512
+)
+
+// Notification is a phase change of a Canary, templates are executed with it.
+type Notification struct {
+	ClusterName   string            `json:"clusterName"`
+	Namespace     string            `json:"namespace"`
+	Name          string            `json:"name"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	PreviousPhase string            `json:"previousPhase"`
+	Phase         string            `json:"phase"`
+	CanaryWeight  int               `json:"canaryWeight"`
+	FailedChecks  int               `json:"failedChecks"`
+	Timestamp     time.Time         `json:"timestamp"`
+	// Message is the output of the template of the rule.
+	Message string `json:"message"`
+	// Rule is the name of the rule sending the notification.
+	Rule string `json:"rule"`
+	// Test is set on notifications sent by TestNotification.
+	Test bool `json:"test,omitempty"`
+}
+
+// DeliveryError is returned when a receiver rejects a notification.
+type DeliveryError struct {
+	Receiver   string
+	StatusCode int
+	Body       string
+}
+
+func (e DeliveryError) Error() string {
+	return fmt.Sprintf("receiver %s responded with %d: %s", e.Receiver, e.StatusCode, e.Body)
+}
+
+// sender delivers notifications, retrying failed requests with an exponential
+// backoff.
+type sender struct {
+	httpClient *http.Client
+	retries    int
+	backoff    time.Duration
+}
+
+func newSender(config *Config) *sender {
+	s := &sender{
+		httpClient: &http.Client{Timeout: deliveryTimeout},
+		retries:    config.Retries,
+		backoff:    config.Backoff,
+	}
+
+	if s.retries == 0 {
+		s.retries = defaultRetries
+	}
+
+	if s.backoff == 0 {
+		s.backoff = defaultBackoff
+	}
+
+	return s
+}
+
+func (s *sender) send(ctx context.Context, receiver Receiver, notification Notification) error {
+	body, err := payload(receiver, notification)
+	if err != nil {
+		return err
+	}
+
+	backoff := s.backoff
+
+	for attempt := 0; ; attempt++ {
+		retry, err := s.post(ctx, receiver, body)
+		if err == nil || !retry || attempt >= s.retries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+// post sends the payload once, it returns whether a failure is worth
+// retrying: network errors, throttling and server errors.
+func (s *sender) post(ctx context.Context, receiver Receiver, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, receiver.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("sending notification to %s: %w", receiver.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorResponseBytes))
+
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+
+	return retry, DeliveryError{Receiver: receiver.Name, StatusCode: resp.StatusCode, Body: string(respBody)}
+}
+
+// payload returns the body of the request for the type of the receiver.
+func payload(receiver Receiver, notification Notification) ([]byte, error) {
+	switch receiver.Type {
+	case SlackReceiver:
+		return json.Marshal(map[string]string{"text": notification.Message})
+	case MSTeamsReceiver:
+		return json.Marshal(map[string]string{
+			"@type":    "MessageCard",
+			"@context": "https://schema.org/extensions",
+			"summary":  notification.Message,
+			"text":     notification.Message,
+		})
+	default:
+		return json.Marshal(notification)
+	}
+}
@@ -16,6 +16,14 @@ export type GetVersionResponse = {
 export type ListCanariesRequest = {
   clusterName?: string
   pagination?: Types.Pagination
+  namespace?: string
+  phases?: string[]
+  providers?: string[]
+  strategies?: string[]
+  labelSelector?: string
+  nameContains?: string
+  sortBy?: string
+  sortDescending?: boolean
 }
 
 export type ListCanariesResponse = {
@@ -28,6 +36,7 @@ export type GetCanaryRequest = {
   name?: string
   namespace?: string
   clusterName?: string
+  controller?: string
 }
 
 export type GetCanaryResponse = {
@@ -42,6 +51,31 @@ export type IsFlaggerAvailableResponse = {
   clusters?: {[key: string]: boolean}
 }
 
+export type IsArgoRolloutsAvailableRequest = {
+}
+
+export type IsArgoRolloutsAvailableResponse = {
+  clusters?: {[key: string]: boolean}
+}
+
+export type GetClusterCapabilitiesRequest = {
+  clusterName?: string
+}
+
+export type GetClusterCapabilitiesResponse = {
+  clusters?: Types.ClusterCapabilities[]
+}
+
+export type GetCanarySummaryRequest = {
+}
+
+export type GetCanarySummaryResponse = {
+  total?: Types.CanaryCounts
+  clusters?: {[key: string]: Types.CanaryCounts}
+  flaggerUnavailableClusters?: string[]
+  errors?: Types.ListError[]
+}
+
 export type ListMetricTemplatesRequest = {
   clusterName?: string
   pagination?: Types.Pagination
@@ -53,6 +87,21 @@ export type ListMetricTemplatesResponse = {
   errors?: Types.ListError[]
 }
 
+export type RunMetricTemplateRequest = {
+  name?: string
+  namespace?: string
+  clusterName?: string
+  canaryName?: string
+  canaryNamespace?: string
+  interval?: string
+}
+
+export type RunMetricTemplateResponse = {
+  query?: string
+  value?: number
+  checks?: Types.CanaryMetricCheck[]
+}
+
 export type ListCanaryObjectsRequest = {
   name?: string
   namespace?: string
@@ -62,6 +111,124 @@ export type ListCanaryObjectsRequest = {
 export type ListCanaryObjectsResponse = {
   objects?: Types.UnstructuredObject[]
   errors?: Types.ListError[]
+  tree?: Types.UnstructuredObject
+}
+
+export type PromoteCanaryRequest = {
+  name?: string
+  namespace?: string
+  clusterName?: string
+}
+
+export type PromoteCanaryResponse = {
+  canary?: Types.Canary
+}
+
+export type RollbackCanaryRequest = {
+  name?: string
+  namespace?: string
+  clusterName?: string
+}
+
+export type RollbackCanaryResponse = {
+  canary?: Types.Canary
+}
+
+export type PauseCanaryRequest = {
+  name?: string
+  namespace?: string
+  clusterName?: string
+}
+
+export type PauseCanaryResponse = {
+  canary?: Types.Canary
+}
+
+export type ResumeCanaryRequest = {
+  name?: string
+  namespace?: string
+  clusterName?: string
+}
+
+export type ResumeCanaryResponse = {
+  canary?: Types.Canary
+}
+
+export type ReconcileCanaryAutomationRequest = {
+  name?: string
+  namespace?: string
+  clusterName?: string
+  controller?: string
+  withSource?: boolean
+}
+
+export type ReconcileCanaryAutomationResponse = {
+  automation?: Types.Automation
+  requestedAt?: string
+}
+
+export type ValidateCanaryRequest = {
+  clusterName?: string
+  name?: string
+  namespace?: string
+  yaml?: string
+}
+
+export type ValidateCanaryResponse = {
+  findings?: Types.CanaryFinding[]
+  valid?: boolean
+}
+
+export type ListCanaryEventsRequest = {
+  name?: string
+  namespace?: string
+  clusterName?: string
+}
+
+export type ListCanaryEventsResponse = {
+  events?: Types.CanaryEvent[]
+}
+
+export type ListCanaryRevisionsRequest = {
+  name?: string
+  namespace?: string
+  clusterName?: string
+  startTime?: string
+  endTime?: string
+}
+
+export type ListCanaryRevisionsResponse = {
+  revisions?: Types.CanaryRevision[]
+}
+
+export type ListNotificationRulesRequest = {
+}
+
+export type ListNotificationRulesResponse = {
+  rules?: Types.NotificationRule[]
+}
+
+export type TestNotificationRequest = {
+  rule?: string
+  name?: string
+  namespace?: string
+  clusterName?: string
+  previousPhase?: string
+  phase?: string
+}
+
+export type TestNotificationResponse = {
+  deliveries?: Types.NotificationDelivery[]
+}
+
+export type WatchCanariesRequest = {
+  clusterName?: string
+  namespace?: string
+}
+
+export type WatchCanariesResponse = {
+  type?: string
+  canary?: Types.Canary
 }
 
 export class ProgressiveDeliveryService {
@@ -77,10 +244,55 @@ export class ProgressiveDeliveryService {
   static IsFlaggerAvailable(req: IsFlaggerAvailableRequest, initReq?: fm.InitReq): Promise<IsFlaggerAvailableResponse> {
     return fm.fetchReq<IsFlaggerAvailableRequest, IsFlaggerAvailableResponse>(`/v1/pd/crd/flagger?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
   }
+  static IsArgoRolloutsAvailable(req: IsArgoRolloutsAvailableRequest, initReq?: fm.InitReq): Promise<IsArgoRolloutsAvailableResponse> {
+    return fm.fetchReq<IsArgoRolloutsAvailableRequest, IsArgoRolloutsAvailableResponse>(`/v1/pd/crd/argo-rollouts?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
+  }
+  static GetClusterCapabilities(req: GetClusterCapabilitiesRequest, initReq?: fm.InitReq): Promise<GetClusterCapabilitiesResponse> {
+    return fm.fetchReq<GetClusterCapabilitiesRequest, GetClusterCapabilitiesResponse>(`/v1/pd/capabilities?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
+  }
+  static GetCanarySummary(req: GetCanarySummaryRequest, initReq?: fm.InitReq): Promise<GetCanarySummaryResponse> {
+    return fm.fetchReq<GetCanarySummaryRequest, GetCanarySummaryResponse>(`/v1/pd/canary_summary?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
+  }
   static ListMetricTemplates(req: ListMetricTemplatesRequest, initReq?: fm.InitReq): Promise<ListMetricTemplatesResponse> {
     return fm.fetchReq<ListMetricTemplatesRequest, ListMetricTemplatesResponse>(`/v1/pd/metric_templates?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
   }
+  static RunMetricTemplate(req: RunMetricTemplateRequest, initReq?: fm.InitReq): Promise<RunMetricTemplateResponse> {
+    return fm.fetchReq<RunMetricTemplateRequest, RunMetricTemplateResponse>(`/v1/pd/metric_templates/${req["name"]}/run`, {...initReq, method: "POST", body: JSON.stringify(req)})
+  }
   static ListCanaryObjects(req: ListCanaryObjectsRequest, initReq?: fm.InitReq): Promise<ListCanaryObjectsResponse> {
     return fm.fetchReq<ListCanaryObjectsRequest, ListCanaryObjectsResponse>(`/v1/pd/canary_objects?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
   }
+  static PromoteCanary(req: PromoteCanaryRequest, initReq?: fm.InitReq): Promise<PromoteCanaryResponse> {
+    return fm.fetchReq<PromoteCanaryRequest, PromoteCanaryResponse>(`/v1/pd/canaries/${req["name"]}/promote`, {...initReq, method: "POST", body: JSON.stringify(req)})
+  }
+  static RollbackCanary(req: RollbackCanaryRequest, initReq?: fm.InitReq): Promise<RollbackCanaryResponse> {
+    return fm.fetchReq<RollbackCanaryRequest, RollbackCanaryResponse>(`/v1/pd/canaries/${req["name"]}/rollback`, {...initReq, method: "POST", body: JSON.stringify(req)})
+  }
+  static PauseCanary(req: PauseCanaryRequest, initReq?: fm.InitReq): Promise<PauseCanaryResponse> {
+    return fm.fetchReq<PauseCanaryRequest, PauseCanaryResponse>(`/v1/pd/canaries/${req["name"]}/pause`, {...initReq, method: "POST", body: JSON.stringify(req)})
+  }
+  static ResumeCanary(req: ResumeCanaryRequest, initReq?: fm.InitReq): Promise<ResumeCanaryResponse> {
+    return fm.fetchReq<ResumeCanaryRequest, ResumeCanaryResponse>(`/v1/pd/canaries/${req["name"]}/resume`, {...initReq, method: "POST", body: JSON.stringify(req)})
+  }
+  static ReconcileCanaryAutomation(req: ReconcileCanaryAutomationRequest, initReq?: fm.InitReq): Promise<ReconcileCanaryAutomationResponse> {
+    return fm.fetchReq<ReconcileCanaryAutomationRequest, ReconcileCanaryAutomationResponse>(`/v1/pd/canaries/${req["name"]}/reconcile`, {...initReq, method: "POST", body: JSON.stringify(req)})
+  }
+  static ValidateCanary(req: ValidateCanaryRequest, initReq?: fm.InitReq): Promise<ValidateCanaryResponse> {
+    return fm.fetchReq<ValidateCanaryRequest, ValidateCanaryResponse>(`/v1/pd/canaries/validate`, {...initReq, method: "POST", body: JSON.stringify(req)})
+  }
+  static ListCanaryEvents(req: ListCanaryEventsRequest, initReq?: fm.InitReq): Promise<ListCanaryEventsResponse> {
+    return fm.fetchReq<ListCanaryEventsRequest, ListCanaryEventsResponse>(`/v1/pd/canaries/${req["name"]}/events?${fm.renderURLSearchParams(req, ["name"])}`, {...initReq, method: "GET"})
+  }
+  static ListCanaryRevisions(req: ListCanaryRevisionsRequest, initReq?: fm.InitReq): Promise<ListCanaryRevisionsResponse> {
+    return fm.fetchReq<ListCanaryRevisionsRequest, ListCanaryRevisionsResponse>(`/v1/pd/canary_revisions?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
+  }
+  static ListNotificationRules(req: ListNotificationRulesRequest, initReq?: fm.InitReq): Promise<ListNotificationRulesResponse> {
+    return fm.fetchReq<ListNotificationRulesRequest, ListNotificationRulesResponse>(`/v1/pd/notification_rules?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
+  }
+  static TestNotification(req: TestNotificationRequest, initReq?: fm.InitReq): Promise<TestNotificationResponse> {
+    return fm.fetchReq<TestNotificationRequest, TestNotificationResponse>(`/v1/pd/notification_rules/${req["rule"]}/test`, {...initReq, method: "POST", body: JSON.stringify(req)})
+  }
+  static WatchCanaries(req: WatchCanariesRequest, entityNotifier?: fm.NotifyStreamEntityArrival<WatchCanariesResponse>, initReq?: fm.InitReq): Promise<void> {
+    return fm.fetchStreamingRequest<WatchCanariesRequest, WatchCanariesResponse>(`/v1/pd/watch/canaries?${fm.renderURLSearchParams(req, [])}`, entityNotifier, {...initReq, method: "GET"})
+  }
 }
\ No newline at end of file
@@ -25,6 +25,8 @@ export type Canary = {
   deploymentStrategy?: string
   analysis?: CanaryAnalysis
   yaml?: string
+  controller?: string
+  targetWorkload?: CanaryTargetWorkload
 }
 
 export type CanaryTargetReference = {
@@ -58,6 +60,43 @@ export type CanaryTargetDeployment = {
   promotedImageVersions?: {[key: string]: string}
 }
 
+export type CanaryTargetWorkload = {
+  kind?: string
+  name?: string
+  uid?: string
+  resourceVersion?: string
+  fluxLabels?: FluxLabels
+  appliedImageVersions?: {[key: string]: string}
+  promotedImageVersions?: {[key: string]: string}
+  containerDiffs?: CanaryContainerDiff[]
+}
+
+export type CanaryContainerDiff = {
+  name?: string
+  change?: string
+  targetImage?: CanaryImageReference
+  promotedImage?: CanaryImageReference
+  repositoryChanged?: boolean
+  tagChanged?: boolean
+  digestChanged?: boolean
+  env?: CanaryEnvVarDiff[]
+  resourcesChanged?: boolean
+  changedProbes?: string[]
+}
+
+export type CanaryImageReference = {
+  repository?: string
+  tag?: string
+  digest?: string
+}
+
+export type CanaryEnvVarDiff = {
+  name?: string
+  change?: string
+  targetValue?: string
+  promotedValue?: string
+}
+
 export type FluxLabels = {
   kustomizeNamespace?: string
   kustomizeName?: string
@@ -67,6 +106,21 @@ export type Automation = {
   kind?: string
   name?: string
   namespace?: string
+  suspended?: boolean
+  ready?: Condition
+  lastAppliedRevision?: string
+  lastAttemptedRevision?: string
+  commit?: string
+  source?: AutomationSource
+}
+
+export type AutomationSource = {
+  kind?: string
+  name?: string
+  namespace?: string
+  url?: string
+  revision?: string
+  ready?: Condition
 }
 
 export type CanaryAnalysis = {
@@ -96,6 +150,21 @@ export type CanaryMetricThresholdRange = {
   max?: number
 }
 
+export type CanaryCounts = {
+  total?: number
+  phases?: {[key: string]: number}
+  strategies?: {[key: string]: number}
+  providers?: {[key: string]: number}
+}
+
+export type CanaryMetricCheck = {
+  canaryName?: string
+  canaryNamespace?: string
+  metricName?: string
+  thresholdRange?: CanaryMetricThresholdRange
+  withinRange?: boolean
+}
+
 export type CanaryMetricTemplate = {
   clusterName?: string
   name?: string
@@ -103,6 +172,7 @@ export type CanaryMetricTemplate = {
   provider?: MetricProvider
   query?: string
   yaml?: string
+  controller?: string
 }
 
 export type MetricProvider = {
@@ -112,6 +182,33 @@ export type MetricProvider = {
   insecureSkipVerify?: boolean
 }
 
+export type CanaryEvent = {
+  kind?: string
+  type?: string
+  reason?: string
+  message?: string
+  timestamp?: string
+  count?: number
+  objectKind?: string
+  objectName?: string
+  canaryWeight?: number
+}
+
+export type CanaryRevision = {
+  clusterName?: string
+  namespace?: string
+  name?: string
+  phase?: string
+  previousPhase?: string
+  timestamp?: string
+  canaryWeight?: number
+  failedChecks?: number
+  iterations?: number
+  deploymentStrategy?: string
+  appliedImageVersions?: {[key: string]: string}
+  promotedImageVersions?: {[key: string]: string}
+}
+
 export type GroupVersionKind = {
   group?: string
   kind?: string
@@ -128,6 +225,21 @@ export type UnstructuredObject = {
   suspended?: boolean
   clusterName?: string
   images?: string[]
+  children?: UnstructuredObject[]
+  containers?: ContainerStatus[]
+  restarts?: number
+}
+
+export type ContainerStatus = {
+  name?: string
+  image?: string
+  ready?: boolean
+  restartCount?: number
+  state?: string
+  reason?: string
+  message?: string
+  startedAt?: string
+  lastTerminationReason?: string
 }
 
 export type Condition = {
@@ -136,4 +248,54 @@ export type Condition = {
   reason?: string
   message?: string
   timestamp?: string
+}
+
+export type CanaryFinding = {
+  severity?: string
+  field?: string
+  message?: string
+}
+
+export type NotificationRule = {
+  name?: string
+  receivers?: NotificationReceiver[]
+  transitions?: PhaseTransition[]
+  clusters?: string[]
+  namespaces?: string[]
+  selector?: string
+}
+
+export type NotificationReceiver = {
+  name?: string
+  type?: string
+}
+
+export type PhaseTransition = {
+  from?: string
+  to?: string
+}
+
+export type NotificationDelivery = {
+  receiver?: string
+  error?: string
+}
+
+export type ClusterCapabilities = {
+  clusterName?: string
+  flagger?: InstalledAPI
+  flaggerVersion?: string
+  meshProviders?: string[]
+  gatewayApi?: InstalledAPI
+  fluxControllers?: FluxController[]
+  argoRollouts?: InstalledAPI
+}
+
+export type InstalledAPI = {
+  installed?: boolean
+  versions?: string[]
+}
+
+export type FluxController = {
+  name?: string
+  kinds?: string[]
 }
\ No newline at end of file